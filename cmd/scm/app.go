@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+)
+
+// app holds the state shared by the commands.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	options options
+
+	// newClient creates the client configured by the
+	// options. It is replaced by the tests.
+	newClient func(*options) (*scm.Client, error)
+	client    *scm.Client
+}
+
+// options holds the global flags.
+type options struct {
+	kind   string
+	url    string
+	token  string
+	config string
+	server string
+	output string
+}
+
+// register registers the global flags, defaulting to their
+// current values so that the flags parsed before the
+// command are kept.
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.kind, "kind", o.kind, "the driver of the git server, defaults to $GIT_KIND")
+	fs.StringVar(&o.url, "url", o.url, "the URL of the git server, defaults to $GIT_SERVER")
+	fs.StringVar(&o.token, "token", o.token, "the token of the git server, defaults to $GIT_TOKEN")
+	fs.StringVar(&o.config, "config", o.config, "the servers configuration file, defaults to $SCM_CONFIG")
+	fs.StringVar(&o.server, "server", o.server, "the name of the server of the configuration file")
+	fs.StringVar(&o.output, "o", o.output, "the output format: table or json")
+}
+
+// scmClient returns the client, creating it on first use.
+func (a *app) scmClient() (*scm.Client, error) {
+	if a.client == nil {
+		client, err := a.newClient(&a.options)
+		if err != nil {
+			return nil, err
+		}
+		a.client = client
+	}
+	return a.client, nil
+}
+
+// newClient creates the client of the server of the
+// configuration file, or else of the factory environment
+// variables, which the flags override.
+func newClient(o *options) (*scm.Client, error) {
+	config := first(o.config, os.Getenv("SCM_CONFIG"))
+	if config != "" {
+		return newConfigClient(config, o.server)
+	}
+	if o.kind != "" || o.url != "" {
+		// the repository URL would take precedence over
+		// the server of the flags.
+		if err := os.Unsetenv("GIT_REPO_URL"); err != nil {
+			return nil, err
+		}
+	}
+	flags := []struct{ env, value string }{
+		{"GIT_KIND", o.kind},
+		{"GIT_SERVER", o.url},
+		{"GIT_TOKEN", o.token},
+	}
+	for _, flag := range flags {
+		if flag.value == "" {
+			continue
+		}
+		if err := os.Setenv(flag.env, flag.value); err != nil {
+			return nil, err
+		}
+	}
+	return factory.NewClientFromEnvironment()
+}
+
+// newConfigClient creates the client of the named server of
+// the configuration file. The name may be omitted if the
+// file configures a single server.
+func newConfigClient(path, name string) (*scm.Client, error) {
+	config, err := factory.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		if len(config.Servers) != 1 {
+			return nil, fmt.Errorf("the -server flag is required since %s configures %d servers", path, len(config.Servers))
+		}
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+		return config.Servers[0].NewClient()
+	}
+	pool, err := factory.NewClientPool(config)
+	if err != nil {
+		return nil, err
+	}
+	return pool.Client(name)
+}
+
+// first returns the first non-empty value.
+func first(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var contentCommand = &group{
+	name:    "content",
+	summary: "Manage the files of repositories",
+	actions: []*action{
+		{name: "get", usage: "<repo> <path>", summary: "Print a file", run: contentGet},
+		{name: "list", usage: "<repo> [path]", summary: "List the files of a directory", run: contentList},
+		{name: "create", usage: "<repo> <path>", summary: "Create a file from -file or stdin", run: contentCreate},
+		{name: "update", usage: "<repo> <path>", summary: "Update a file from -file or stdin", run: contentUpdate},
+		{name: "delete", usage: "<repo> <path>", summary: "Delete a file", run: contentDelete},
+	},
+}
+
+var fileColumns = []column[*scm.FileEntry]{
+	{"NAME", func(f *scm.FileEntry) string { return f.Name }},
+	{"TYPE", func(f *scm.FileEntry) string { return f.Type }},
+	{"SIZE", func(f *scm.FileEntry) string { return strconv.Itoa(f.Size) }},
+	{"SHA", func(f *scm.FileEntry) string { return f.Sha }},
+}
+
+func contentGet(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	ref := fs.String("ref", "", "the branch, tag or commit, defaults to the default branch")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	content, _, err := client.Contents.Find(ctx, args[0], args[1], *ref)
+	if err != nil {
+		return err
+	}
+	format, err := a.format()
+	if err != nil {
+		return err
+	}
+	if format == outputJSON {
+		return a.printJSON(content)
+	}
+	// print the raw file so that it can be redirected.
+	_, err = a.stdout.Write(content.Data)
+	return err
+}
+
+func contentList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	ref := fs.String("ref", "", "the branch, tag or commit, defaults to the default branch")
+	opts := listFlags(fs)
+	args, err := parse(fs, args, 1, 2)
+	if err != nil {
+		return err
+	}
+	path := ""
+	if len(args) > 1 {
+		path = args[1]
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	files, _, err := client.Contents.List(ctx, args[0], path, *ref, opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, files, fileColumns)
+}
+
+func contentCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	params, file := contentFlags(fs)
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	if params.Data, err = a.readInput(*file); err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Contents.Create(ctx, args[0], args[1], params); err != nil {
+		return err
+	}
+	return a.printDone("created file %s of repository %s", args[1], args[0])
+}
+
+func contentUpdate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	params, file := contentFlags(fs)
+	fs.StringVar(&params.Sha, "sha", "", "the SHA of the file being replaced")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	if params.Data, err = a.readInput(*file); err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Contents.Update(ctx, args[0], args[1], params); err != nil {
+		return err
+	}
+	return a.printDone("updated file %s of repository %s", args[1], args[0])
+}
+
+func contentDelete(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	params, _ := contentFlags(fs)
+	fs.StringVar(&params.Sha, "sha", "", "the SHA of the file being deleted")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Contents.Delete(ctx, args[0], args[1], params); err != nil {
+		return err
+	}
+	return a.printDone("deleted file %s of repository %s", args[1], args[0])
+}
+
+// contentFlags registers the flags of the changes of files.
+func contentFlags(fs *flag.FlagSet) (params *scm.ContentParams, file *string) {
+	params = new(scm.ContentParams)
+	fs.StringVar(&params.Message, "message", "", "the commit message")
+	fs.StringVar(&params.Branch, "branch", "", "the branch to commit to, defaults to the default branch")
+	fs.StringVar(&params.Signature.Name, "author", "", "the name of the author of the commit")
+	fs.StringVar(&params.Signature.Email, "email", "", "the email of the author of the commit")
+	file = fs.String("file", "", "the file holding the new content, defaults to stdin")
+	return params, file
+}
+
+// readInput reads the file, or stdin if no file is given.
+func (a *app) readInput(file string) ([]byte, error) {
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	data, err := io.ReadAll(a.stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var gitCommand = &group{
+	name:    "git",
+	summary: "Inspect the git data of repositories",
+	actions: []*action{
+		{name: "commits", usage: "<repo>", summary: "List the commits of a repository", run: gitCommits},
+		{name: "ref", usage: "<repo> <ref>", summary: "Show the sha of a branch, tag or commit", run: gitRef},
+		{name: "changes", usage: "<repo> <ref>", summary: "List the files changed by a commit", run: gitChanges},
+	},
+}
+
+var commitColumns = []column[*scm.Commit]{
+	{"SHA", func(c *scm.Commit) string { return c.Sha }},
+	{"AUTHOR", func(c *scm.Commit) string { return c.Author.Name }},
+	{"DATE", func(c *scm.Commit) string { return formatTime(c.Author.Date) }},
+	{"MESSAGE", func(c *scm.Commit) string { return firstLine(c.Message) }},
+}
+
+func gitCommits(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := scm.CommitListOptions{}
+	fs.StringVar(&opts.Ref, "ref", "", "the branch, tag or sha to list the commits from, defaults to the default branch")
+	fs.StringVar(&opts.Path, "path", "", "list the commits changing the path")
+	fs.IntVar(&opts.Page, "page", 0, "the page to list")
+	fs.IntVar(&opts.Size, "size", 0, "the size of the pages")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	commits, _, err := client.Git.ListCommits(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, commits, commitColumns)
+}
+
+func gitRef(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	sha, _, err := client.Git.FindRef(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	format, err := a.format()
+	if err != nil {
+		return err
+	}
+	if format == outputJSON {
+		return a.printJSON(map[string]string{"ref": args[1], "sha": sha})
+	}
+	_, err = fmt.Fprintln(a.stdout, sha)
+	return err
+}
+
+func gitChanges(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	changes, _, err := client.Git.ListChanges(ctx, args[0], args[1], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, changes, changeColumns)
+}
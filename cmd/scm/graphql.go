@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+var graphqlCommand = &group{
+	name:    "graphql",
+	summary: "Search the pull requests using the GraphQL API",
+	usage:   "<query>",
+	run:     graphqlSearch,
+}
+
+// graphqlPullRequest is a pull request found by the search.
+type graphqlPullRequest struct {
+	Number     githubql.Int
+	Title      githubql.String
+	State      githubql.PullRequestState
+	HeadRefOID githubql.String `graphql:"headRefOid"`
+	Author     struct {
+		Login githubql.String
+	}
+	Repository struct {
+		NameWithOwner githubql.String
+	}
+}
+
+// graphqlSearchQuery is a page of the search of the pull
+// requests.
+type graphqlSearchQuery struct {
+	Search struct {
+		PageInfo struct {
+			HasNextPage githubql.Boolean
+			EndCursor   githubql.String
+		}
+		Nodes []struct {
+			PullRequest graphqlPullRequest `graphql:"... on PullRequest"`
+		}
+	} `graphql:"search(type: ISSUE, first: 100, after: $searchCursor, query: $query)"`
+}
+
+var graphqlColumns = []column[graphqlPullRequest]{
+	{"REPOSITORY", func(pr graphqlPullRequest) string { return string(pr.Repository.NameWithOwner) }},
+	{"NUMBER", func(pr graphqlPullRequest) string { return strconv.Itoa(int(pr.Number)) }},
+	{"TITLE", func(pr graphqlPullRequest) string { return string(pr.Title) }},
+	{"STATE", func(pr graphqlPullRequest) string { return string(pr.State) }},
+	{"HEAD", func(pr graphqlPullRequest) string { return string(pr.HeadRefOID) }},
+	{"AUTHOR", func(pr graphqlPullRequest) string { return string(pr.Author.Login) }},
+}
+
+func graphqlSearch(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	limit := fs.Int("limit", 0, "the maximum number of pull requests, defaults to all")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if client.GraphQL == nil {
+		return fmt.Errorf("driver %s does not support GraphQL", client.Driver)
+	}
+
+	vars := map[string]interface{}{
+		"query":        githubql.String(args[0]),
+		"searchCursor": (*githubql.String)(nil),
+	}
+	var prs []graphqlPullRequest
+	for {
+		var q graphqlSearchQuery
+		if err := client.GraphQL.Query(ctx, &q, vars); err != nil {
+			return err
+		}
+		for _, node := range q.Search.Nodes {
+			prs = append(prs, node.PullRequest)
+		}
+		if *limit > 0 && len(prs) >= *limit {
+			prs = prs[:*limit]
+			break
+		}
+		if !q.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor := q.Search.PageInfo.EndCursor
+		vars["searchCursor"] = &cursor
+	}
+	return printList(a, prs, graphqlColumns)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var hookCommand = &group{
+	name:    "hook",
+	summary: "Manage repository webhooks",
+	actions: []*action{
+		{name: "list", usage: "<repo>", summary: "List the webhooks of a repository", run: hookList},
+		{name: "get", usage: "<repo> <id>", summary: "Show a webhook", run: hookGet},
+		{name: "create", usage: "<repo> <target>", summary: "Create a webhook sending events to the target URL", run: hookCreate},
+		{name: "delete", usage: "<repo> <id>", summary: "Delete a webhook", run: hookDelete},
+	},
+}
+
+var hookColumns = []column[*scm.Hook]{
+	{"ID", func(h *scm.Hook) string { return h.ID }},
+	{"NAME", func(h *scm.Hook) string { return h.Name }},
+	{"TARGET", func(h *scm.Hook) string { return h.Target }},
+	{"EVENTS", func(h *scm.Hook) string { return strings.Join(h.Events, ",") }},
+	{"ACTIVE", func(h *scm.Hook) string { return formatBool(h.Active) }},
+}
+
+// hookEvents maps the names of the -events flag to the
+// fields of the hook events.
+var hookEvents = map[string]func(*scm.HookEvents) *bool{
+	"branch":               func(e *scm.HookEvents) *bool { return &e.Branch },
+	"deployment":           func(e *scm.HookEvents) *bool { return &e.Deployment },
+	"deployment_status":    func(e *scm.HookEvents) *bool { return &e.DeploymentStatus },
+	"issue":                func(e *scm.HookEvents) *bool { return &e.Issue },
+	"issue_comment":        func(e *scm.HookEvents) *bool { return &e.IssueComment },
+	"pull_request":         func(e *scm.HookEvents) *bool { return &e.PullRequest },
+	"pull_request_comment": func(e *scm.HookEvents) *bool { return &e.PullRequestComment },
+	"push":                 func(e *scm.HookEvents) *bool { return &e.Push },
+	"release":              func(e *scm.HookEvents) *bool { return &e.Release },
+	"review":               func(e *scm.HookEvents) *bool { return &e.Review },
+	"review_comment":       func(e *scm.HookEvents) *bool { return &e.ReviewComment },
+	"tag":                  func(e *scm.HookEvents) *bool { return &e.Tag },
+}
+
+func hookList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	hooks, _, err := client.Repositories.ListHooks(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, hooks, hookColumns)
+}
+
+func hookGet(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	hook, _, err := client.Repositories.FindHook(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	return printItem(a, hook, hookColumns)
+}
+
+func hookCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := new(scm.HookInput)
+	fs.StringVar(&input.Name, "name", "", "the name of the webhook")
+	fs.StringVar(&input.Secret, "secret", "", "the secret signing the payloads")
+	fs.BoolVar(&input.SkipVerify, "skip-verify", false, "skip the verification of the certificate of the target")
+	events := fs.String("events", "push,pull_request", "the comma separated events: "+strings.Join(hookEventNames(), ", "))
+	fs.Func("native", "a native event of the driver, may be repeated", func(event string) error {
+		input.NativeEvents = append(input.NativeEvents, event)
+		return nil
+	})
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	input.Target = args[1]
+	for _, name := range strings.Split(*events, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		field, ok := hookEvents[name]
+		if !ok {
+			return fmt.Errorf("invalid event %q, want one of %s", name, strings.Join(hookEventNames(), ", "))
+		}
+		*field(&input.Events) = true
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	hook, _, err := client.Repositories.CreateHook(ctx, args[0], input)
+	if err != nil {
+		return err
+	}
+	return printItem(a, hook, hookColumns)
+}
+
+func hookDelete(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Repositories.DeleteHook(ctx, args[0], args[1]); err != nil {
+		return err
+	}
+	return a.printDone("deleted webhook %s of repository %s", args[1], args[0])
+}
+
+// hookEventNames returns the sorted names of the events.
+func hookEventNames() []string {
+	names := make([]string, 0, len(hookEvents))
+	for name := range hookEvents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var issueCommand = &group{
+	name:    "issue",
+	summary: "Manage issues",
+	actions: []*action{
+		{name: "list", usage: "<repo>", summary: "List the issues of a repository", run: issueList},
+		{name: "get", usage: "<repo> <number>", summary: "Show an issue", run: issueGet},
+		{name: "create", usage: "<repo>", summary: "Create an issue", run: issueCreate},
+		{name: "close", usage: "<repo> <number>", summary: "Close an issue", run: issueClose},
+		{name: "reopen", usage: "<repo> <number>", summary: "Reopen an issue", run: issueReopen},
+		{name: "comments", usage: "<repo> <number>", summary: "List the comments of an issue", run: issueComments},
+		{name: "comment", usage: "<repo> <number> <body>", summary: "Comment on an issue", run: issueComment},
+		{name: "label", usage: "<repo> <number> <label>", summary: "Add or remove a label of an issue", run: issueLabel},
+		{name: "search", usage: "<query>", summary: "Search the issues and pull requests", run: issueSearch},
+	},
+}
+
+var issueColumns = []column[*scm.Issue]{
+	{"NUMBER", func(i *scm.Issue) string { return strconv.Itoa(i.Number) }},
+	{"TITLE", func(i *scm.Issue) string { return i.Title }},
+	{"STATE", func(i *scm.Issue) string { return i.State }},
+	{"LABELS", func(i *scm.Issue) string { return strings.Join(i.Labels, ",") }},
+	{"AUTHOR", func(i *scm.Issue) string { return i.Author.Login }},
+}
+
+var searchIssueColumns = []column[*scm.SearchIssue]{
+	{"REPOSITORY", func(i *scm.SearchIssue) string { return i.Repository.FullName }},
+	{"NUMBER", func(i *scm.SearchIssue) string { return strconv.Itoa(i.Number) }},
+	{"TITLE", func(i *scm.SearchIssue) string { return i.Title }},
+	{"STATE", func(i *scm.SearchIssue) string { return i.State }},
+	{"AUTHOR", func(i *scm.SearchIssue) string { return i.Author.Login }},
+}
+
+func issueList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	state := fs.String("state", "open", "the state of the issues: open, closed or all")
+	opts := scm.IssueListOptions{}
+	fs.IntVar(&opts.Page, "page", 0, "the page to list")
+	fs.IntVar(&opts.Size, "size", 0, "the size of the pages")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	opts.Open, opts.Closed, err = openClosed(*state)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	issues, _, err := client.Issues.List(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, issues, issueColumns)
+}
+
+func issueGet(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	issue, _, err := client.Issues.Find(ctx, repo, n)
+	if err != nil {
+		return err
+	}
+	return printItem(a, issue, issueColumns)
+}
+
+func issueCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := new(scm.IssueInput)
+	fs.StringVar(&input.Title, "title", "", "the title of the issue")
+	fs.StringVar(&input.Body, "body", "", "the description of the issue")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if input.Title == "" {
+		return fmt.Errorf("the -title flag is required")
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	issue, _, err := client.Issues.Create(ctx, args[0], input)
+	if err != nil {
+		return err
+	}
+	return printItem(a, issue, issueColumns)
+}
+
+func issueClose(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Issues.Close(ctx, repo, n); err != nil {
+		return err
+	}
+	return a.printDone("closed issue %s#%d", repo, n)
+}
+
+func issueReopen(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Issues.Reopen(ctx, repo, n); err != nil {
+		return err
+	}
+	return a.printDone("reopened issue %s#%d", repo, n)
+}
+
+func issueComments(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	comments, _, err := client.Issues.ListComments(ctx, repo, n, opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, comments, commentColumns)
+}
+
+func issueComment(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, rest, err := parseNumber(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	comment, _, err := client.Issues.CreateComment(ctx, repo, n, &scm.CommentInput{Body: rest[0]})
+	if err != nil {
+		return err
+	}
+	return printItem(a, comment, commentColumns)
+}
+
+func issueLabel(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	remove := fs.Bool("remove", false, "remove the label instead of adding it")
+	repo, n, rest, err := parseNumber(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if *remove {
+		if _, err := client.Issues.DeleteLabel(ctx, repo, n, rest[0]); err != nil {
+			return err
+		}
+		return a.printDone("removed label %s from issue %s#%d", rest[0], repo, n)
+	}
+	if _, err := client.Issues.AddLabel(ctx, repo, n, rest[0]); err != nil {
+		return err
+	}
+	return a.printDone("added label %s to issue %s#%d", rest[0], repo, n)
+}
+
+func issueSearch(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := scm.SearchOptions{}
+	fs.StringVar(&opts.Sort, "sort", "", "the field to sort the results by, such as created or updated")
+	fs.BoolVar(&opts.Ascending, "asc", false, "sort the results in ascending order")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	opts.Query = args[0]
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	issues, _, err := client.Issues.Search(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, issues, searchIssueColumns)
+}
@@ -0,0 +1,203 @@
+// Command scm is a command-line client for the git servers
+// supported by go-scm.
+//
+// Usage:
+//
+//	scm [flags] <command> <action> [flags] [args]
+//
+// The client is configured by the factory environment
+// variables $GIT_KIND, $GIT_SERVER and $GIT_TOKEN, which the
+// -kind, -url and -token flags override, or by the servers
+// of the -config file. Run scm -help for the commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := newApp(os.Stdin, os.Stdout, os.Stderr).run(ctx, os.Args[1:])
+	stop()
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(0)
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// errUsage is returned when the command line is invalid,
+// once the usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+// group is a command grouping the actions on a resource.
+type group struct {
+	name    string
+	summary string
+	actions []*action
+	// run runs the command if it has no actions.
+	run func(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error
+	// usage describes the arguments of a command without
+	// actions.
+	usage string
+}
+
+// action is an action of a command.
+type action struct {
+	name    string
+	usage   string
+	summary string
+	run     func(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error
+}
+
+// commands lists the commands of the tool.
+var commands = []*group{
+	repoCommand,
+	prCommand,
+	issueCommand,
+	reviewCommand,
+	contentCommand,
+	releaseCommand,
+	statusCommand,
+	hookCommand,
+	gitCommand,
+	graphqlCommand,
+	webhookParseCommand,
+}
+
+// run runs the command line.
+func (a *app) run(ctx context.Context, args []string) error {
+	a.options = options{}
+	fs := a.flagSet("scm", "<command> <action> [args]")
+	fs.Usage = a.usage
+	if err := fs.Parse(args); err != nil {
+		return usageError(err)
+	}
+	args = fs.Args()
+	if len(args) == 0 {
+		a.usage()
+		return errUsage
+	}
+	g := findGroup(args[0])
+	if g == nil {
+		fmt.Fprintf(a.stderr, "unknown command %q\n\n", args[0])
+		a.usage()
+		return errUsage
+	}
+	if g.run != nil {
+		return g.run(ctx, a, a.flagSet("scm "+g.name, g.usage), args[1:])
+	}
+	if len(args) < 2 {
+		a.groupUsage(g)
+		return errUsage
+	}
+	for _, act := range g.actions {
+		if act.name == args[1] {
+			return act.run(ctx, a, a.flagSet("scm "+g.name+" "+act.name, act.usage), args[2:])
+		}
+	}
+	fmt.Fprintf(a.stderr, "unknown action %q of command %s\n\n", args[1], g.name)
+	a.groupUsage(g)
+	return errUsage
+}
+
+// findGroup returns the named command.
+func findGroup(name string) *group {
+	for _, g := range commands {
+		if g.name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// usage prints the usage of the tool.
+func (a *app) usage() {
+	w := a.stderr
+	fmt.Fprintf(w, "Usage: scm [flags] <command> <action> [flags] [args]\n\n")
+	fmt.Fprintf(w, "Commands:\n")
+	for _, g := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", g.name, g.summary)
+	}
+	fmt.Fprintf(w, "\nFlags:\n")
+	fs := flag.NewFlagSet("scm", flag.ContinueOnError)
+	new(options).register(fs)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+// groupUsage prints the usage of the command.
+func (a *app) groupUsage(g *group) {
+	w := a.stderr
+	fmt.Fprintf(w, "Usage: scm %s <action> [flags] [args]\n\n", g.name)
+	fmt.Fprintf(w, "Actions:\n")
+	for _, act := range g.actions {
+		fmt.Fprintf(w, "  %-14s %-32s %s\n", act.name, act.usage, act.summary)
+	}
+}
+
+// flagSet returns a flag set holding the global flags.
+func (a *app) flagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: %s [flags] %s\n\nFlags:\n", name, usage)
+		fs.PrintDefaults()
+	}
+	a.options.register(fs)
+	return fs
+}
+
+// parse parses the flags, which may be interleaved with
+// the arguments, and returns the arguments. It fails
+// unless there are between minArgs and maxArgs arguments.
+func parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usageError(err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) < minArgs || len(positional) > maxArgs {
+		fs.Usage()
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+// usageError returns errUsage for the error of parsing the
+// flags, which the flag set has already printed.
+func usageError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return errUsage
+}
+
+// newApp returns the application using the standard
+// streams.
+func newApp(stdin io.Reader, stdout, stderr io.Writer) *app {
+	return &app{
+		stdin:     stdin,
+		stdout:    stdout,
+		stderr:    stderr,
+		newClient: newClient,
+	}
+}
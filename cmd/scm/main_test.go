@@ -0,0 +1,336 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	githubql "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp returns an application using the fake client,
+// reading the stdin and writing to the buffers.
+func testApp(stdin string) (a *app, data *fake.Data, stdout, stderr *bytes.Buffer) {
+	client, data := fake.NewDefault()
+	stdout = new(bytes.Buffer)
+	stderr = new(bytes.Buffer)
+	a = newApp(strings.NewReader(stdin), stdout, stderr)
+	a.newClient = func(*options) (*scm.Client, error) {
+		return client, nil
+	}
+	return a, data, stdout, stderr
+}
+
+// run runs the command line, failing the test on error.
+func run(t *testing.T, a *app, args ...string) {
+	t.Helper()
+	require.NoError(t, a.run(context.Background(), args), strings.Join(args, " "))
+}
+
+func TestRepo(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+	data.Repositories = []*scm.Repository{
+		{Namespace: "jenkins-x", Name: "go-scm", FullName: "jenkins-x/go-scm", Branch: "main", Clone: "https://fake.com/jenkins-x/go-scm.git"},
+	}
+
+	run(t, a, "repo", "get", "jenkins-x/go-scm")
+	want := "NAME              BRANCH  PRIVATE  CLONE\n" +
+		"jenkins-x/go-scm  main    false    https://fake.com/jenkins-x/go-scm.git\n"
+	assert.Equal(t, want, stdout.String())
+
+	stdout.Reset()
+	run(t, a, "-o", "json", "repo", "list")
+	var repos []*scm.Repository
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &repos))
+	assert.Equal(t, data.Repositories, repos)
+}
+
+func TestPullRequest(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+
+	// flags may follow the arguments.
+	run(t, a, "pr", "create", "jenkins-x/go-scm", "-title", "Add CLI", "-head", "cli", "-base", "main", "-o", "json")
+	pr := new(scm.PullRequest)
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), pr))
+	assert.Equal(t, 1, pr.Number)
+	assert.Equal(t, "Add CLI", data.PullRequests[1].Title)
+
+	stdout.Reset()
+	run(t, a, "pr", "comment", "jenkins-x/go-scm", "1", "looks good")
+	assert.Contains(t, stdout.String(), "looks good")
+	assert.Len(t, data.PullRequestComments[1], 1)
+
+	stdout.Reset()
+	run(t, a, "pr", "merge", "jenkins-x/go-scm", "1", "-method", "squash")
+	assert.Equal(t, "merged pull request jenkins-x/go-scm#1\n", stdout.String())
+	assert.True(t, data.PullRequests[1].Merged)
+}
+
+func TestIssueComment(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+	data.Issues[1] = []*scm.Issue{{Number: 1, Title: "Bug"}}
+
+	run(t, a, "issue", "comment", "jenkins-x/go-scm", "1", "on it")
+	assert.Contains(t, stdout.String(), "on it")
+	assert.Equal(t, []string{"jenkins-x/go-scm#1:on it"}, data.IssueCommentsAdded)
+
+	stdout.Reset()
+	run(t, a, "issue", "label", "jenkins-x/go-scm", "1", "bug")
+	assert.Equal(t, "added label bug to issue jenkins-x/go-scm#1\n", stdout.String())
+}
+
+func TestStatus(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+
+	run(t, a, "status", "create", "jenkins-x/go-scm", "abc", "-state", "success", "-label", "ci", "-description", "passed")
+	assert.Equal(t, scm.StateSuccess, data.Statuses["abc"][0].State)
+
+	stdout.Reset()
+	run(t, a, "status", "combined", "jenkins-x/go-scm", "abc")
+	want := "state: unknown sha: abc\n\n" +
+		"LABEL  STATE    DESCRIPTION  TARGET\n" +
+		"ci     success  passed       \n"
+	assert.Equal(t, want, stdout.String())
+
+	err := a.run(context.Background(), []string{"status", "create", "jenkins-x/go-scm", "abc", "-state", "done", "-label", "ci"})
+	assert.EqualError(t, err, `invalid state "done", want pending, running, success, failure, error or cancelled`)
+}
+
+func TestHook(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+
+	run(t, a, "hook", "create", "jenkins-x/go-scm", "https://example.com/hook", "-events", "push,tag")
+	require.Len(t, data.Hooks["jenkins-x/go-scm"], 1)
+	id := data.Hooks["jenkins-x/go-scm"][0].ID
+
+	stdout.Reset()
+	run(t, a, "hook", "delete", "jenkins-x/go-scm", id)
+	assert.Empty(t, data.Hooks["jenkins-x/go-scm"])
+
+	err := a.run(context.Background(), []string{"hook", "create", "jenkins-x/go-scm", "https://example.com/hook", "-events", "push,unknown"})
+	assert.ErrorContains(t, err, `invalid event "unknown"`)
+}
+
+func TestContent(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+	data.ContentDir = t.TempDir()
+	dir := filepath.Join(data.ContentDir, "jenkins-x", "go-scm")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# go-scm\n"), 0o600))
+
+	run(t, a, "content", "get", "jenkins-x/go-scm", "README.md")
+	assert.Equal(t, "# go-scm\n", stdout.String())
+
+	stdout.Reset()
+	run(t, a, "content", "list", "jenkins-x/go-scm", "-o", "json")
+	var files []*scm.FileEntry
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &files))
+	require.Len(t, files, 1)
+	assert.Equal(t, "README.md", files[0].Name)
+}
+
+func TestGit(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+	_, err := data.InitRepository("jenkins-x/go-scm", "main")
+	require.NoError(t, err)
+	client, err := a.scmClient()
+	require.NoError(t, err)
+	_, err = client.Contents.Create(context.Background(), "jenkins-x/go-scm", "README.md", &scm.ContentParams{
+		Branch:  "main",
+		Message: "Add README\n\nWith the usage.",
+		Data:    []byte("# go-scm\n"),
+	})
+	require.NoError(t, err)
+
+	run(t, a, "git", "commits", "jenkins-x/go-scm", "-o", "json")
+	var commits []*scm.Commit
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &commits))
+	require.NotEmpty(t, commits)
+	sha := commits[0].Sha
+
+	stdout.Reset()
+	run(t, a, "git", "commits", "jenkins-x/go-scm", "-size", "1")
+	assert.Contains(t, stdout.String(), sha)
+	assert.Contains(t, stdout.String(), "Add README\n")
+
+	stdout.Reset()
+	run(t, a, "git", "ref", "jenkins-x/go-scm", "main")
+	assert.Equal(t, sha+"\n", stdout.String())
+
+	stdout.Reset()
+	run(t, a, "git", "changes", "jenkins-x/go-scm", sha, "-o", "json")
+	var changes []*scm.Change
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &changes))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "README.md", changes[0].Path)
+	assert.True(t, changes[0].Added)
+}
+
+func TestRepoCollaborators(t *testing.T) {
+	a, data, stdout, _ := testApp("")
+	data.Collaborators = []string{"alice", "bob"}
+
+	run(t, a, "repo", "collaborators", "jenkins-x/go-scm")
+	want := "LOGIN  NAME  EMAIL\n" +
+		"alice        \n" +
+		"bob          \n"
+	assert.Equal(t, want, stdout.String())
+}
+
+func TestIssueSearch(t *testing.T) {
+	a, _, stdout, _ := testApp("")
+
+	run(t, a, "issue", "search", "is:open label:bug", "-sort", "updated", "-o", "json")
+	assert.Equal(t, "[]\n", stdout.String())
+}
+
+func TestGraphQL(t *testing.T) {
+	a, _, stdout, _ := testApp("")
+	err := a.run(context.Background(), []string{"graphql", "is:pr"})
+	assert.EqualError(t, err, "driver fake does not support GraphQL")
+
+	client, err := a.scmClient()
+	require.NoError(t, err)
+	graphql := &graphqlStub{pages: []string{
+		`{"Search": {"PageInfo": {"HasNextPage": true, "EndCursor": "c1"}, "Nodes": [
+			{"PullRequest": {"Number": 1, "Title": "Add CLI", "State": "OPEN", "Author": {"Login": "alice"}, "Repository": {"NameWithOwner": "jenkins-x/go-scm"}}}]}}`,
+		`{"Search": {"PageInfo": {"HasNextPage": false}, "Nodes": [
+			{"PullRequest": {"Number": 2, "Title": "Fix docs", "State": "MERGED", "Author": {"Login": "bob"}, "Repository": {"NameWithOwner": "jenkins-x/go-scm"}}}]}}`,
+	}}
+	client.GraphQL = graphql
+
+	run(t, a, "graphql", "is:pr")
+	want := "REPOSITORY        NUMBER  TITLE     STATE   HEAD  AUTHOR\n" +
+		"jenkins-x/go-scm  1       Add CLI   OPEN          alice\n" +
+		"jenkins-x/go-scm  2       Fix docs  MERGED        bob\n"
+	assert.Equal(t, want, stdout.String())
+	require.Len(t, graphql.vars, 2)
+	assert.Nil(t, graphql.vars[0]["searchCursor"])
+	assert.Equal(t, "c1", fmt.Sprint(*graphql.vars[1]["searchCursor"].(*githubql.String)))
+}
+
+// graphqlStub is a GraphQL service returning the pages of a
+// search.
+type graphqlStub struct {
+	pages []string
+	vars  []map[string]interface{}
+}
+
+func (s *graphqlStub) Query(_ context.Context, q interface{}, vars map[string]interface{}) error {
+	copied := map[string]interface{}{}
+	for k, v := range vars {
+		copied[k] = v
+	}
+	s.vars = append(s.vars, copied)
+	page := s.pages[0]
+	s.pages = s.pages[1:]
+	return json.Unmarshal([]byte(page), q)
+}
+
+func TestWebhookParse(t *testing.T) {
+	payload, err := os.ReadFile("../../scm/driver/github/testdata/webhooks/push.json")
+	require.NoError(t, err)
+	a, _, stdout, _ := testApp(string(payload))
+
+	run(t, a, "webhook-parse", "-kind", "github", "-header", "X-GitHub-Event: push", "-header", "X-GitHub-Delivery: 1")
+	want := "KIND  TYPE      REPOSITORY\n" +
+		"push  PushHook  Codertocat/Hello-World\n"
+	assert.Equal(t, want, stdout.String())
+
+	a, _, _, _ = testApp(string(payload))
+	err = a.run(context.Background(), []string{"webhook-parse", "-kind", "github", "-secret", "secret",
+		"-header", "X-GitHub-Event: push", "-header", "X-GitHub-Delivery: 1"})
+	assert.ErrorIs(t, err, scm.ErrSignatureInvalid)
+}
+
+func TestWebhookParseRaw(t *testing.T) {
+	request := "POST /hook HTTP/1.1\r\n" +
+		"Host: example.com\r\n" +
+		"X-GitHub-Event: ping\r\n" +
+		"X-GitHub-Delivery: 1\r\n" +
+		"Content-Type: application/json\r\n" +
+		"Content-Length: 56\r\n" +
+		"\r\n" +
+		`{"zen": "Keep it simple", "repository": {"id": 1}}      `
+	a, _, stdout, _ := testApp(request)
+
+	run(t, a, "webhook-parse", "-kind", "github", "-raw", "-o", "json")
+	assert.Contains(t, stdout.String(), `"Kind": "ping"`)
+}
+
+func TestUsage(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"unknown"},
+		{"pr"},
+		{"pr", "unknown"},
+		{"pr", "get", "jenkins-x/go-scm"},
+		{"pr", "get", "jenkins-x/go-scm", "1", "2"},
+	}
+	for _, args := range tests {
+		a, _, _, stderr := testApp("")
+		err := a.run(context.Background(), args)
+		assert.True(t, errors.Is(err, errUsage), "Want usage error for %v, got %v", args, err)
+		assert.Contains(t, stderr.String(), "Usage:")
+	}
+
+	a, _, _, _ := testApp("")
+	err := a.run(context.Background(), []string{"pr", "get", "jenkins-x/go-scm", "one"})
+	assert.EqualError(t, err, `invalid number "one": not a number`)
+
+	err = a.run(context.Background(), []string{"-o", "yaml", "repo", "list"})
+	assert.EqualError(t, err, `unsupported output format "yaml", want table or json`)
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("SCM_CONFIG", "")
+	t.Setenv("GIT_REPO_URL", "https://github.com/jenkins-x/go-scm.git")
+	t.Setenv("GIT_KIND", "github")
+	t.Setenv("GIT_SERVER", "")
+	t.Setenv("GIT_TOKEN", "")
+
+	client, err := newClient(&options{kind: "fake", token: "abc123"})
+	require.NoError(t, err)
+	assert.Equal(t, scm.DriverFake, client.Driver)
+
+	t.Setenv("GIT_REPO_URL", "")
+	t.Setenv("GIT_KIND", "fake")
+	t.Setenv("GIT_TOKEN", "abc123")
+	client, err = newClient(&options{})
+	require.NoError(t, err)
+	assert.Equal(t, scm.DriverFake, client.Driver)
+
+	t.Setenv("GIT_TOKEN", "")
+	_, err = newClient(&options{})
+	assert.EqualError(t, err, "no Git OAuth token specified for $GIT_TOKEN")
+}
+
+func TestNewConfigClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := `servers:
+- name: gogs
+  driver: gogs
+  url: https://gogs.example.com
+- name: stash
+  driver: stash
+  url: https://stash.example.com
+`
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	client, err := newClient(&options{config: path, server: "stash"})
+	require.NoError(t, err)
+	assert.Equal(t, scm.DriverStash, client.Driver)
+
+	_, err = newClient(&options{config: path})
+	assert.EqualError(t, err, "the -server flag is required since "+path+" configures 2 servers")
+}
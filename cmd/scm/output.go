@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// column is a column of a table, rendering the cell of
+// each value.
+type column[T any] struct {
+	name  string
+	value func(T) string
+}
+
+// printList prints the values as a JSON array or as a table
+// of the columns.
+func printList[T any](a *app, values []T, columns []column[T]) error {
+	format, err := a.format()
+	if err != nil {
+		return err
+	}
+	if format == outputJSON {
+		if values == nil {
+			values = []T{}
+		}
+		return a.printJSON(values)
+	}
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	fmt.Fprintln(w, strings.Join(names, "\t"))
+	for _, v := range values {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			// tabs and newlines would break the table.
+			cells[i] = strings.Join(strings.Fields(c.value(v)), " ")
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// printItem prints the value as a JSON object or as a
+// table of the columns.
+func printItem[T any](a *app, value T, columns []column[T]) error {
+	format, err := a.format()
+	if err != nil {
+		return err
+	}
+	if format == outputJSON {
+		return a.printJSON(value)
+	}
+	return printList(a, []T{value}, columns)
+}
+
+// printDone reports the success of an action returning no
+// value. Nothing is printed in JSON.
+func (a *app) printDone(format string, args ...interface{}) error {
+	output, err := a.format()
+	if err != nil || output == outputJSON {
+		return err
+	}
+	_, err = fmt.Fprintf(a.stdout, format+"\n", args...)
+	return err
+}
+
+// printJSON prints the value as indented JSON.
+func (a *app) printJSON(value interface{}) error {
+	encoder := json.NewEncoder(a.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// format returns the output format.
+func (a *app) format() (string, error) {
+	switch a.options.output {
+	case "", outputTable:
+		return outputTable, nil
+	case outputJSON:
+		return outputJSON, nil
+	}
+	return "", fmt.Errorf("unsupported output format %q, want table or json", a.options.output)
+}
+
+// formatTime formats the time for tables.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// firstLine returns the first line of the text, such as the
+// subject of a commit message.
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// formatBool formats the boolean for tables.
+func formatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var prCommand = &group{
+	name:    "pr",
+	summary: "Manage pull requests",
+	actions: []*action{
+		{name: "list", usage: "<repo>", summary: "List the pull requests of a repository", run: prList},
+		{name: "get", usage: "<repo> <number>", summary: "Show a pull request", run: prGet},
+		{name: "create", usage: "<repo>", summary: "Create a pull request", run: prCreate},
+		{name: "merge", usage: "<repo> <number>", summary: "Merge a pull request", run: prMerge},
+		{name: "close", usage: "<repo> <number>", summary: "Close a pull request", run: prClose},
+		{name: "reopen", usage: "<repo> <number>", summary: "Reopen a pull request", run: prReopen},
+		{name: "changes", usage: "<repo> <number>", summary: "List the files changed by a pull request", run: prChanges},
+		{name: "comments", usage: "<repo> <number>", summary: "List the comments of a pull request", run: prComments},
+		{name: "comment", usage: "<repo> <number> <body>", summary: "Comment on a pull request", run: prComment},
+		{name: "label", usage: "<repo> <number> <label>", summary: "Add or remove a label of a pull request", run: prLabel},
+	},
+}
+
+var prColumns = []column[*scm.PullRequest]{
+	{"NUMBER", func(pr *scm.PullRequest) string { return strconv.Itoa(pr.Number) }},
+	{"TITLE", func(pr *scm.PullRequest) string { return pr.Title }},
+	{"STATE", func(pr *scm.PullRequest) string { return pr.State }},
+	{"HEAD", func(pr *scm.PullRequest) string { return pr.Head.Ref }},
+	{"BASE", func(pr *scm.PullRequest) string { return pr.Base.Ref }},
+	{"AUTHOR", func(pr *scm.PullRequest) string { return pr.Author.Login }},
+}
+
+var changeColumns = []column[*scm.Change]{
+	{"PATH", func(c *scm.Change) string { return c.Path }},
+	{"ADDED", func(c *scm.Change) string { return formatBool(c.Added) }},
+	{"DELETED", func(c *scm.Change) string { return formatBool(c.Deleted) }},
+	{"RENAMED", func(c *scm.Change) string { return formatBool(c.Renamed) }},
+	{"ADDITIONS", func(c *scm.Change) string { return strconv.Itoa(c.Additions) }},
+	{"DELETIONS", func(c *scm.Change) string { return strconv.Itoa(c.Deletions) }},
+}
+
+var commentColumns = []column[*scm.Comment]{
+	{"ID", func(c *scm.Comment) string { return strconv.Itoa(c.ID) }},
+	{"AUTHOR", func(c *scm.Comment) string { return c.Author.Login }},
+	{"CREATED", func(c *scm.Comment) string { return formatTime(c.Created) }},
+	{"BODY", func(c *scm.Comment) string { return c.Body }},
+}
+
+func prList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	state := fs.String("state", "open", "the state of the pull requests: open, closed or all")
+	opts := new(scm.PullRequestListOptions)
+	fs.IntVar(&opts.Page, "page", 0, "the page to list")
+	fs.IntVar(&opts.Size, "size", 0, "the size of the pages")
+	fs.Func("label", "list the pull requests having the label, may be repeated", func(label string) error {
+		opts.Labels = append(opts.Labels, label)
+		return nil
+	})
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	opts.Open, opts.Closed, err = openClosed(*state)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	prs, _, err := client.PullRequests.List(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, prs, prColumns)
+}
+
+func prGet(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	pr, _, err := client.PullRequests.Find(ctx, repo, n)
+	if err != nil {
+		return err
+	}
+	return printItem(a, pr, prColumns)
+}
+
+func prCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := new(scm.PullRequestInput)
+	fs.StringVar(&input.Title, "title", "", "the title of the pull request")
+	fs.StringVar(&input.Body, "body", "", "the description of the pull request")
+	fs.StringVar(&input.Head, "head", "", "the branch holding the changes")
+	fs.StringVar(&input.Base, "base", "", "the branch the changes are pulled into")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if input.Title == "" || input.Head == "" || input.Base == "" {
+		return fmt.Errorf("the -title, -head and -base flags are required")
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	pr, _, err := client.PullRequests.Create(ctx, args[0], input)
+	if err != nil {
+		return err
+	}
+	return printItem(a, pr, prColumns)
+}
+
+func prMerge(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := new(scm.PullRequestMergeOptions)
+	fs.StringVar(&opts.MergeMethod, "method", "", "the merge method: merge, squash, rebase or rebase-merge")
+	fs.StringVar(&opts.CommitTitle, "title", "", "the title of the merge commit")
+	fs.StringVar(&opts.SHA, "sha", "", "the SHA the head of the pull request must match")
+	fs.BoolVar(&opts.DeleteSourceBranch, "delete-branch", false, "delete the source branch once merged")
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.PullRequests.Merge(ctx, repo, n, opts); err != nil {
+		return err
+	}
+	return a.printDone("merged pull request %s#%d", repo, n)
+}
+
+func prClose(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.PullRequests.Close(ctx, repo, n); err != nil {
+		return err
+	}
+	return a.printDone("closed pull request %s#%d", repo, n)
+}
+
+func prReopen(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.PullRequests.Reopen(ctx, repo, n); err != nil {
+		return err
+	}
+	return a.printDone("reopened pull request %s#%d", repo, n)
+}
+
+func prChanges(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	changes, _, err := client.PullRequests.ListChanges(ctx, repo, n, opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, changes, changeColumns)
+}
+
+func prComments(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	comments, _, err := client.PullRequests.ListComments(ctx, repo, n, opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, comments, commentColumns)
+}
+
+func prComment(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, rest, err := parseNumber(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	comment, _, err := client.PullRequests.CreateComment(ctx, repo, n, &scm.CommentInput{Body: rest[0]})
+	if err != nil {
+		return err
+	}
+	return printItem(a, comment, commentColumns)
+}
+
+func prLabel(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	remove := fs.Bool("remove", false, "remove the label instead of adding it")
+	repo, n, rest, err := parseNumber(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if *remove {
+		if _, err := client.PullRequests.DeleteLabel(ctx, repo, n, rest[0]); err != nil {
+			return err
+		}
+		return a.printDone("removed label %s from pull request %s#%d", rest[0], repo, n)
+	}
+	if _, err := client.PullRequests.AddLabel(ctx, repo, n, rest[0]); err != nil {
+		return err
+	}
+	return a.printDone("added label %s to pull request %s#%d", rest[0], repo, n)
+}
+
+// parseNumber parses the repository and number arguments,
+// followed by the given number of extra arguments.
+func parseNumber(fs *flag.FlagSet, args []string, extra int) (repo string, n int, rest []string, err error) {
+	args, err = parse(fs, args, 2+extra, 2+extra)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	n, err = number("number", args[1])
+	if err != nil {
+		return "", 0, nil, err
+	}
+	return args[0], n, args[2:], nil
+}
+
+// openClosed returns whether to list the open and the
+// closed items of the state.
+func openClosed(state string) (open, closed bool, err error) {
+	switch state {
+	case "open":
+		return true, false, nil
+	case "closed":
+		return false, true, nil
+	case "all":
+		return true, true, nil
+	}
+	return false, false, fmt.Errorf("invalid state %q, want open, closed or all", state)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var releaseCommand = &group{
+	name:    "release",
+	summary: "Manage releases",
+	actions: []*action{
+		{name: "list", usage: "<repo>", summary: "List the releases of a repository", run: releaseList},
+		{name: "get", usage: "<repo> <tag>", summary: "Show the release of a tag", run: releaseGet},
+		{name: "create", usage: "<repo> <tag>", summary: "Create a release", run: releaseCreate},
+		{name: "delete", usage: "<repo> <tag>", summary: "Delete the release of a tag", run: releaseDelete},
+	},
+}
+
+var releaseColumns = []column[*scm.Release]{
+	{"ID", func(r *scm.Release) string { return strconv.Itoa(r.ID) }},
+	{"TAG", func(r *scm.Release) string { return r.Tag }},
+	{"TITLE", func(r *scm.Release) string { return r.Title }},
+	{"DRAFT", func(r *scm.Release) string { return formatBool(r.Draft) }},
+	{"PRERELEASE", func(r *scm.Release) string { return formatBool(r.Prerelease) }},
+	{"PUBLISHED", func(r *scm.Release) string { return formatTime(r.Published) }},
+}
+
+func releaseList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := scm.ReleaseListOptions{Open: true, Closed: true}
+	fs.IntVar(&opts.Page, "page", 0, "the page to list")
+	fs.IntVar(&opts.Size, "size", 0, "the size of the pages")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	releases, _, err := client.Releases.List(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, releases, releaseColumns)
+}
+
+func releaseGet(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	release, _, err := client.Releases.FindByTag(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	return printItem(a, release, releaseColumns)
+}
+
+func releaseCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := new(scm.ReleaseInput)
+	fs.StringVar(&input.Title, "title", "", "the title of the release, defaults to the tag")
+	fs.StringVar(&input.Description, "description", "", "the description of the release")
+	fs.StringVar(&input.Commitish, "commitish", "", "the commit to tag if the tag does not exist")
+	fs.BoolVar(&input.Draft, "draft", false, "whether the release is a draft")
+	fs.BoolVar(&input.Prerelease, "prerelease", false, "whether the release is a prerelease")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	input.Tag = args[1]
+	if input.Title == "" {
+		input.Title = input.Tag
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	release, _, err := client.Releases.Create(ctx, args[0], input)
+	if err != nil {
+		return err
+	}
+	return printItem(a, release, releaseColumns)
+}
+
+func releaseDelete(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Releases.DeleteByTag(ctx, args[0], args[1]); err != nil {
+		return err
+	}
+	return a.printDone("deleted release %s of repository %s", args[1], args[0])
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var repoCommand = &group{
+	name:    "repo",
+	summary: "Manage repositories",
+	actions: []*action{
+		{name: "get", usage: "<repo>", summary: "Show a repository", run: repoGet},
+		{name: "list", usage: "", summary: "List the repositories of the user or of an organization", run: repoList},
+		{name: "create", usage: "<name>", summary: "Create a repository", run: repoCreate},
+		{name: "delete", usage: "<repo>", summary: "Delete a repository", run: repoDelete},
+		{name: "labels", usage: "<repo>", summary: "List the labels of a repository", run: repoLabels},
+		{name: "collaborators", usage: "<repo>", summary: "List the collaborators of a repository", run: repoCollaborators},
+	},
+}
+
+var repoColumns = []column[*scm.Repository]{
+	{"NAME", func(r *scm.Repository) string { return r.FullName }},
+	{"BRANCH", func(r *scm.Repository) string { return r.Branch }},
+	{"PRIVATE", func(r *scm.Repository) string { return formatBool(r.Private) }},
+	{"CLONE", func(r *scm.Repository) string { return r.Clone }},
+}
+
+var labelColumns = []column[*scm.Label]{
+	{"NAME", func(l *scm.Label) string { return l.Name }},
+	{"COLOR", func(l *scm.Label) string { return l.Color }},
+	{"DESCRIPTION", func(l *scm.Label) string { return l.Description }},
+}
+
+func repoGet(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	repo, _, err := client.Repositories.Find(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return printItem(a, repo, repoColumns)
+}
+
+func repoList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	org := fs.String("org", "", "list the repositories of the organization")
+	user := fs.String("user", "", "list the repositories of the user")
+	opts := listFlags(fs)
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	var repos []*scm.Repository
+	switch {
+	case *org != "":
+		repos, _, err = client.Repositories.ListOrganisation(ctx, *org, opts)
+	case *user != "":
+		repos, _, err = client.Repositories.ListUser(ctx, *user, opts)
+	default:
+		repos, _, err = client.Repositories.List(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+	return printList(a, repos, repoColumns)
+}
+
+func repoCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := new(scm.RepositoryInput)
+	fs.StringVar(&input.Namespace, "org", "", "the organization owning the repository, defaults to the user")
+	fs.StringVar(&input.Description, "description", "", "the description of the repository")
+	fs.StringVar(&input.Homepage, "homepage", "", "the homepage of the repository")
+	fs.BoolVar(&input.Private, "private", false, "whether the repository is private")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	input.Name = args[0]
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	repo, _, err := client.Repositories.Create(ctx, input)
+	if err != nil {
+		return err
+	}
+	return printItem(a, repo, repoColumns)
+}
+
+func repoDelete(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Repositories.Delete(ctx, args[0]); err != nil {
+		return err
+	}
+	return a.printDone("deleted repository %s", args[0])
+}
+
+func repoLabels(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	labels, _, err := client.Repositories.ListLabels(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, labels, labelColumns)
+}
+
+var userColumns = []column[scm.User]{
+	{"LOGIN", func(u scm.User) string { return u.Login }},
+	{"NAME", func(u scm.User) string { return u.Name }},
+	{"EMAIL", func(u scm.User) string { return u.Email }},
+}
+
+func repoCollaborators(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	users, _, err := client.Repositories.ListCollaborators(ctx, args[0], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, users, userColumns)
+}
+
+// listFlags registers the pagination flags.
+func listFlags(fs *flag.FlagSet) *scm.ListOptions {
+	opts := new(scm.ListOptions)
+	fs.IntVar(&opts.Page, "page", 0, "the page to list")
+	fs.IntVar(&opts.Size, "size", 0, "the size of the pages")
+	return opts
+}
+
+// number parses the number argument.
+func number(name, arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: not a number", name, arg)
+	}
+	return n, nil
+}
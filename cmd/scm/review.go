@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var reviewCommand = &group{
+	name:    "review",
+	summary: "Manage the reviews of pull requests",
+	actions: []*action{
+		{name: "list", usage: "<repo> <number>", summary: "List the reviews of a pull request", run: reviewList},
+		{name: "get", usage: "<repo> <number> <id>", summary: "Show a review", run: reviewGet},
+		{name: "create", usage: "<repo> <number>", summary: "Review a pull request", run: reviewCreate},
+		{name: "delete", usage: "<repo> <number> <id>", summary: "Delete a pending review", run: reviewDelete},
+	},
+}
+
+var reviewColumns = []column[*scm.Review]{
+	{"ID", func(r *scm.Review) string { return strconv.Itoa(r.ID) }},
+	{"STATE", func(r *scm.Review) string { return r.State }},
+	{"AUTHOR", func(r *scm.Review) string { return r.Author.Login }},
+	{"SHA", func(r *scm.Review) string { return r.Sha }},
+	{"BODY", func(r *scm.Review) string { return r.Body }},
+}
+
+func reviewList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	reviews, _, err := client.Reviews.List(ctx, repo, n, opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, reviews, reviewColumns)
+}
+
+func reviewGet(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, rest, err := parseNumber(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := number("id", rest[0])
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	review, _, err := client.Reviews.Find(ctx, repo, n, id)
+	if err != nil {
+		return err
+	}
+	return printItem(a, review, reviewColumns)
+}
+
+func reviewCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := new(scm.ReviewInput)
+	fs.StringVar(&input.Event, "event", "COMMENT", "the review event: APPROVE, REQUEST_CHANGES or COMMENT")
+	fs.StringVar(&input.Body, "body", "", "the body of the review")
+	fs.StringVar(&input.Sha, "sha", "", "the SHA of the reviewed commit, defaults to the head of the pull request")
+	repo, n, _, err := parseNumber(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	review, _, err := client.Reviews.Create(ctx, repo, n, input)
+	if err != nil {
+		return err
+	}
+	return printItem(a, review, reviewColumns)
+}
+
+func reviewDelete(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	repo, n, rest, err := parseNumber(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := number("id", rest[0])
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.Reviews.Delete(ctx, repo, n, id); err != nil {
+		return err
+	}
+	return a.printDone("deleted review %d of pull request %s#%d", id, repo, n)
+}
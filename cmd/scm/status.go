@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+var statusCommand = &group{
+	name:    "status",
+	summary: "Manage commit statuses",
+	actions: []*action{
+		{name: "list", usage: "<repo> <ref>", summary: "List the statuses of a commit", run: statusList},
+		{name: "combined", usage: "<repo> <ref>", summary: "Show the combined status of a commit", run: statusCombined},
+		{name: "create", usage: "<repo> <ref>", summary: "Create a commit status", run: statusCreate},
+	},
+}
+
+var statusColumns = []column[*scm.Status]{
+	{"LABEL", func(s *scm.Status) string { return s.Label }},
+	{"STATE", func(s *scm.Status) string { return s.State.String() }},
+	{"DESCRIPTION", func(s *scm.Status) string { return s.Desc }},
+	{"TARGET", func(s *scm.Status) string { return s.Target }},
+}
+
+func statusList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	opts := listFlags(fs)
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	statuses, _, err := client.Repositories.ListStatus(ctx, args[0], args[1], opts)
+	if err != nil {
+		return err
+	}
+	return printList(a, statuses, statusColumns)
+}
+
+func statusCombined(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	combined, _, err := client.Repositories.FindCombinedStatus(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	format, err := a.format()
+	if err != nil {
+		return err
+	}
+	if format == outputJSON {
+		return a.printJSON(combined)
+	}
+	if _, err := fmt.Fprintf(a.stdout, "state: %s sha: %s\n\n", combined.State, combined.Sha); err != nil {
+		return err
+	}
+	return printList(a, combined.Statuses, statusColumns)
+}
+
+func statusCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := new(scm.StatusInput)
+	state := fs.String("state", "", "the state: pending, running, success, failure, error or cancelled")
+	fs.StringVar(&input.Label, "label", "", "the label, or context, of the status")
+	fs.StringVar(&input.Desc, "description", "", "the description of the status")
+	fs.StringVar(&input.Target, "target", "", "the URL of the details of the status")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	input.State = scm.ToState(*state)
+	if input.State == scm.StateUnknown {
+		return fmt.Errorf("invalid state %q, want pending, running, success, failure, error or cancelled", *state)
+	}
+	if input.Label == "" {
+		return fmt.Errorf("the -label flag is required")
+	}
+	client, err := a.scmClient()
+	if err != nil {
+		return err
+	}
+	status, _, err := client.Repositories.CreateStatus(ctx, args[0], args[1], input)
+	if err != nil {
+		return err
+	}
+	return printItem(a, status, statusColumns)
+}
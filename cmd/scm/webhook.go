@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+)
+
+var webhookParseCommand = &group{
+	name:    "webhook-parse",
+	summary: "Parse a webhook payload from a file or stdin",
+	usage:   "[file]",
+	run:     webhookParse,
+}
+
+// parsedWebhook is the output of webhook-parse.
+type parsedWebhook struct {
+	Kind    scm.WebhookKind
+	Type    string
+	Webhook scm.Webhook
+}
+
+var webhookColumns = []column[*parsedWebhook]{
+	{"KIND", func(p *parsedWebhook) string { return string(p.Kind) }},
+	{"TYPE", func(p *parsedWebhook) string { return p.Type }},
+	{"REPOSITORY", func(p *parsedWebhook) string { return p.Webhook.Repository().FullName }},
+}
+
+func webhookParse(_ context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	header := http.Header{}
+	fs.Func("header", "a header of the request as \"Name: value\", may be repeated", func(value string) error {
+		name, value, ok := strings.Cut(value, ":")
+		if !ok {
+			return fmt.Errorf("invalid header %q, want \"Name: value\"", value)
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		return nil
+	})
+	raw := fs.Bool("raw", false, "the input is a raw HTTP request, including the request line and headers")
+	secret := fs.String("secret", "", "the secret verifying the signature of the payload")
+	args, err := parse(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	file := ""
+	if len(args) > 0 {
+		file = args[0]
+	}
+	data, err := a.readInput(file)
+	if err != nil {
+		return err
+	}
+
+	var req *http.Request
+	if *raw {
+		req, err = http.ReadRequest(bufio.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return fmt.Errorf("failed to read the HTTP request: %w", err)
+		}
+	} else {
+		req, err = http.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	kind := first(a.options.kind, os.Getenv("GIT_KIND"))
+	service, err := factory.NewWebHookService(kind)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("the %s driver does not support webhooks", kind)
+	}
+	hook, err := service.Parse(req, func(scm.Webhook) (string, error) {
+		return *secret, nil
+	})
+	if err != nil {
+		return err
+	}
+	if hook == nil {
+		return fmt.Errorf("the webhook is not supported by the driver")
+	}
+	parsed := &parsedWebhook{
+		Kind:    hook.Kind(),
+		Type:    strings.TrimPrefix(fmt.Sprintf("%T", hook), "*scm."),
+		Webhook: hook,
+	}
+	return printItem(a, parsed, webhookColumns)
+}
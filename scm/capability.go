@@ -0,0 +1,464 @@
+package scm
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Capability identifies a service operation of the Client,
+// named after the service and the method, for example
+// PullRequests.Merge.
+type Capability string
+
+// Capability values.
+const (
+	// Apps operations
+	CapAppCreateInstallationToken     Capability = "Apps.CreateInstallationToken"
+	CapAppGetOrganisationInstallation Capability = "Apps.GetOrganisationInstallation"
+	CapAppGetRepositoryInstallation   Capability = "Apps.GetRepositoryInstallation"
+	CapAppGetUserInstallation         Capability = "Apps.GetUserInstallation"
+
+	// Contents operations
+	CapContentCreate Capability = "Contents.Create"
+	CapContentDelete Capability = "Contents.Delete"
+	CapContentFind   Capability = "Contents.Find"
+	CapContentList   Capability = "Contents.List"
+	CapContentUpdate Capability = "Contents.Update"
+
+	// Deployments operations
+	CapDeploymentCreate       Capability = "Deployments.Create"
+	CapDeploymentCreateStatus Capability = "Deployments.CreateStatus"
+	CapDeploymentDelete       Capability = "Deployments.Delete"
+	CapDeploymentFind         Capability = "Deployments.Find"
+	CapDeploymentFindStatus   Capability = "Deployments.FindStatus"
+	CapDeploymentList         Capability = "Deployments.List"
+	CapDeploymentListStatus   Capability = "Deployments.ListStatus"
+
+	// Git operations
+	CapGitCompareCommits   Capability = "Git.CompareCommits"
+	CapGitCreateRef        Capability = "Git.CreateRef"
+	CapGitDeleteRef        Capability = "Git.DeleteRef"
+	CapGitFindBranch       Capability = "Git.FindBranch"
+	CapGitFindCommit       Capability = "Git.FindCommit"
+	CapGitFindRef          Capability = "Git.FindRef"
+	CapGitFindTag          Capability = "Git.FindTag"
+	CapGitGetDefaultBranch Capability = "Git.GetDefaultBranch"
+	CapGitListBranches     Capability = "Git.ListBranches"
+	CapGitListChanges      Capability = "Git.ListChanges"
+	CapGitListCommits      Capability = "Git.ListCommits"
+	CapGitListTags         Capability = "Git.ListTags"
+
+	// GraphQL operations
+	CapGraphQLQuery Capability = "GraphQL.Query"
+
+	// Organizations operations
+	CapOrganizationAcceptOrganizationInvitation Capability = "Organizations.AcceptOrganizationInvitation"
+	CapOrganizationCreate                       Capability = "Organizations.Create"
+	CapOrganizationDelete                       Capability = "Organizations.Delete"
+	CapOrganizationFind                         Capability = "Organizations.Find"
+	CapOrganizationIsAdmin                      Capability = "Organizations.IsAdmin"
+	CapOrganizationIsMember                     Capability = "Organizations.IsMember"
+	CapOrganizationList                         Capability = "Organizations.List"
+	CapOrganizationListMemberships              Capability = "Organizations.ListMemberships"
+	CapOrganizationListOrgMembers               Capability = "Organizations.ListOrgMembers"
+	CapOrganizationListPendingInvitations       Capability = "Organizations.ListPendingInvitations"
+	CapOrganizationListTeamMembers              Capability = "Organizations.ListTeamMembers"
+	CapOrganizationListTeams                    Capability = "Organizations.ListTeams"
+
+	// Issues operations
+	CapIssueAddLabel       Capability = "Issues.AddLabel"
+	CapIssueAssignIssue    Capability = "Issues.AssignIssue"
+	CapIssueClearMilestone Capability = "Issues.ClearMilestone"
+	CapIssueClose          Capability = "Issues.Close"
+	CapIssueCreate         Capability = "Issues.Create"
+	CapIssueCreateComment  Capability = "Issues.CreateComment"
+	CapIssueDeleteComment  Capability = "Issues.DeleteComment"
+	CapIssueDeleteLabel    Capability = "Issues.DeleteLabel"
+	CapIssueEditComment    Capability = "Issues.EditComment"
+	CapIssueFind           Capability = "Issues.Find"
+	CapIssueFindComment    Capability = "Issues.FindComment"
+	CapIssueList           Capability = "Issues.List"
+	CapIssueListComments   Capability = "Issues.ListComments"
+	CapIssueListEvents     Capability = "Issues.ListEvents"
+	CapIssueListLabels     Capability = "Issues.ListLabels"
+	CapIssueLock           Capability = "Issues.Lock"
+	CapIssueReopen         Capability = "Issues.Reopen"
+	CapIssueSearch         Capability = "Issues.Search"
+	CapIssueSetMilestone   Capability = "Issues.SetMilestone"
+	CapIssueUnassignIssue  Capability = "Issues.UnassignIssue"
+	CapIssueUnlock         Capability = "Issues.Unlock"
+
+	// Milestones operations
+	CapMilestoneCreate Capability = "Milestones.Create"
+	CapMilestoneDelete Capability = "Milestones.Delete"
+	CapMilestoneFind   Capability = "Milestones.Find"
+	CapMilestoneList   Capability = "Milestones.List"
+	CapMilestoneUpdate Capability = "Milestones.Update"
+
+	// Releases operations
+	CapReleaseCreate      Capability = "Releases.Create"
+	CapReleaseDelete      Capability = "Releases.Delete"
+	CapReleaseDeleteByTag Capability = "Releases.DeleteByTag"
+	CapReleaseFind        Capability = "Releases.Find"
+	CapReleaseFindByTag   Capability = "Releases.FindByTag"
+	CapReleaseList        Capability = "Releases.List"
+	CapReleaseUpdate      Capability = "Releases.Update"
+	CapReleaseUpdateByTag Capability = "Releases.UpdateByTag"
+
+	// PullRequests operations
+	CapPullRequestAddLabel          Capability = "PullRequests.AddLabel"
+	CapPullRequestAssignIssue       Capability = "PullRequests.AssignIssue"
+	CapPullRequestClearMilestone    Capability = "PullRequests.ClearMilestone"
+	CapPullRequestClose             Capability = "PullRequests.Close"
+	CapPullRequestCreate            Capability = "PullRequests.Create"
+	CapPullRequestCreateComment     Capability = "PullRequests.CreateComment"
+	CapPullRequestDeleteComment     Capability = "PullRequests.DeleteComment"
+	CapPullRequestDeleteLabel       Capability = "PullRequests.DeleteLabel"
+	CapPullRequestDeletePullRequest Capability = "PullRequests.DeletePullRequest"
+	CapPullRequestEditComment       Capability = "PullRequests.EditComment"
+	CapPullRequestFind              Capability = "PullRequests.Find"
+	CapPullRequestFindComment       Capability = "PullRequests.FindComment"
+	CapPullRequestList              Capability = "PullRequests.List"
+	CapPullRequestListChanges       Capability = "PullRequests.ListChanges"
+	CapPullRequestListComments      Capability = "PullRequests.ListComments"
+	CapPullRequestListCommits       Capability = "PullRequests.ListCommits"
+	CapPullRequestListEvents        Capability = "PullRequests.ListEvents"
+	CapPullRequestListLabels        Capability = "PullRequests.ListLabels"
+	CapPullRequestMerge             Capability = "PullRequests.Merge"
+	CapPullRequestReopen            Capability = "PullRequests.Reopen"
+	CapPullRequestRequestReview     Capability = "PullRequests.RequestReview"
+	CapPullRequestSetMilestone      Capability = "PullRequests.SetMilestone"
+	CapPullRequestUnassignIssue     Capability = "PullRequests.UnassignIssue"
+	CapPullRequestUnrequestReview   Capability = "PullRequests.UnrequestReview"
+	CapPullRequestUpdate            Capability = "PullRequests.Update"
+
+	// Repositories operations
+	CapRepositoryAddCollaborator    Capability = "Repositories.AddCollaborator"
+	CapRepositoryCreate             Capability = "Repositories.Create"
+	CapRepositoryCreateHook         Capability = "Repositories.CreateHook"
+	CapRepositoryCreateStatus       Capability = "Repositories.CreateStatus"
+	CapRepositoryDelete             Capability = "Repositories.Delete"
+	CapRepositoryDeleteHook         Capability = "Repositories.DeleteHook"
+	CapRepositoryFind               Capability = "Repositories.Find"
+	CapRepositoryFindCombinedStatus Capability = "Repositories.FindCombinedStatus"
+	CapRepositoryFindHook           Capability = "Repositories.FindHook"
+	CapRepositoryFindPerms          Capability = "Repositories.FindPerms"
+	CapRepositoryFindUserPermission Capability = "Repositories.FindUserPermission"
+	CapRepositoryFork               Capability = "Repositories.Fork"
+	CapRepositoryIsCollaborator     Capability = "Repositories.IsCollaborator"
+	CapRepositoryList               Capability = "Repositories.List"
+	CapRepositoryListCollaborators  Capability = "Repositories.ListCollaborators"
+	CapRepositoryListHooks          Capability = "Repositories.ListHooks"
+	CapRepositoryListLabels         Capability = "Repositories.ListLabels"
+	CapRepositoryListOrganisation   Capability = "Repositories.ListOrganisation"
+	CapRepositoryListStatus         Capability = "Repositories.ListStatus"
+	CapRepositoryListUser           Capability = "Repositories.ListUser"
+	CapRepositoryUpdateHook         Capability = "Repositories.UpdateHook"
+
+	// Reviews operations
+	CapReviewCreate       Capability = "Reviews.Create"
+	CapReviewDelete       Capability = "Reviews.Delete"
+	CapReviewDismiss      Capability = "Reviews.Dismiss"
+	CapReviewFind         Capability = "Reviews.Find"
+	CapReviewList         Capability = "Reviews.List"
+	CapReviewListComments Capability = "Reviews.ListComments"
+	CapReviewSubmit       Capability = "Reviews.Submit"
+	CapReviewUpdate       Capability = "Reviews.Update"
+
+	// Users operations
+	CapUserAcceptInvitation Capability = "Users.AcceptInvitation"
+	CapUserCreateToken      Capability = "Users.CreateToken"
+	CapUserDeleteToken      Capability = "Users.DeleteToken"
+	CapUserFind             Capability = "Users.Find"
+	CapUserFindEmail        Capability = "Users.FindEmail"
+	CapUserFindLogin        Capability = "Users.FindLogin"
+	CapUserListInvitations  Capability = "Users.ListInvitations"
+
+	// Webhooks operations
+	CapWebhookParse Capability = "Webhooks.Parse"
+
+	// Commits operations
+	CapCommitUpdateCommitStatus Capability = "Commits.UpdateCommitStatus"
+)
+
+// operations lists the capabilities of every service
+// operation of the Client.
+var operations = []Capability{
+	CapAppCreateInstallationToken,
+	CapAppGetOrganisationInstallation,
+	CapAppGetRepositoryInstallation,
+	CapAppGetUserInstallation,
+	CapContentCreate,
+	CapContentDelete,
+	CapContentFind,
+	CapContentList,
+	CapContentUpdate,
+	CapDeploymentCreate,
+	CapDeploymentCreateStatus,
+	CapDeploymentDelete,
+	CapDeploymentFind,
+	CapDeploymentFindStatus,
+	CapDeploymentList,
+	CapDeploymentListStatus,
+	CapGitCompareCommits,
+	CapGitCreateRef,
+	CapGitDeleteRef,
+	CapGitFindBranch,
+	CapGitFindCommit,
+	CapGitFindRef,
+	CapGitFindTag,
+	CapGitGetDefaultBranch,
+	CapGitListBranches,
+	CapGitListChanges,
+	CapGitListCommits,
+	CapGitListTags,
+	CapGraphQLQuery,
+	CapOrganizationAcceptOrganizationInvitation,
+	CapOrganizationCreate,
+	CapOrganizationDelete,
+	CapOrganizationFind,
+	CapOrganizationIsAdmin,
+	CapOrganizationIsMember,
+	CapOrganizationList,
+	CapOrganizationListMemberships,
+	CapOrganizationListOrgMembers,
+	CapOrganizationListPendingInvitations,
+	CapOrganizationListTeamMembers,
+	CapOrganizationListTeams,
+	CapIssueAddLabel,
+	CapIssueAssignIssue,
+	CapIssueClearMilestone,
+	CapIssueClose,
+	CapIssueCreate,
+	CapIssueCreateComment,
+	CapIssueDeleteComment,
+	CapIssueDeleteLabel,
+	CapIssueEditComment,
+	CapIssueFind,
+	CapIssueFindComment,
+	CapIssueList,
+	CapIssueListComments,
+	CapIssueListEvents,
+	CapIssueListLabels,
+	CapIssueLock,
+	CapIssueReopen,
+	CapIssueSearch,
+	CapIssueSetMilestone,
+	CapIssueUnassignIssue,
+	CapIssueUnlock,
+	CapMilestoneCreate,
+	CapMilestoneDelete,
+	CapMilestoneFind,
+	CapMilestoneList,
+	CapMilestoneUpdate,
+	CapReleaseCreate,
+	CapReleaseDelete,
+	CapReleaseDeleteByTag,
+	CapReleaseFind,
+	CapReleaseFindByTag,
+	CapReleaseList,
+	CapReleaseUpdate,
+	CapReleaseUpdateByTag,
+	CapPullRequestAddLabel,
+	CapPullRequestAssignIssue,
+	CapPullRequestClearMilestone,
+	CapPullRequestClose,
+	CapPullRequestCreate,
+	CapPullRequestCreateComment,
+	CapPullRequestDeleteComment,
+	CapPullRequestDeleteLabel,
+	CapPullRequestDeletePullRequest,
+	CapPullRequestEditComment,
+	CapPullRequestFind,
+	CapPullRequestFindComment,
+	CapPullRequestList,
+	CapPullRequestListChanges,
+	CapPullRequestListComments,
+	CapPullRequestListCommits,
+	CapPullRequestListEvents,
+	CapPullRequestListLabels,
+	CapPullRequestMerge,
+	CapPullRequestReopen,
+	CapPullRequestRequestReview,
+	CapPullRequestSetMilestone,
+	CapPullRequestUnassignIssue,
+	CapPullRequestUnrequestReview,
+	CapPullRequestUpdate,
+	CapRepositoryAddCollaborator,
+	CapRepositoryCreate,
+	CapRepositoryCreateHook,
+	CapRepositoryCreateStatus,
+	CapRepositoryDelete,
+	CapRepositoryDeleteHook,
+	CapRepositoryFind,
+	CapRepositoryFindCombinedStatus,
+	CapRepositoryFindHook,
+	CapRepositoryFindPerms,
+	CapRepositoryFindUserPermission,
+	CapRepositoryFork,
+	CapRepositoryIsCollaborator,
+	CapRepositoryList,
+	CapRepositoryListCollaborators,
+	CapRepositoryListHooks,
+	CapRepositoryListLabels,
+	CapRepositoryListOrganisation,
+	CapRepositoryListStatus,
+	CapRepositoryListUser,
+	CapRepositoryUpdateHook,
+	CapReviewCreate,
+	CapReviewDelete,
+	CapReviewDismiss,
+	CapReviewFind,
+	CapReviewList,
+	CapReviewListComments,
+	CapReviewSubmit,
+	CapReviewUpdate,
+	CapUserAcceptInvitation,
+	CapUserCreateToken,
+	CapUserDeleteToken,
+	CapUserFind,
+	CapUserFindEmail,
+	CapUserFindLogin,
+	CapUserListInvitations,
+	CapWebhookParse,
+	CapCommitUpdateCommitStatus,
+}
+
+// Operations returns the capabilities of every service
+// operation of the Client.
+func Operations() []Capability {
+	return slices.Clone(operations)
+}
+
+// Service returns the name of the Client service of the
+// operation, for example PullRequests.
+func (c Capability) Service() string {
+	service, _, _ := strings.Cut(string(c), ".")
+	return service
+}
+
+// Method returns the name of the service method of the
+// operation, for example Merge.
+func (c Capability) Method() string {
+	_, method, _ := strings.Cut(string(c), ".")
+	return method
+}
+
+// LabelSupport describes how a driver supports labels on
+// issues and pull requests.
+type LabelSupport int
+
+// LabelSupport values.
+const (
+	// LabelsNotSupported indicates labels are not
+	// supported.
+	LabelsNotSupported LabelSupport = iota
+
+	// LabelsNative indicates labels are stored by the
+	// provider.
+	LabelsNative
+
+	// LabelsComments indicates labels are emulated with
+	// /label comments, see package labels.
+	LabelsComments
+)
+
+// String returns the string representation of the label
+// support.
+func (l LabelSupport) String() string {
+	switch l {
+	case LabelsNative:
+		return "native"
+	case LabelsComments:
+		return "comments"
+	default:
+		return "none"
+	}
+}
+
+// Capabilities describes the features supported by a
+// driver. Drivers declare their capabilities in a single
+// table, so callers can check for a feature upfront rather
+// than handling ErrNotSupported at runtime.
+type Capabilities struct {
+	// Unsupported lists the operations returning
+	// ErrNotSupported. Every other operation is supported.
+	Unsupported []Capability
+
+	// Webhooks lists the kinds of webhooks parsed by the
+	// driver.
+	Webhooks []WebhookKind
+
+	// MergeMethods lists the pull request merge methods
+	// supported by the driver.
+	MergeMethods []string
+
+	// Labels describes how labels are supported.
+	Labels LabelSupport
+}
+
+// Supports reports whether the operation is supported.
+// A nil Capabilities supports every operation.
+func (c *Capabilities) Supports(op Capability) bool {
+	if c == nil {
+		return true
+	}
+	return !slices.Contains(c.Unsupported, op)
+}
+
+// Operations returns the supported operations.
+func (c *Capabilities) Operations() []Capability {
+	var supported []Capability
+	for _, op := range operations {
+		if c.Supports(op) {
+			supported = append(supported, op)
+		}
+	}
+	return supported
+}
+
+// SupportsWebhook reports whether webhooks of the kind are
+// parsed by the driver.
+func (c *Capabilities) SupportsWebhook(kind WebhookKind) bool {
+	return c != nil && slices.Contains(c.Webhooks, kind)
+}
+
+// SupportsMergeMethod reports whether pull requests can be
+// merged using the method.
+func (c *Capabilities) SupportsMergeMethod(method string) bool {
+	return c != nil && slices.Contains(c.MergeMethods, method)
+}
+
+// Capabilities returns the capabilities of the driver, or
+// nil if the driver does not declare its capabilities.
+// The returned value must not be modified.
+func (c *Client) Capabilities() *Capabilities {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capabilities
+}
+
+// SetCapabilities sets the capabilities of the driver.
+func (c *Client) SetCapabilities(capabilities *Capabilities) {
+	c.mu.Lock()
+	c.capabilities = capabilities
+	c.mu.Unlock()
+}
+
+// Supports reports whether the client supports the
+// operation. Operations of services not implemented by the
+// driver are never supported.
+func Supports(client *Client, op Capability) bool {
+	if client == nil || !client.hasService(op.Service()) {
+		return false
+	}
+	return client.Capabilities().Supports(op)
+}
+
+// hasService reports whether the client implements the
+// named service.
+func (c *Client) hasService(name string) bool {
+	field := reflect.ValueOf(c).Elem().FieldByName(name)
+	return field.IsValid() && field.Kind() == reflect.Interface && !field.IsNil()
+}
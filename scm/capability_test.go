@@ -0,0 +1,72 @@
+package scm
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestOperations verifies the operations are in sync with
+// the methods of the client services.
+func TestOperations(t *testing.T) {
+	want := map[Capability]bool{}
+	typ := reflect.TypeOf(Client{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Interface {
+			continue
+		}
+		for j := 0; j < field.Type.NumMethod(); j++ {
+			want[Capability(field.Name+"."+field.Type.Method(j).Name)] = true
+		}
+	}
+	got := map[Capability]bool{}
+	for _, op := range Operations() {
+		if !want[op] {
+			t.Errorf("Want operation %s removed", op)
+		}
+		got[op] = true
+	}
+	for op := range want {
+		if !got[op] {
+			t.Errorf("Want operation %s declared", op)
+		}
+	}
+}
+
+func TestSupports(t *testing.T) {
+	client := &Client{Issues: struct{ IssueService }{}}
+	if !Supports(client, CapIssueFind) {
+		t.Errorf("Want operations supported without declared capabilities")
+	}
+	if Supports(client, CapPullRequestMerge) {
+		t.Errorf("Want operations of missing services not supported")
+	}
+
+	client.SetCapabilities(&Capabilities{
+		Unsupported:  []Capability{CapIssueLock},
+		Webhooks:     []WebhookKind{WebhookKindPush},
+		MergeMethods: []string{MergeMethodMerge},
+	})
+	if !Supports(client, CapIssueFind) {
+		t.Errorf("Want Issues.Find supported")
+	}
+	if Supports(client, CapIssueLock) {
+		t.Errorf("Want Issues.Lock not supported")
+	}
+	caps := client.Capabilities()
+	if !caps.SupportsWebhook(WebhookKindPush) || caps.SupportsWebhook(WebhookKindTag) {
+		t.Errorf("Want only push webhooks supported")
+	}
+	if !caps.SupportsMergeMethod(MergeMethodMerge) || caps.SupportsMergeMethod(MergeMethodSquash) {
+		t.Errorf("Want only the merge method supported")
+	}
+	if got, want := len(caps.Operations()), len(Operations())-1; got != want {
+		t.Errorf("Want %d supported operations, got %d", want, got)
+	}
+	if got, want := CapPullRequestMerge.Service(), "PullRequests"; got != want {
+		t.Errorf("Want service %q, got %q", want, got)
+	}
+	if got, want := CapPullRequestMerge.Method(), "Merge"; got != want {
+		t.Errorf("Want method %q, got %q", want, got)
+	}
+}
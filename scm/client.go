@@ -5,6 +5,7 @@
 package scm
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 var (
@@ -115,10 +117,29 @@ type (
 		// DumpResponse optionally specifies a function to
 		// dump the the response body for debugging purposes.
 		// This can be set to httputil.DumpResponse.
+		//
+		// Deprecated: use Use to register a Middleware,
+		// which also has access to the request, the latency
+		// and errors.
 		DumpResponse func(*http.Response, bool) ([]byte, error)
 
+		// Retry optionally specifies the policy used to
+		// retry failed requests. Requests are not retried
+		// if nil.
+		Retry *RetryPolicy
+
+		// Throttle optionally pauses requests when the
+		// remaining rate limit drops below a threshold.
+		Throttle *Throttler
+
 		// snapshot of the request rate limit.
 		rate Rate
+
+		// middleware invoked around every request.
+		middleware []Middleware
+
+		// capabilities supported by the driver.
+		capabilities *Capabilities
 	}
 )
 
@@ -130,6 +151,14 @@ func (c *Client) Rate() Rate {
 	return c.rate
 }
 
+// middlewareChain returns a snapshot of the middleware
+// registered with the client.
+func (c *Client) middlewareChain() []Middleware {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.middleware
+}
+
 // SetRate set the last recorded request rate limit for
 // the current client.
 func (c *Client) SetRate(rate Rate) {
@@ -145,13 +174,96 @@ func (c *Client) SetRate(rate Rate) {
 // interface, the raw response will be written to v,
 // without attempting to decode it.
 func (c *Client) Do(ctx context.Context, in *Request) (*Response, error) {
+	// use the default client if none provided.
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	policy := c.Retry
+	// buffered is the request body buffered so that it can
+	// be replayed if the request needs to be retried.
+	var buffered io.Reader
+	var body []byte
+
+	middleware := c.middlewareChain()
+	for attempt := 1; ; attempt++ {
+		if err := c.Throttle.Wait(ctx, c.Rate()); err != nil {
+			return nil, err
+		}
+		event := &RequestEvent{
+			Driver:  c.Driver,
+			Request: in,
+			Attempt: attempt,
+			Start:   time.Now(),
+		}
+		c.beforeRequest(ctx, middleware, event)
+
+		reader := in.Body
+		if policy.enabled() && in.Body != nil {
+			// the body is buffered again if a middleware
+			// replaced it.
+			if in.Body != buffered {
+				b, err := io.ReadAll(in.Body)
+				if err != nil {
+					c.afterRequest(ctx, middleware, event, nil, err)
+					return nil, err
+				}
+				body = b
+				in.Body = bytes.NewReader(body)
+				buffered = in.Body
+			}
+			reader = bytes.NewReader(body)
+		}
+		req, err := c.newRequest(ctx, in, reader)
+		if err != nil {
+			c.afterRequest(ctx, middleware, event, nil, err)
+			return nil, err
+		}
+
+		// The callers of this method should do the closing
+		//nolint:bodyclose
+		res, err := client.Do(req)
+
+		var wait time.Duration
+		var retry bool
+		if policy.enabled() {
+			wait, retry = policy.retry(in.Method, res, err, attempt)
+		}
+
+		var out *Response
+		var dumpErr error
+		if err == nil {
+			out, dumpErr = c.response(res)
+		}
+		c.afterRequest(ctx, middleware, event, out, err)
+
+		if !retry {
+			if err != nil {
+				return nil, err
+			}
+			return out, dumpErr
+		}
+		if out != nil {
+			// drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, out.Body)
+			out.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// newRequest creates a new http request with context for
+// the provided Request, resolving its path against the
+// base URL of the client.
+func (c *Client) newRequest(ctx context.Context, in *Request, body io.Reader) (*http.Request, error) {
 	uri, err := c.BaseURL.Parse(in.Path)
 	if err != nil {
 		return nil, err
 	}
-
-	// creates a new http request with context.
-	req, err := http.NewRequest(in.Method, uri.String(), in.Body)
+	req, err := http.NewRequestWithContext(ctx, in.Method, uri.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -160,29 +272,25 @@ func (c *Client) Do(ctx context.Context, in *Request) (*Response, error) {
 	if strings.Contains(in.Path, "%2F") {
 		req.URL.Opaque = strings.Split(req.URL.RawPath, "?")[0]
 	}
-
-	req = req.WithContext(ctx)
 	if in.Header != nil {
 		req.Header = in.Header
 	}
+	return req, nil
+}
 
-	// use the default client if none provided.
-	client := c.Client
-	if client == nil {
-		client = http.DefaultClient
-	}
-	// The callers of this method should do the closing
-	//nolint:bodyclose
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	// dumps the response for debugging purposes.
+// response dumps the http response for debugging purposes
+// if configured and converts it to a Response.
+func (c *Client) response(res *http.Response) (*Response, error) {
+	var err error
 	if c.DumpResponse != nil {
 		_, err = c.DumpResponse(res, true)
 	}
-	return newResponse(res), err
+	out := newResponse(res)
+	// snapshot the request rate limit
+	if out.Rate != (Rate{}) {
+		c.SetRate(out.Rate)
+	}
+	return out, err
 }
 
 // newResponse creates a new Response for the provided
@@ -193,10 +301,32 @@ func newResponse(r *http.Response) *Response {
 		Header: r.Header,
 		Body:   r.Body,
 	}
+	res.ID = requestID(r.Header)
 	res.PopulatePageValues()
+	res.PopulateRate()
 	return res
 }
 
+// requestIDHeaders are the headers used by the supported
+// providers to report the request ID.
+var requestIDHeaders = []string{
+	"X-GitHub-Request-Id", // GitHub
+	"X-Request-Id",        // GitLab, Bitbucket, Gitea
+	"X-Arequestid",        // Bitbucket Server
+	"ActivityId",          // Azure DevOps
+}
+
+// requestID returns the provider request ID of the
+// response, if any.
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
 // PopulatePageValues parses the HTTP Link response headers
 // and populates the various pagination link values in the
 // Response.
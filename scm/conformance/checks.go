@@ -0,0 +1,972 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// checks lists the checks, grouped by service.
+var checks = []*check{
+	// Repositories
+	{
+		op:    scm.CapRepositoryFind,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			repo, _, err := client.Repositories.Find(ctx, f.Repo)
+			if err != nil {
+				return err
+			}
+			if repo == nil {
+				return errors.New("want the repository, got nil")
+			}
+			if !strings.EqualFold(repo.FullName, f.Repo) {
+				return fmt.Errorf("want repository %s, got %s", f.Repo, repo.FullName)
+			}
+			if repo.Name == "" {
+				return errors.New("want the name of the repository, got none")
+			}
+			return nil
+		},
+	},
+	{
+		name:  "Repositories.Find/not-found",
+		op:    scm.CapRepositoryFind,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			owner, _, _ := strings.Cut(f.Repo, "/")
+			repo, _, err := client.Repositories.Find(ctx, owner+"/does-not-exist")
+			if err == nil {
+				return fmt.Errorf("want an error finding a missing repository, got %v", repo)
+			}
+			if errors.Is(err, scm.ErrNotSupported) {
+				return err
+			}
+			return nil
+		},
+	},
+	{
+		op: scm.CapRepositoryList,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.List(ctx, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapRepositoryListOrganisation,
+		needs: func(f *Fixture) bool { return f.Org != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			repos, _, err := client.Repositories.ListOrganisation(ctx, f.Org, &scm.ListOptions{})
+			if err != nil {
+				return err
+			}
+			if len(repos) == 0 {
+				return fmt.Errorf("want the repositories of organization %s, got none", f.Org)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapRepositoryListLabels,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.ListLabels(ctx, f.Repo, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapRepositoryListHooks,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.ListHooks(ctx, f.Repo, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapRepositoryListStatus,
+		needs: hasCommit,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.ListStatus(ctx, f.Repo, f.Commit, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapRepositoryFindCombinedStatus,
+		needs: hasCommit,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			status, _, err := client.Repositories.FindCombinedStatus(ctx, f.Repo, f.Commit)
+			if err != nil {
+				return err
+			}
+			if status == nil {
+				return errors.New("want the combined status, got nil")
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapRepositoryFindHook,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Hook != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			hook, _, err := client.Repositories.FindHook(ctx, f.Repo, f.Hook)
+			if err != nil {
+				return err
+			}
+			if hook == nil {
+				return errors.New("want the hook, got nil")
+			}
+			if hook.ID != f.Hook {
+				return fmt.Errorf("want hook %s, got %s", f.Hook, hook.ID)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapRepositoryFindPerms,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			perms, _, err := client.Repositories.FindPerms(ctx, f.Repo)
+			if err != nil {
+				return err
+			}
+			if perms == nil {
+				return errors.New("want the permissions, got nil")
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapRepositoryFindUserPermission,
+		needs: hasRepoUser,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.FindUserPermission(ctx, f.Repo, f.User)
+			return err
+		},
+	},
+	{
+		op:    scm.CapRepositoryIsCollaborator,
+		needs: hasRepoUser,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.IsCollaborator(ctx, f.Repo, f.User)
+			return err
+		},
+	},
+	{
+		op:    scm.CapRepositoryListCollaborators,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.ListCollaborators(ctx, f.Repo, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapRepositoryListUser,
+		needs: func(f *Fixture) bool { return f.User != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Repositories.ListUser(ctx, f.User, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:      scm.CapRepositoryCreateStatus,
+		needs:   hasCommit,
+		mutates: true,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			label := uniqueName("conformance")
+			input := &scm.StatusInput{
+				State: scm.StateSuccess,
+				Label: label,
+				Desc:  "conformance check",
+			}
+			if _, _, err := client.Repositories.CreateStatus(ctx, f.Repo, f.Commit, input); err != nil {
+				return err
+			}
+			statuses, _, err := client.Repositories.ListStatus(ctx, f.Repo, f.Commit, &scm.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list the statuses: %w", err)
+			}
+			for _, status := range statuses {
+				if status.Label == label {
+					if status.State != scm.StateSuccess {
+						return fmt.Errorf("want status %s, got %s", scm.StateSuccess, status.State)
+					}
+					return nil
+				}
+			}
+			return fmt.Errorf("want the created status %s, got none", label)
+		},
+	},
+
+	// PullRequests
+	{
+		op:    scm.CapPullRequestFind,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			pr, _, err := client.PullRequests.Find(ctx, f.Repo, f.PullRequest)
+			if err != nil {
+				return err
+			}
+			if pr == nil {
+				return errors.New("want the pull request, got nil")
+			}
+			if pr.Number != f.PullRequest {
+				return fmt.Errorf("want pull request %d, got %d", f.PullRequest, pr.Number)
+			}
+			if pr.Title == "" {
+				return errors.New("want the title of the pull request, got none")
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapPullRequestList,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			prs, _, err := client.PullRequests.List(ctx, f.Repo, &scm.PullRequestListOptions{Open: true})
+			if err != nil {
+				return err
+			}
+			found := false
+			for _, pr := range prs {
+				if pr.Closed || pr.Merged {
+					return fmt.Errorf("want open pull requests, got closed pull request %d", pr.Number)
+				}
+				found = found || pr.Number == f.PullRequest
+			}
+			if !found {
+				return fmt.Errorf("want pull request %d in the open pull requests, got none", f.PullRequest)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapPullRequestListChanges,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.PullRequests.ListChanges(ctx, f.Repo, f.PullRequest, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapPullRequestListComments,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.PullRequests.ListComments(ctx, f.Repo, f.PullRequest, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapPullRequestFindComment,
+		needs: func(f *Fixture) bool { return hasPullRequest(f) && f.PullRequestComment != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			comment, _, err := client.PullRequests.FindComment(ctx, f.Repo, f.PullRequest, f.PullRequestComment)
+			if err != nil {
+				return err
+			}
+			if comment == nil {
+				return errors.New("want the comment, got nil")
+			}
+			if comment.ID != f.PullRequestComment {
+				return fmt.Errorf("want comment %d, got %d", f.PullRequestComment, comment.ID)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapPullRequestListCommits,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.PullRequests.ListCommits(ctx, f.Repo, f.PullRequest, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapPullRequestListEvents,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.PullRequests.ListEvents(ctx, f.Repo, f.PullRequest, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapPullRequestListLabels,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.PullRequests.ListLabels(ctx, f.Repo, f.PullRequest, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:      scm.CapPullRequestCreateComment,
+		needs:   hasPullRequest,
+		mutates: true,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			body := uniqueName("conformance comment")
+			if _, _, err := client.PullRequests.CreateComment(ctx, f.Repo, f.PullRequest, &scm.CommentInput{Body: body}); err != nil {
+				return err
+			}
+			comments, _, err := client.PullRequests.ListComments(ctx, f.Repo, f.PullRequest, &scm.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list the comments: %w", err)
+			}
+			return containsComment(comments, body)
+		},
+	},
+
+	// Issues
+	{
+		op:    scm.CapIssueFind,
+		needs: hasIssue,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			issue, _, err := client.Issues.Find(ctx, f.Repo, f.Issue)
+			if err != nil {
+				return err
+			}
+			if issue == nil {
+				return errors.New("want the issue, got nil")
+			}
+			if issue.Number != f.Issue {
+				return fmt.Errorf("want issue %d, got %d", f.Issue, issue.Number)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapIssueListComments,
+		needs: hasIssue,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Issues.ListComments(ctx, f.Repo, f.Issue, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapIssueFindComment,
+		needs: func(f *Fixture) bool { return hasIssue(f) && f.IssueComment != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			comment, _, err := client.Issues.FindComment(ctx, f.Repo, f.Issue, f.IssueComment)
+			if err != nil {
+				return err
+			}
+			if comment == nil {
+				return errors.New("want the comment, got nil")
+			}
+			if comment.ID != f.IssueComment {
+				return fmt.Errorf("want comment %d, got %d", f.IssueComment, comment.ID)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapIssueList,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Issues.List(ctx, f.Repo, scm.IssueListOptions{Open: true})
+			return err
+		},
+	},
+	{
+		op:    scm.CapIssueListLabels,
+		needs: hasIssue,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Issues.ListLabels(ctx, f.Repo, f.Issue, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapIssueListEvents,
+		needs: hasIssue,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Issues.ListEvents(ctx, f.Repo, f.Issue, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapIssueSearch,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Issues.Search(ctx, scm.SearchOptions{Query: "repo:" + f.Repo})
+			return err
+		},
+	},
+	{
+		op:      scm.CapIssueCreateComment,
+		needs:   func(f *Fixture) bool { return f.Repo != "" && f.Issue != 0 },
+		mutates: true,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			body := uniqueName("conformance comment")
+			if _, _, err := client.Issues.CreateComment(ctx, f.Repo, f.Issue, &scm.CommentInput{Body: body}); err != nil {
+				return err
+			}
+			comments, _, err := client.Issues.ListComments(ctx, f.Repo, f.Issue, &scm.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list the comments: %w", err)
+			}
+			return containsComment(comments, body)
+		},
+	},
+
+	// Users
+	{
+		op:    scm.CapUserFind,
+		needs: func(f *Fixture) bool { return f.User != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			user, _, err := client.Users.Find(ctx)
+			if err != nil {
+				return err
+			}
+			if user == nil {
+				return errors.New("want the user, got nil")
+			}
+			if user.Login != f.User {
+				return fmt.Errorf("want user %s, got %s", f.User, user.Login)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapUserFindEmail,
+		needs: func(f *Fixture) bool { return f.User != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Users.FindEmail(ctx)
+			return err
+		},
+	},
+	{
+		op:    scm.CapUserFindLogin,
+		needs: func(f *Fixture) bool { return f.User != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			user, _, err := client.Users.FindLogin(ctx, f.User)
+			if err != nil {
+				return err
+			}
+			if user == nil {
+				return errors.New("want the user, got nil")
+			}
+			if user.Login != f.User {
+				return fmt.Errorf("want user %s, got %s", f.User, user.Login)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapUserListInvitations,
+		needs: func(f *Fixture) bool { return f.User != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Users.ListInvitations(ctx)
+			return err
+		},
+	},
+
+	// Organizations
+	{
+		op:    scm.CapOrganizationFind,
+		needs: hasOrg,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			org, _, err := client.Organizations.Find(ctx, f.Org)
+			if err != nil {
+				return err
+			}
+			if org == nil {
+				return errors.New("want the organization, got nil")
+			}
+			if !strings.EqualFold(org.Name, f.Org) {
+				return fmt.Errorf("want organization %s, got %s", f.Org, org.Name)
+			}
+			return nil
+		},
+	},
+	{
+		op: scm.CapOrganizationList,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.List(ctx, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapOrganizationIsAdmin,
+		needs: hasOrgUser,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.IsAdmin(ctx, f.Org, f.User)
+			return err
+		},
+	},
+	{
+		op:    scm.CapOrganizationIsMember,
+		needs: hasOrgUser,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.IsMember(ctx, f.Org, f.User)
+			return err
+		},
+	},
+	{
+		op: scm.CapOrganizationListMemberships,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.ListMemberships(ctx, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapOrganizationListOrgMembers,
+		needs: hasOrg,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.ListOrgMembers(ctx, f.Org, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapOrganizationListPendingInvitations,
+		needs: hasOrg,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.ListPendingInvitations(ctx, f.Org, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapOrganizationListTeams,
+		needs: hasOrg,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.ListTeams(ctx, f.Org, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapOrganizationListTeamMembers,
+		needs: func(f *Fixture) bool { return f.Team != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Organizations.ListTeamMembers(ctx, f.Team, "all", &scm.ListOptions{})
+			return err
+		},
+	},
+
+	// Apps
+	{
+		op:    scm.CapAppGetRepositoryInstallation,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Installation != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			installation, _, err := client.Apps.GetRepositoryInstallation(ctx, f.Repo)
+			return checkInstallation(installation, err, f)
+		},
+	},
+	{
+		op:    scm.CapAppGetOrganisationInstallation,
+		needs: func(f *Fixture) bool { return f.Org != "" && f.Installation != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			installation, _, err := client.Apps.GetOrganisationInstallation(ctx, f.Org)
+			return checkInstallation(installation, err, f)
+		},
+	},
+	{
+		op:    scm.CapAppGetUserInstallation,
+		needs: func(f *Fixture) bool { return f.User != "" && f.Installation != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			installation, _, err := client.Apps.GetUserInstallation(ctx, f.User)
+			return checkInstallation(installation, err, f)
+		},
+	},
+
+	// GraphQL
+	{
+		op:    scm.CapGraphQLQuery,
+		needs: func(f *Fixture) bool { return f.User != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			var query struct {
+				Viewer struct {
+					Login string
+				}
+			}
+			if err := client.GraphQL.Query(ctx, &query, nil); err != nil {
+				return err
+			}
+			if query.Viewer.Login != f.User {
+				return fmt.Errorf("want viewer %s, got %s", f.User, query.Viewer.Login)
+			}
+			return nil
+		},
+	},
+
+	// Contents
+	{
+		op:    scm.CapContentFind,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Path != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			content, _, err := client.Contents.Find(ctx, f.Repo, f.Path, f.Branch)
+			if err != nil {
+				return err
+			}
+			if content == nil {
+				return errors.New("want the content, got nil")
+			}
+			if len(content.Data) == 0 {
+				return fmt.Errorf("want the content of %s, got none", f.Path)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapContentList,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Path != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			entries, _, err := client.Contents.List(ctx, f.Repo, "", f.Branch, &scm.ListOptions{})
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				if entry.Path == f.Path {
+					return nil
+				}
+			}
+			return fmt.Errorf("want %s in the contents, got none", f.Path)
+		},
+	},
+
+	// Git
+	{
+		op:    scm.CapGitFindBranch,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Branch != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			branch, _, err := client.Git.FindBranch(ctx, f.Repo, f.Branch)
+			if err != nil {
+				return err
+			}
+			if branch == nil {
+				return errors.New("want the branch, got nil")
+			}
+			if branch.Name != f.Branch {
+				return fmt.Errorf("want branch %s, got %s", f.Branch, branch.Name)
+			}
+			if branch.Sha == "" {
+				return errors.New("want the SHA of the branch, got none")
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapGitFindCommit,
+		needs: hasCommit,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			commit, _, err := client.Git.FindCommit(ctx, f.Repo, f.Commit)
+			if err != nil {
+				return err
+			}
+			if commit == nil {
+				return errors.New("want the commit, got nil")
+			}
+			if commit.Sha != f.Commit {
+				return fmt.Errorf("want commit %s, got %s", f.Commit, commit.Sha)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapGitFindRef,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Branch != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			sha, _, err := client.Git.FindRef(ctx, f.Repo, "heads/"+f.Branch)
+			if err != nil {
+				return err
+			}
+			if sha == "" {
+				return errors.New("want the SHA of the ref, got none")
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapGitFindTag,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Tag != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			tag, _, err := client.Git.FindTag(ctx, f.Repo, f.Tag)
+			if err != nil {
+				return err
+			}
+			if tag == nil {
+				return errors.New("want the tag, got nil")
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapGitGetDefaultBranch,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			branch, _, err := client.Git.GetDefaultBranch(ctx, f.Repo)
+			if err != nil {
+				return err
+			}
+			if branch == nil || branch.Name == "" {
+				return errors.New("want the default branch, got none")
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapGitListBranches,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Git.ListBranches(ctx, f.Repo, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapGitListTags,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Git.ListTags(ctx, f.Repo, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapGitListCommits,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Branch != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Git.ListCommits(ctx, f.Repo, scm.CommitListOptions{Ref: f.Branch})
+			return err
+		},
+	},
+	{
+		op:    scm.CapGitListChanges,
+		needs: hasCommit,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Git.ListChanges(ctx, f.Repo, f.Commit, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapGitCompareCommits,
+		needs: hasCommit,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Git.CompareCommits(ctx, f.Repo, f.Commit, f.Commit, &scm.ListOptions{})
+			return err
+		},
+	},
+
+	// Releases
+	{
+		op:    scm.CapReleaseFindByTag,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Tag != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			release, _, err := client.Releases.FindByTag(ctx, f.Repo, f.Tag)
+			if err != nil {
+				return err
+			}
+			if release == nil {
+				return errors.New("want the release, got nil")
+			}
+			if release.Tag != f.Tag {
+				return fmt.Errorf("want release %s, got %s", f.Tag, release.Tag)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapReleaseFind,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Release != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			release, _, err := client.Releases.Find(ctx, f.Repo, f.Release)
+			if err != nil {
+				return err
+			}
+			if release == nil {
+				return errors.New("want the release, got nil")
+			}
+			if release.ID != f.Release {
+				return fmt.Errorf("want release %d, got %d", f.Release, release.ID)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapReleaseList,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Releases.List(ctx, f.Repo, scm.ReleaseListOptions{Open: true, Closed: true})
+			return err
+		},
+	},
+
+	// Milestones
+	{
+		op:    scm.CapMilestoneFind,
+		needs: func(f *Fixture) bool { return f.Repo != "" && f.Milestone != 0 },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			milestone, _, err := client.Milestones.Find(ctx, f.Repo, f.Milestone)
+			if err != nil {
+				return err
+			}
+			if milestone == nil {
+				return errors.New("want the milestone, got nil")
+			}
+			if milestone.Number != f.Milestone {
+				return fmt.Errorf("want milestone %d, got %d", f.Milestone, milestone.Number)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapMilestoneList,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Milestones.List(ctx, f.Repo, scm.MilestoneListOptions{Open: true, Closed: true})
+			return err
+		},
+	},
+
+	// Reviews
+	{
+		op:    scm.CapReviewFind,
+		needs: hasReview,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			review, _, err := client.Reviews.Find(ctx, f.Repo, f.PullRequest, f.Review)
+			if err != nil {
+				return err
+			}
+			if review == nil {
+				return errors.New("want the review, got nil")
+			}
+			if review.ID != f.Review {
+				return fmt.Errorf("want review %d, got %d", f.Review, review.ID)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapReviewList,
+		needs: hasPullRequest,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Reviews.List(ctx, f.Repo, f.PullRequest, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapReviewListComments,
+		needs: hasReview,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Reviews.ListComments(ctx, f.Repo, f.PullRequest, f.Review, &scm.ListOptions{})
+			return err
+		},
+	},
+
+	// Deployments
+	{
+		op:    scm.CapDeploymentFind,
+		needs: hasDeployment,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			deployment, _, err := client.Deployments.Find(ctx, f.Repo, f.Deployment)
+			if err != nil {
+				return err
+			}
+			if deployment == nil {
+				return errors.New("want the deployment, got nil")
+			}
+			if deployment.ID != f.Deployment {
+				return fmt.Errorf("want deployment %s, got %s", f.Deployment, deployment.ID)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapDeploymentList,
+		needs: hasRepo,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Deployments.List(ctx, f.Repo, &scm.ListOptions{})
+			return err
+		},
+	},
+	{
+		op:    scm.CapDeploymentFindStatus,
+		needs: func(f *Fixture) bool { return hasDeployment(f) && f.DeploymentStatus != "" },
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			status, _, err := client.Deployments.FindStatus(ctx, f.Repo, f.Deployment, f.DeploymentStatus)
+			if err != nil {
+				return err
+			}
+			if status == nil {
+				return errors.New("want the deployment status, got nil")
+			}
+			if status.ID != f.DeploymentStatus {
+				return fmt.Errorf("want deployment status %s, got %s", f.DeploymentStatus, status.ID)
+			}
+			return nil
+		},
+	},
+	{
+		op:    scm.CapDeploymentListStatus,
+		needs: hasDeployment,
+		run: func(ctx context.Context, client *scm.Client, f *Fixture) error {
+			_, _, err := client.Deployments.ListStatus(ctx, f.Repo, f.Deployment, &scm.ListOptions{})
+			return err
+		},
+	},
+}
+
+func hasRepo(f *Fixture) bool {
+	return f.Repo != ""
+}
+
+func hasRepoUser(f *Fixture) bool {
+	return f.Repo != "" && f.User != ""
+}
+
+func hasOrg(f *Fixture) bool {
+	return f.Org != ""
+}
+
+func hasOrgUser(f *Fixture) bool {
+	return f.Org != "" && f.User != ""
+}
+
+func hasPullRequest(f *Fixture) bool {
+	return f.Repo != "" && f.PullRequest != 0
+}
+
+func hasIssue(f *Fixture) bool {
+	return f.Repo != "" && f.Issue != 0
+}
+
+func hasReview(f *Fixture) bool {
+	return hasPullRequest(f) && f.Review != 0
+}
+
+func hasDeployment(f *Fixture) bool {
+	return f.Repo != "" && f.Deployment != ""
+}
+
+func hasCommit(f *Fixture) bool {
+	return f.Repo != "" && f.Commit != ""
+}
+
+// checkInstallation returns an error unless the installation
+// is the one of the fixture.
+func checkInstallation(installation *scm.Installation, err error, f *Fixture) error {
+	if err != nil {
+		return err
+	}
+	if installation == nil {
+		return errors.New("want the installation, got nil")
+	}
+	if installation.ID != f.Installation {
+		return fmt.Errorf("want installation %d, got %d", f.Installation, installation.ID)
+	}
+	return nil
+}
+
+// containsComment returns an error unless a comment has the
+// body.
+func containsComment(comments []*scm.Comment, body string) error {
+	for _, comment := range comments {
+		if comment.Body == body {
+			return nil
+		}
+	}
+	return fmt.Errorf("want the created comment %q, got none", body)
+}
+
+// uniqueName returns the name with a unique suffix, so that
+// the resources created by the checks can be told apart.
+func uniqueName(name string) string {
+	return fmt.Sprintf("%s %d", name, time.Now().UnixNano())
+}
@@ -0,0 +1,300 @@
+// Package conformance provides a test suite checking that
+// the drivers behave the same way. The suite runs the same
+// behavioral checks against a client of each driver, backed
+// by the fake driver, a stand-in HTTP server or a real git
+// server, and reports which checks pass, fail or are not
+// supported by the driver.
+//
+// The checks cover every operation reading resources, and a
+// few creating them: the statuses of the commits and the
+// comments of the pull requests and issues. The other
+// operations creating, updating or deleting resources are
+// not checked.
+//
+// The checks read the resources described by the Fixture of
+// the target, and only create resources if the fixture
+// allows mutations.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// Status is the outcome of a check.
+type Status int
+
+// Status values.
+const (
+	// Pass means the driver behaves as expected.
+	Pass Status = iota
+	// Fail means the driver misbehaves, including returning
+	// scm.ErrNotSupported for an operation its capabilities
+	// report as supported.
+	Fail
+	// NotSupported means the driver does not support the
+	// operation, as reported by its capabilities.
+	NotSupported
+	// Skipped means the check was not run, because the
+	// fixture lacks the resources it needs or the target
+	// skips it.
+	Skipped
+)
+
+// String returns the string representation of the status.
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "pass"
+	case Fail:
+		return "fail"
+	case NotSupported:
+		return "not supported"
+	case Skipped:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
+// Fixture describes the resources held by the server of a
+// target, which the checks read and compare against. The
+// checks needing a resource the fixture lacks are skipped.
+type Fixture struct {
+	// Repo is the full name of a repository.
+	Repo string
+	// Org is the organization owning repositories.
+	Org string
+	// User is the login of the authenticated user.
+	User string
+	// PullRequest is the number of an open pull request of
+	// the repository.
+	PullRequest int
+	// PullRequestComment is the ID of a comment of the pull
+	// request.
+	PullRequestComment int
+	// Review is the ID of a review of the pull request.
+	Review int
+	// Issue is the number of an issue of the repository.
+	Issue int
+	// IssueComment is the ID of a comment of the issue.
+	IssueComment int
+	// Branch is the name of a branch of the repository.
+	Branch string
+	// Commit is the SHA of a commit of the repository.
+	Commit string
+	// Path is the path of a file of the branch.
+	Path string
+	// Tag is the tag of a release of the repository.
+	Tag string
+	// Release is the ID of a release of the repository.
+	Release int
+	// Milestone is the number of a milestone of the
+	// repository.
+	Milestone int
+	// Hook is the ID of a hook of the repository.
+	Hook string
+	// Deployment is the ID of a deployment of the repository.
+	Deployment string
+	// DeploymentStatus is the ID of a status of the
+	// deployment.
+	DeploymentStatus string
+	// Team is the ID of a team of the organization.
+	Team int
+	// Installation is the ID of the installation of the app
+	// authenticating the client, for the repository, the
+	// organization and the user.
+	Installation int64
+	// Mutations allows the checks creating resources, such
+	// as comments and statuses, to run.
+	Mutations bool
+}
+
+// Target is the client of a driver under test.
+type Target struct {
+	// Name identifies the target in the report, usually
+	// the name of the driver.
+	Name string
+	// NewClient returns the client under test. It is called
+	// once per check, so checks creating resources need a
+	// client sharing the state of the server.
+	NewClient func() (*scm.Client, error)
+	// Fixture describes the resources of the server.
+	Fixture Fixture
+	// Skip lists the names of the checks to skip, such as
+	// those needing endpoints a stand-in does not serve.
+	Skip []string
+}
+
+// Result is the outcome of a check of a target.
+type Result struct {
+	// Name is the name of the check, which starts with the
+	// operation it checks.
+	Name string
+	// Op is the operation checked.
+	Op scm.Capability
+	// Status is the outcome of the check.
+	Status Status
+	// Message explains the failure or the skip.
+	Message string
+}
+
+// Report holds the results of the checks of a target.
+type Report struct {
+	Target  string
+	Results []Result
+}
+
+// Count returns the number of results with the status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Result returns the result of the named check, if any.
+func (r *Report) Result(name string) (Result, bool) {
+	for _, result := range r.Results {
+		if result.Name == name {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
+
+// String returns the report as a table.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d passed, %d failed, %d not supported, %d skipped\n",
+		r.Target, r.Count(Pass), r.Count(Fail), r.Count(NotSupported), r.Count(Skipped))
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, result := range r.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Name, result.Status, result.Message)
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+// Check runs the checks against the target and returns the
+// report.
+func Check(ctx context.Context, target Target) *Report {
+	report := &Report{Target: target.Name}
+	for _, c := range checks {
+		report.Results = append(report.Results, c.check(ctx, &target))
+	}
+	return report
+}
+
+// Run runs the checks against the target as subtests of the
+// test, failing the subtests of the failed checks and
+// skipping the others not passing. It returns the report.
+func Run(t *testing.T, target Target) *Report {
+	report := Check(context.Background(), target)
+	for _, result := range report.Results {
+		t.Run(result.Name, func(t *testing.T) {
+			switch result.Status {
+			case Fail:
+				t.Error(result.Message)
+			case NotSupported, Skipped:
+				t.Skip(result.Message)
+			}
+		})
+	}
+	t.Log(report)
+	return report
+}
+
+// check is a behavioral check of an operation.
+type check struct {
+	// name defaults to the operation.
+	name string
+	op   scm.Capability
+	// needs reports whether the fixture holds the
+	// resources needed by the check.
+	needs func(f *Fixture) bool
+	// mutates means the check creates resources.
+	mutates bool
+	run     func(ctx context.Context, client *scm.Client, f *Fixture) error
+}
+
+// checkName returns the name of the check.
+func (c *check) checkName() string {
+	if c.name == "" {
+		return string(c.op)
+	}
+	return c.name
+}
+
+// check runs the check against the target.
+func (c *check) check(ctx context.Context, target *Target) Result {
+	result := Result{Name: c.checkName(), Op: c.op}
+	skip := func(msg string) Result {
+		result.Status = Skipped
+		result.Message = msg
+		return result
+	}
+	switch {
+	case slices.Contains(target.Skip, result.Name):
+		return skip("skipped by the target")
+	case c.mutates && !target.Fixture.Mutations:
+		return skip("the fixture does not allow mutations")
+	case c.needs != nil && !c.needs(&target.Fixture):
+		return skip("the fixture lacks the resources of the check")
+	}
+
+	client, err := target.NewClient()
+	if err != nil {
+		result.Status = Fail
+		result.Message = fmt.Sprintf("failed to create the client: %v", err)
+		return result
+	}
+	if !scm.Supports(client, c.op) {
+		result.Status = NotSupported
+		if client.Capabilities().Supports(c.op) {
+			result.Message = fmt.Sprintf("the driver lacks the %s service", c.op.Service())
+			return result
+		}
+		// the driver must not pretend to support the
+		// operation.
+		if err := c.safeRun(ctx, client, &target.Fixture); !errors.Is(err, scm.ErrNotSupported) {
+			result.Status = Fail
+			result.Message = fmt.Sprintf("the capabilities report %s as not supported, but it returned %v instead of scm.ErrNotSupported", c.op, err)
+		}
+		return result
+	}
+
+	err = c.safeRun(ctx, client, &target.Fixture)
+	switch {
+	case err == nil:
+		result.Status = Pass
+	case errors.Is(err, scm.ErrNotSupported):
+		result.Status = Fail
+		result.Message = fmt.Sprintf("the capabilities report %s as supported, but it returned scm.ErrNotSupported", c.op)
+	default:
+		result.Status = Fail
+		result.Message = err.Error()
+	}
+	return result
+}
+
+// safeRun runs the check, turning a panic of the driver
+// into an error.
+func (c *check) safeRun(ctx context.Context, client *scm.Client, f *Fixture) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return c.run(ctx, client, f)
+}
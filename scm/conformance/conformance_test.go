@@ -0,0 +1,1118 @@
+package conformance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/azure"
+	"github.com/jenkins-x/go-scm/scm/driver/bitbucket"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/driver/gitea"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/jenkins-x/go-scm/scm/driver/gitlab"
+	"github.com/jenkins-x/go-scm/scm/driver/gogs"
+	"github.com/jenkins-x/go-scm/scm/driver/stash"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// driverAliases maps the drivers registered under another
+// name to the driver checked by their target.
+var driverAliases = map[string]string{
+	"bitbucketcloud":  "bitbucket",
+	"bitbucketserver": "stash",
+	"fakegit":         "fake",
+}
+
+// targets returns the targets run by TestConformance, keyed
+// by the name of their driver.
+func targets(t *testing.T) map[string]Target {
+	return map[string]Target{
+		"fake":        fakeTarget(t),
+		"github":      githubTarget(t),
+		"github/fake": githubFakeTarget(t),
+		"gitea":       giteaTarget(t),
+		"gitlab":      gitlabTarget(t),
+		"gogs":        gogsTarget(t),
+		"stash":       stashTarget(t),
+		"bitbucket":   bitbucketTarget(t),
+		"azure":       azureTarget(t),
+	}
+}
+
+// expected lists, by target, the checks expected not to pass.
+// The other checks are expected to pass.
+var expected = map[string]map[Status][]string{
+	"fake": {
+		NotSupported: {
+			"PullRequests.ListCommits",
+			"PullRequests.ListEvents",
+			"Organizations.ListOrgMembers",
+			"GraphQL.Query",
+			"Milestones.List",
+			"Reviews.ListComments",
+		},
+		Skipped: {
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"Milestones.Find",
+		},
+	},
+	"github": {
+		NotSupported: {
+			"PullRequests.ListCommits",
+			"Git.FindTag",
+		},
+		Skipped: {
+			"Repositories.ListLabels",
+			"Repositories.ListCollaborators",
+			"Repositories.CreateStatus",
+			"PullRequests.ListComments",
+			"PullRequests.ListEvents",
+			"PullRequests.ListLabels",
+			"PullRequests.CreateComment",
+			"Issues.ListLabels",
+			"Issues.ListEvents",
+			"Issues.CreateComment",
+			"GraphQL.Query",
+			"Contents.List",
+		},
+	},
+	"github/fake": {
+		NotSupported: {
+			"PullRequests.ListCommits",
+			"Git.FindTag",
+		},
+		Skipped: {
+			"Repositories.FindHook",
+			"Repositories.FindUserPermission",
+			"Repositories.ListCollaborators",
+			"PullRequests.FindComment",
+			"PullRequests.ListEvents",
+			"Issues.FindComment",
+			"Issues.ListEvents",
+			"Issues.Search",
+			"Users.ListInvitations",
+			"Organizations.Find",
+			"Organizations.List",
+			"Organizations.IsAdmin",
+			"Organizations.IsMember",
+			"Organizations.ListMemberships",
+			"Organizations.ListOrgMembers",
+			"Organizations.ListPendingInvitations",
+			"Organizations.ListTeams",
+			"Organizations.ListTeamMembers",
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"GraphQL.Query",
+			"Releases.FindByTag",
+			"Releases.Find",
+			"Releases.List",
+			"Milestones.Find",
+			"Milestones.List",
+			"Reviews.Find",
+			"Reviews.List",
+			"Reviews.ListComments",
+			"Deployments.Find",
+			"Deployments.List",
+			"Deployments.FindStatus",
+			"Deployments.ListStatus",
+		},
+	},
+	"gitea": {
+		NotSupported: {
+			"PullRequests.ListCommits",
+			"PullRequests.ListEvents",
+			"Issues.ListEvents",
+			"Issues.Search",
+			"Users.ListInvitations",
+			"Organizations.ListMemberships",
+			"Organizations.ListPendingInvitations",
+			"GraphQL.Query",
+			"Git.FindTag",
+			"Git.ListChanges",
+			"Git.CompareCommits",
+			"Deployments.List",
+		},
+		Skipped: {
+			"Repositories.FindUserPermission",
+			"Repositories.ListCollaborators",
+			"Repositories.CreateStatus",
+			"PullRequests.CreateComment",
+			"Issues.CreateComment",
+			"Organizations.IsAdmin",
+			"Organizations.ListOrgMembers",
+			"Organizations.ListTeams",
+			"Organizations.ListTeamMembers",
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"Git.FindRef",
+			"Reviews.ListComments",
+			"Deployments.Find",
+			"Deployments.FindStatus",
+			"Deployments.ListStatus",
+		},
+	},
+	"gitlab": {
+		NotSupported: {
+			"Repositories.ListOrganisation",
+			"Repositories.ListUser",
+			"PullRequests.ListCommits",
+			"Users.ListInvitations",
+			"Organizations.ListMemberships",
+			"Organizations.ListPendingInvitations",
+			"Reviews.List",
+			"Deployments.List",
+		},
+		Skipped: {
+			"Repositories.ListLabels",
+			"Repositories.CreateStatus",
+			"PullRequests.List",
+			"PullRequests.CreateComment",
+			"Issues.CreateComment",
+			"Organizations.ListTeamMembers",
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"GraphQL.Query",
+			"Contents.List",
+			"Releases.Find",
+			"Reviews.Find",
+			"Reviews.ListComments",
+			"Deployments.Find",
+			"Deployments.FindStatus",
+			"Deployments.ListStatus",
+		},
+	},
+	"gogs": {
+		NotSupported: {
+			"Repositories.ListLabels",
+			"Repositories.ListStatus",
+			"Repositories.FindCombinedStatus",
+			"Repositories.FindUserPermission",
+			"Repositories.IsCollaborator",
+			"Repositories.ListCollaborators",
+			"PullRequests.Find",
+			"PullRequests.List",
+			"PullRequests.ListChanges",
+			"PullRequests.ListComments",
+			"PullRequests.FindComment",
+			"PullRequests.ListCommits",
+			"PullRequests.ListEvents",
+			"PullRequests.ListLabels",
+			"Issues.FindComment",
+			"Issues.ListLabels",
+			"Issues.ListEvents",
+			"Issues.Search",
+			"Users.ListInvitations",
+			"Organizations.IsAdmin",
+			"Organizations.IsMember",
+			"Organizations.ListMemberships",
+			"Organizations.ListOrgMembers",
+			"Organizations.ListPendingInvitations",
+			"Organizations.ListTeams",
+			"GraphQL.Query",
+			"Git.FindRef",
+			"Git.ListTags",
+			"Git.ListCommits",
+			"Git.ListChanges",
+			"Git.CompareCommits",
+			"Releases.List",
+			"Milestones.List",
+			"Reviews.List",
+			"Deployments.List",
+		},
+		Skipped: {
+			"Repositories.CreateStatus",
+			"PullRequests.CreateComment",
+			"Issues.CreateComment",
+			"Organizations.ListTeamMembers",
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"Contents.Find",
+			"Contents.List",
+			"Git.FindTag",
+			"Releases.FindByTag",
+			"Releases.Find",
+			"Milestones.Find",
+			"Reviews.Find",
+			"Reviews.ListComments",
+			"Deployments.Find",
+			"Deployments.FindStatus",
+			"Deployments.ListStatus",
+		},
+	},
+	"stash": {
+		NotSupported: {
+			"Repositories.ListUser",
+			"PullRequests.ListCommits",
+			"PullRequests.ListEvents",
+			"Issues.Find",
+			"Issues.ListComments",
+			"Issues.List",
+			"Issues.ListLabels",
+			"Issues.ListEvents",
+			"Issues.Search",
+			"Organizations.Find",
+			"Organizations.ListMemberships",
+			"Organizations.ListPendingInvitations",
+			"Organizations.ListTeams",
+			"GraphQL.Query",
+			"Git.ListCommits",
+			"Releases.FindByTag",
+			"Releases.List",
+			"Milestones.List",
+			"Reviews.List",
+			"Deployments.List",
+		},
+		Skipped: {
+			"Repositories.CreateStatus",
+			"PullRequests.CreateComment",
+			"Issues.FindComment",
+			"Issues.CreateComment",
+			"Users.Find",
+			"Users.FindEmail",
+			"Organizations.ListTeamMembers",
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"Contents.List",
+			"Releases.Find",
+			"Milestones.Find",
+			"Reviews.Find",
+			"Reviews.ListComments",
+			"Deployments.Find",
+			"Deployments.FindStatus",
+			"Deployments.ListStatus",
+		},
+	},
+	"bitbucket": {
+		NotSupported: {
+			"Repositories.FindUserPermission",
+			"Repositories.ListUser",
+			"PullRequests.ListCommits",
+			"PullRequests.ListEvents",
+			"Issues.Find",
+			"Issues.List",
+			"Issues.ListEvents",
+			"Issues.Search",
+			"Users.FindEmail",
+			"Users.ListInvitations",
+			"Organizations.IsAdmin",
+			"Organizations.ListMemberships",
+			"Organizations.ListOrgMembers",
+			"Organizations.ListPendingInvitations",
+			"Organizations.ListTeams",
+			"GraphQL.Query",
+			"Contents.List",
+			"Releases.FindByTag",
+			"Releases.List",
+			"Milestones.List",
+			"Reviews.List",
+			"Deployments.List",
+		},
+		Skipped: {
+			"Repositories.CreateStatus",
+			"PullRequests.ListComments",
+			"PullRequests.FindComment",
+			"PullRequests.ListLabels",
+			"PullRequests.CreateComment",
+			"Issues.ListComments",
+			"Issues.FindComment",
+			"Issues.ListLabels",
+			"Issues.CreateComment",
+			"Organizations.IsMember",
+			"Organizations.ListTeamMembers",
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"Releases.Find",
+			"Milestones.Find",
+			"Reviews.Find",
+			"Reviews.ListComments",
+			"Deployments.Find",
+			"Deployments.FindStatus",
+			"Deployments.ListStatus",
+		},
+	},
+	"azure": {
+		NotSupported: {
+			"Repositories.List",
+			"Repositories.ListLabels",
+			"Repositories.ListHooks",
+			"Repositories.ListStatus",
+			"Repositories.FindCombinedStatus",
+			"Repositories.FindPerms",
+			"Repositories.FindUserPermission",
+			"Repositories.IsCollaborator",
+			"Repositories.ListCollaborators",
+			"Repositories.ListUser",
+			"PullRequests.ListChanges",
+			"PullRequests.ListComments",
+			"PullRequests.ListEvents",
+			"PullRequests.ListLabels",
+			"Issues.Find",
+			"Issues.ListComments",
+			"Issues.List",
+			"Issues.ListLabels",
+			"Issues.ListEvents",
+			"Issues.Search",
+			"Users.Find",
+			"Users.FindEmail",
+			"Users.FindLogin",
+			"Users.ListInvitations",
+			"Organizations.Find",
+			"Organizations.List",
+			"Organizations.IsAdmin",
+			"Organizations.IsMember",
+			"Organizations.ListMemberships",
+			"Organizations.ListOrgMembers",
+			"Organizations.ListPendingInvitations",
+			"Organizations.ListTeams",
+			"GraphQL.Query",
+			"Git.FindBranch",
+			"Git.FindRef",
+			"Git.FindTag",
+			"Git.GetDefaultBranch",
+			"Git.ListTags",
+			"Git.ListChanges",
+			"Releases.FindByTag",
+			"Releases.List",
+			"Milestones.List",
+			"Reviews.List",
+			"Deployments.List",
+		},
+		Skipped: {
+			"Repositories.FindHook",
+			"Repositories.CreateStatus",
+			"PullRequests.List",
+			"PullRequests.FindComment",
+			"PullRequests.CreateComment",
+			"Issues.FindComment",
+			"Issues.CreateComment",
+			"Organizations.ListTeamMembers",
+			"Apps.GetRepositoryInstallation",
+			"Apps.GetOrganisationInstallation",
+			"Apps.GetUserInstallation",
+			"Contents.List",
+			"Releases.Find",
+			"Milestones.Find",
+			"Reviews.Find",
+			"Reviews.ListComments",
+			"Deployments.Find",
+			"Deployments.FindStatus",
+			"Deployments.ListStatus",
+		},
+	},
+}
+
+func TestConformance(t *testing.T) {
+	for name, target := range targets(t) {
+		t.Run(name, func(t *testing.T) {
+			report := Run(t, target)
+			want := map[string]Status{}
+			for status, names := range expected[name] {
+				for _, check := range names {
+					want[check] = status
+				}
+			}
+			for _, result := range report.Results {
+				status, ok := want[result.Name]
+				if !ok {
+					status = Pass
+				}
+				delete(want, result.Name)
+				assert.Equal(t, status, result.Status, "Want status of %s %v, got %v: %s", result.Name, status, result.Status, result.Message)
+			}
+			assert.Empty(t, want, "Want the results of the expected checks")
+		})
+	}
+}
+
+func TestDrivers(t *testing.T) {
+	tested := targets(t)
+	for _, driver := range factory.Drivers() {
+		if alias, ok := driverAliases[driver]; ok {
+			driver = alias
+		}
+		if _, ok := tested[driver]; !ok {
+			t.Errorf("Want a conformance target for driver %s, got none", driver)
+		}
+	}
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	client, err := gitlab.New("https://gitlab.example.com")
+	require.NoError(t, err)
+	target := Target{
+		Name:      "gitlab",
+		NewClient: func() (*scm.Client, error) { return client, nil },
+		Fixture:   Fixture{Org: "jenkins-x"},
+	}
+	name := string(scm.CapRepositoryListOrganisation)
+
+	// GitLab does not list the repositories of an
+	// organization, as its capabilities report.
+	report := Check(context.Background(), target)
+	result, ok := report.Result(name)
+	require.True(t, ok)
+	assert.Equal(t, NotSupported, result.Status, result.Message)
+
+	// capabilities claiming support are a drift.
+	client.SetCapabilities(&scm.Capabilities{})
+	report = Check(context.Background(), target)
+	result, _ = report.Result(name)
+	assert.Equal(t, Fail, result.Status)
+	assert.Contains(t, result.Message, "returned scm.ErrNotSupported")
+
+	// as are capabilities denying the support of an
+	// implemented operation.
+	fakeClient, data := fake.NewDefault()
+	data.Repositories = []*scm.Repository{{Name: "go-scm", FullName: "jenkins-x/go-scm"}}
+	fakeClient.SetCapabilities(&scm.Capabilities{Unsupported: []scm.Capability{scm.CapRepositoryFind}})
+	target = Target{
+		Name:      "fake",
+		NewClient: func() (*scm.Client, error) { return fakeClient, nil },
+		Fixture:   Fixture{Repo: "jenkins-x/go-scm"},
+	}
+	report = Check(context.Background(), target)
+	result, _ = report.Result(string(scm.CapRepositoryFind))
+	assert.Equal(t, Fail, result.Status)
+	assert.Contains(t, result.Message, "instead of scm.ErrNotSupported")
+}
+
+func TestCheckPanic(t *testing.T) {
+	client, _ := fake.NewDefault()
+	// a service missing its implementation panics when called.
+	client.Repositories = struct{ scm.RepositoryService }{}
+	target := Target{
+		Name:      "fake",
+		NewClient: func() (*scm.Client, error) { return client, nil },
+		Fixture:   Fixture{Org: "jenkins-x"},
+	}
+	report := Check(context.Background(), target)
+	result, ok := report.Result(string(scm.CapRepositoryListOrganisation))
+	require.True(t, ok)
+	assert.Equal(t, Fail, result.Status)
+	assert.Contains(t, result.Message, "panic: ")
+}
+
+func TestCheckSkip(t *testing.T) {
+	client, _ := fake.NewDefault()
+	target := Target{
+		Name:      "skip",
+		NewClient: func() (*scm.Client, error) { return client, nil },
+		Fixture:   Fixture{Repo: "jenkins-x/go-scm", Commit: "abc"},
+		Skip:      []string{"Repositories.Find"},
+	}
+	report := Check(context.Background(), target)
+	for name, want := range map[string]Status{
+		"Repositories.Find":         Skipped,
+		"Repositories.CreateStatus": Skipped,
+		"PullRequests.Find":         Skipped,
+		"Repositories.ListStatus":   Pass,
+	} {
+		result, ok := report.Result(name)
+		require.True(t, ok, name)
+		assert.Equal(t, want, result.Status, "Want status of %s %v, got %v: %s", name, want, result.Status, result.Message)
+	}
+}
+
+// fakeTarget returns a target of the fake driver, seeded with
+// the resources of the fixture.
+func fakeTarget(t *testing.T) Target {
+	client, _, fixture := fakeData(t)
+	return Target{
+		Name:      "fake",
+		NewClient: func() (*scm.Client, error) { return client, nil },
+		Fixture:   fixture,
+	}
+}
+
+// githubFakeTarget returns a target of the GitHub driver,
+// backed by the server of the fake driver.
+func githubFakeTarget(t *testing.T) Target {
+	_, data, fixture := fakeData(t)
+	server := httptest.NewServer(fake.NewServer(data))
+	t.Cleanup(server.Close)
+	return Target{
+		Name:      "github/fake",
+		NewClient: func() (*scm.Client, error) { return github.New(server.URL) },
+		Fixture:   fixture,
+		// the server serves a subset of the API, without the
+		// organizations, releases, reviews, deployments,
+		// searches, events and single hooks or comments.
+		Skip: append(checksOf("Organizations", "Releases", "Reviews", "Deployments", "GraphQL"),
+			"Repositories.FindHook",
+			"Repositories.FindUserPermission",
+			"Repositories.ListCollaborators",
+			"PullRequests.FindComment",
+			"PullRequests.ListEvents",
+			"Issues.FindComment",
+			"Issues.ListEvents",
+			"Issues.Search",
+			"Users.ListInvitations",
+			"Milestones.List",
+		),
+	}
+}
+
+// checksOf returns the names of the checks of the services.
+func checksOf(services ...string) []string {
+	var names []string
+	for _, c := range checks {
+		if slices.Contains(services, c.op.Service()) {
+			names = append(names, c.checkName())
+		}
+	}
+	return names
+}
+
+// fakeData returns a fake client and its data, seeded with the
+// resources of the returned fixture.
+func fakeData(t *testing.T) (*scm.Client, *fake.Data, Fixture) {
+	client, data := fake.NewDefault()
+	repo := &scm.Repository{Namespace: "jenkins-x", Name: "go-scm", FullName: "jenkins-x/go-scm", Branch: "main"}
+	data.Repositories = []*scm.Repository{repo}
+	data.PullRequests[1] = &scm.PullRequest{
+		Number: 1,
+		Title:  "Add conformance checks",
+		Base:   scm.PullRequestBranch{Ref: "main", Repo: *repo},
+	}
+	data.PullRequestComments[1] = []*scm.Comment{{ID: 3, Body: "LGTM"}}
+	data.Reviews[1] = []*scm.Review{{ID: 4, Body: "Approved"}}
+	data.Issues[2] = []*scm.Issue{{Number: 2, Title: "Check the drivers"}}
+	data.IssueComments[2] = []*scm.Comment{{ID: 5, Body: "Thanks"}}
+	data.Releases = map[string]map[int]*scm.Release{
+		repo.FullName: {1: {ID: 1, Tag: "v1.0.0"}},
+	}
+	data.Organizations = []*scm.Organization{{ID: 6, Name: "jenkins-x"}}
+	data.OrgMembers["jenkins-x"] = []string{data.CurrentUser.Login}
+	data.Users = []*scm.User{&data.CurrentUser}
+	data.Hooks[repo.FullName] = []*scm.Hook{{ID: "7", Name: "ci", Target: "https://ci.example.com/hook"}}
+	data.Deployments[repo.FullName] = []*scm.Deployment{{ID: "8", Name: "production"}}
+	data.DeploymentStatus[scm.Join(repo.FullName, "8")] = []*scm.DeploymentStatus{{ID: "9", State: "success"}}
+
+	ctx := context.Background()
+	_, err := data.InitRepository(repo.FullName, repo.Branch)
+	require.NoError(t, err)
+	_, err = client.Contents.Create(ctx, repo.FullName, "README.md", &scm.ContentParams{Data: []byte("# go-scm\n")})
+	require.NoError(t, err)
+	branch, _, err := client.Git.FindBranch(ctx, repo.FullName, repo.Branch)
+	require.NoError(t, err)
+	_, _, err = client.Git.CreateRef(ctx, repo.FullName, "refs/tags/v1.0.0", branch.Sha)
+	require.NoError(t, err)
+
+	return client, data, Fixture{
+		Repo:               repo.FullName,
+		Org:                "jenkins-x",
+		User:               data.CurrentUser.Login,
+		PullRequest:        1,
+		PullRequestComment: 3,
+		Review:             4,
+		Issue:              2,
+		IssueComment:       5,
+		Branch:             repo.Branch,
+		Commit:             branch.Sha,
+		Path:               "README.md",
+		Tag:                "v1.0.0",
+		Release:            1,
+		Hook:               "7",
+		Deployment:         "8",
+		DeploymentStatus:   "9",
+		Team:               42,
+		Mutations:          true,
+	}
+}
+
+// githubTarget returns a target of the GitHub driver, backed
+// by a stand-in server replying with the testdata of the
+// driver.
+func githubTarget(t *testing.T) Target {
+	const repo = "/repos/octocat/Hello-World"
+	const sha = "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"
+	routes := map[string]string{
+		"/user":                                    "user.json",
+		"/user/repos":                              "repos.json",
+		"/user/orgs":                               "orgs.json",
+		"/user/memberships/orgs":                   "list_memberships.json",
+		"/user/repository_invitations":             "list_invitations.json",
+		"/users/octocat":                           "user.json",
+		"/users/octocat/repos":                     "repos.json",
+		"/users/octocat/installation":              "app_repo_install.json",
+		"/orgs/github":                             "org.json",
+		"/orgs/github/repos":                       "repos.json",
+		"/orgs/github/members":                     "org_members.json",
+		"/orgs/github/memberships/octocat":         "membership_admin.json",
+		"/orgs/github/invitations":                 "list_pending_invitations.json",
+		"/orgs/github/teams":                       "teams.json",
+		"/orgs/github/installation":                "app_repo_install.json",
+		"/teams/1/members":                         "team_members.json",
+		"/search/issues":                           "issue_search.json",
+		repo:                                       "repo.json",
+		repo + "/installation":                     "app_repo_install.json",
+		repo + "/hooks":                            "hooks.json",
+		repo + "/hooks/1":                          "hook.json",
+		repo + "/collaborators/octocat/permission": "user_perm.json",
+		repo + "/pulls":                            "pulls.json",
+		repo + "/pulls/1347":                       "pr.json",
+		repo + "/pulls/1347/files":                 "pr_files.json",
+		repo + "/pulls/1347/reviews":               "reviews_list.json",
+		repo + "/pulls/1347/reviews/80":            "reviews_find.json",
+		repo + "/pulls/1347/reviews/80/comments":   "reviews_list_comments.json",
+		repo + "/issues":                           "issues.json",
+		repo + "/issues/1347":                      "issue.json",
+		repo + "/issues/1347/comments":             "issue_comments.json",
+		repo + "/issues/comments/1":                "issue_comment.json",
+		repo + "/contents/README":                  "content.json",
+		repo + "/branches":                         "branches.json",
+		repo + "/branches/master":                  "branch.json",
+		repo + "/git/refs/heads/master":            "ref.json",
+		repo + "/tags":                             "tags.json",
+		repo + "/commits":                          "commits.json",
+		repo + "/commits/" + sha:                   "commit.json",
+		repo + "/commits/" + sha + "/status":       "combined_status.json",
+		repo + "/compare/" + sha + "..." + sha:     "changes.json",
+		repo + "/statuses/" + sha:                  "statuses.json",
+		repo + "/releases":                         "releases.json",
+		repo + "/releases/1":                       "release.json",
+		repo + "/releases/tags/v1.0.0":             "release.json",
+		repo + "/milestones":                       "milestones.json",
+		repo + "/milestones/1":                     "milestone.json",
+		repo + "/deployments":                      "deploys.json",
+		repo + "/deployments/1":                    "deploy.json",
+		repo + "/deployments/1/statuses":           "deploy_statuses.json",
+		repo + "/deployments/1/statuses/1":         "deploy_status.json",
+	}
+	server := standIn(t, "../driver/github/testdata", routes)
+
+	return Target{
+		Name:      "github",
+		NewClient: func() (*scm.Client, error) { return github.New(server.URL) },
+		Fixture: Fixture{
+			Repo:               "octocat/Hello-World",
+			Org:                "github",
+			User:               "octocat",
+			PullRequest:        1347,
+			PullRequestComment: 1,
+			Review:             80,
+			Issue:              1347,
+			IssueComment:       1,
+			Branch:             "master",
+			Commit:             sha,
+			Path:               "README",
+			Tag:                "v1.0.0",
+			Release:            1,
+			Milestone:          1,
+			Hook:               "1",
+			Deployment:         "1",
+			DeploymentStatus:   "1",
+			Team:               1,
+			Installation:       1,
+		},
+		// the testdata holds no labels, events, collaborators
+		// nor comments of the pull requests, lists another
+		// directory than the one of the file, and the stand-in
+		// does not serve GraphQL.
+		Skip: []string{
+			"Repositories.ListLabels",
+			"Repositories.ListCollaborators",
+			"PullRequests.ListComments",
+			"PullRequests.ListEvents",
+			"PullRequests.ListLabels",
+			"Issues.ListLabels",
+			"Issues.ListEvents",
+			"Contents.List",
+			"GraphQL.Query",
+		},
+	}
+}
+
+// gitlabTarget returns a target of the GitLab driver, backed
+// by a stand-in server replying with the testdata of the
+// driver.
+func gitlabTarget(t *testing.T) Target {
+	const repo = "/api/v4/projects/diaspora/diaspora"
+	const sha = "6104942438c14ec7bd21c6cd5bd995272b3faff6"
+	routes := map[string]string{
+		"/api/v4/user":                                    "user.json",
+		"/api/v4/users":                                   "user_search.json",
+		"/api/v4/groups":                                  "groups.json",
+		"/api/v4/groups/Twitter":                          "group.json",
+		"/api/v4/groups/Twitter/members/all":              "contributors.json",
+		"/api/v4/projects":                                "repos.json",
+		"/api/v4/projects/32732":                          "repo.json",
+		repo:                                              "repo.json",
+		repo + "/members/all":                             "contributors.json",
+		repo + "/hooks":                                   "hooks.json",
+		repo + "/hooks/1":                                 "hook.json",
+		repo + "/merge_requests":                          "merges.json",
+		repo + "/merge_requests/1":                        "merge.json",
+		repo + "/merge_requests/1/changes":                "merge_diff.json",
+		repo + "/merge_requests/1/notes":                  "merge_notes.json",
+		repo + "/merge_requests/1/notes/301":              "merge_note.json",
+		repo + "/merge_requests/1/resource_label_events":  "pr_events.json",
+		repo + "/issues":                                  "issues.json",
+		repo + "/issues/1":                                "issue.json",
+		repo + "/issues/1/notes":                          "issue_notes.json",
+		repo + "/issues/1/notes/302":                      "issue_note.json",
+		repo + "/issues/1/resource_label_events":          "issue_events.json",
+		repo + "/repository/files/README":                 "content.json",
+		repo + "/repository/branches":                     "branches.json",
+		repo + "/repository/branches/master":              "branch.json",
+		repo + "/repository/tags":                         "tags.json",
+		repo + "/repository/tags/v0.1":                    "tag.json",
+		repo + "/repository/commits":                      "commits.json",
+		repo + "/repository/commits/" + sha:               "commit.json",
+		repo + "/repository/commits/" + sha + "/diff":     "commit_diff.json",
+		repo + "/repository/commits/" + sha + "/statuses": "statuses.json",
+		repo + "/repository/compare":                      "compare.json",
+		repo + "/releases":                                "releases.json",
+		repo + "/releases/v0.1":                           "release.json",
+		repo + "/milestones":                              "milestones.json",
+		repo + "/milestones/12":                           "milestone.json",
+	}
+	server := standIn(t, "../driver/gitlab/testdata", routes)
+
+	return Target{
+		Name:      "gitlab",
+		NewClient: func() (*scm.Client, error) { return gitlab.New(server.URL) },
+		Fixture: Fixture{
+			Repo:               "diaspora/diaspora",
+			Org:                "Twitter",
+			User:               "john_smith",
+			PullRequest:        1,
+			PullRequestComment: 301,
+			Issue:              1,
+			IssueComment:       302,
+			Branch:             "master",
+			Commit:             sha,
+			Path:               "README",
+			Tag:                "v0.1",
+			Milestone:          12,
+			Hook:               "1",
+		},
+		// the stand-in does not serve the labels nor GraphQL,
+		// the testdata holds no open merge request to list,
+		// and lists another directory than the one of the
+		// file.
+		Skip: []string{
+			"Repositories.ListLabels",
+			"PullRequests.List",
+			"Contents.List",
+			"GraphQL.Query",
+		},
+	}
+}
+
+// giteaTarget returns a target of the Gitea driver, backed
+// by a stand-in server replying with the testdata of the
+// driver.
+func giteaTarget(t *testing.T) Target {
+	const repo = "/api/v1/repos/go-gitea/gitea"
+	const sha = "c43399cad8766ee521b873a32c1652407c5a4630"
+	routes := map[string]string{
+		"/api/v1/version":                      "version.json",
+		"/api/v1/user":                         "user.json",
+		"/api/v1/user/repos":                   "repos.json",
+		"/api/v1/user/orgs":                    "organizations.json",
+		"/api/v1/users/jcitizen":               "user.json",
+		"/api/v1/users/jcitizen/repos":         "repos.json",
+		"/api/v1/orgs/gogits":                  "organization.json",
+		"/api/v1/orgs/gogits/repos":            "repos.json",
+		repo:                                   "repo.json",
+		repo + "/labels":                       "issue_labels.json",
+		repo + "/hooks":                        "hooks.json",
+		repo + "/hooks/20":                     "hook.json",
+		repo + "/pulls":                        "prs.json",
+		repo + "/pulls/1":                      "pr.json",
+		repo + "/pulls/1.patch":                "pr_changes.patch",
+		repo + "/pulls/1/reviews":              "reviews.json",
+		repo + "/pulls/1/reviews/1":            "review.json",
+		repo + "/issues":                       "issues.json",
+		repo + "/issues/1":                     "issue.json",
+		repo + "/issues/1/comments":            "comments.json",
+		repo + "/issues/1/labels":              "issue_labels.json",
+		repo + "/contents/":                    "content_list.json",
+		repo + "/contents/.gitignore":          "content_find.json",
+		repo + "/branches":                     "branches.json",
+		repo + "/branches/master":              "branch.json",
+		repo + "/tags":                         "tags.json",
+		repo + "/commits":                      "commits.json",
+		repo + "/releases":                     "releases.json",
+		repo + "/releases/1":                   "release.json",
+		repo + "/milestones":                   "milestones.json",
+		repo + "/milestones/1":                 "milestone.json",
+		repo + "/git/commits/" + sha:           "commit.json",
+		repo + "/commits/" + sha + "/statuses": "statuses.json",
+		repo + "/commits/" + sha + "/status":   "statuses.json",
+	}
+	server := standIn(t, "../driver/gitea/testdata", routes)
+
+	return Target{
+		Name:      "gitea",
+		NewClient: func() (*scm.Client, error) { return gitea.New(server.URL) },
+		Fixture: Fixture{
+			Repo:               "go-gitea/gitea",
+			Org:                "gogits",
+			User:               "jcitizen",
+			PullRequest:        1,
+			PullRequestComment: 74,
+			Review:             1,
+			Issue:              1,
+			IssueComment:       74,
+			Branch:             "master",
+			Commit:             sha,
+			Path:               ".gitignore",
+			Tag:                "v1.0.0",
+			Release:            1,
+			Milestone:          1,
+			Hook:               "20",
+		},
+		// the testdata holds no collaborators, members, teams,
+		// refs nor comments of the reviews.
+		Skip: []string{
+			"Repositories.FindUserPermission",
+			"Repositories.ListCollaborators",
+			"Organizations.IsAdmin",
+			"Organizations.ListOrgMembers",
+			"Organizations.ListTeams",
+			"Git.FindRef",
+			"Reviews.ListComments",
+		},
+	}
+}
+
+// gogsTarget returns a target of the Gogs driver, backed by a
+// stand-in server replying with the testdata of the driver.
+func gogsTarget(t *testing.T) Target {
+	const repo = "/api/v1/repos/gogits/gogs"
+	routes := map[string]string{
+		"/api/v1/user":                 "user.json",
+		"/api/v1/user/repos":           "repos.json",
+		"/api/v1/user/orgs":            "organizations.json",
+		"/api/v1/users/jcitizen":       "user.json",
+		"/api/v1/users/jcitizen/repos": "repos.json",
+		"/api/v1/orgs/gogits":          "organization.json",
+		"/api/v1/orgs/gogits/repos":    "repos.json",
+		repo:                           "repo.json",
+		repo + "/hooks":                "hooks.json",
+		repo + "/hooks/20":             "hook.json",
+		repo + "/issues":               "issues.json",
+		repo + "/issues/1":             "issue.json",
+		repo + "/issues/1/comments":    "comments.json",
+		repo + "/branches":             "branches.json",
+		repo + "/branches/master":      "branch.json",
+		repo + "/commits/2c3e2b701e012294d457937e6bfbffd63dd8ae4f": "commits.json",
+	}
+	server := standIn(t, "../driver/gogs/testdata", routes)
+
+	return Target{
+		Name:      "gogs",
+		NewClient: func() (*scm.Client, error) { return gogs.New(server.URL) },
+		Fixture: Fixture{
+			Repo:               "gogits/gogs",
+			Org:                "gogits",
+			User:               "jcitizen",
+			PullRequest:        1,
+			PullRequestComment: 74,
+			Issue:              1,
+			IssueComment:       74,
+			Branch:             "master",
+			Commit:             "2c3e2b701e012294d457937e6bfbffd63dd8ae4f",
+			Hook:               "20",
+		},
+	}
+}
+
+// stashTarget returns a target of the Bitbucket Server
+// driver, backed by a stand-in server replying with the
+// testdata of the driver.
+func stashTarget(t *testing.T) Target {
+	const repo = "/rest/api/1.0/projects/PRJ/repos/my-repo"
+	const sha = "131cb13f4aed12e725177bc4b7c28db67839bf9f"
+	routes := map[string]string{
+		"/rest/api/1.0/repos":                           "repos.json",
+		"/rest/api/1.0/users/jcitizen":                  "user.json",
+		"/rest/api/1.0/admin/groups/more-members":       "group_members.json",
+		"/rest/api/1.0/projects":                        "orgs.json",
+		"/rest/api/1.0/projects/PRJ/repos":              "repos.json",
+		"/rest/api/1.0/projects/PRJ/permissions/users":  "org_members.json",
+		"/rest/api/1.0/projects/PRJ/permissions/groups": "project_groups.json",
+		repo:                                    "repo.json",
+		repo + "/permissions/users":             "find_user_permission.json",
+		repo + "/permissions/groups":            "repo_groups.json",
+		repo + "/webhooks":                      "webhooks.json",
+		repo + "/webhooks/1":                    "webhook.json",
+		repo + "/pull-requests":                 "prs.json",
+		repo + "/pull-requests/1":               "pr.json",
+		repo + "/pull-requests/1/changes":       "pr_change.json",
+		repo + "/pull-requests/1/activities":    "pr_comments.json",
+		repo + "/pull-requests/1/comments/1":    "pr_comment.json",
+		repo + "/raw/README":                    "content.txt",
+		repo + "/branches":                      "branch.json",
+		repo + "/branches/default":              "default_branch.json",
+		repo + "/tags":                          "tags.json",
+		repo + "/compare/changes":               "changes.json",
+		repo + "/commits/master":                "commit.json",
+		repo + "/commits/" + sha:                "commit.json",
+		repo + "/commits/" + sha + "/changes":   "changes.json",
+		"/rest/build-status/1.0/commits/" + sha: "commit_build_status.json",
+	}
+	server := standIn(t, "../driver/stash/testdata", routes)
+
+	return Target{
+		Name:      "stash",
+		NewClient: func() (*scm.Client, error) { return stash.New(server.URL) },
+		Fixture: Fixture{
+			Repo:               "PRJ/my-repo",
+			Org:                "PRJ",
+			User:               "jcitizen",
+			PullRequest:        1,
+			PullRequestComment: 1,
+			Issue:              1,
+			Branch:             "master",
+			Commit:             sha,
+			Path:               "README",
+			Tag:                "v1.0.0",
+			Hook:               "1",
+		},
+		// the driver finds the user with the applinks servlet,
+		// which replies in plain text the stand-in does not
+		// serve, and the testdata lists other files than the
+		// one of the fixture.
+		Skip: []string{"Users.Find", "Users.FindEmail", "Contents.List"},
+	}
+}
+
+// bitbucketTarget returns a target of the Bitbucket Cloud
+// driver, backed by a stand-in server replying with the
+// testdata of the driver.
+func bitbucketTarget(t *testing.T) Target {
+	const repo = "/2.0/repositories/atlassian/stash-example-plugin"
+	const sha = "a6e5e7d797edf751cbd839d6bd4aef86c941eec9"
+	const hook = "{d53603cc-3f67-45ea-b310-aaa5ef6ec061}"
+	routes := map[string]string{
+		"/2.0/user":                          "user.json",
+		"/2.0/user/permissions/repositories": "perms.json",
+		"/2.0/user/workspaces":               "workspaces.json",
+		"/2.0/users/brydzewski":              "user.json",
+		"/2.0/workspaces/atlassian":          "workspace.json",
+		"/2.0/workspaces/atlassian/permissions/repositories/stash-example-plugin": "repo-permision.json",
+		"/2.0/repositories":                    "repos.json",
+		"/2.0/repositories/atlassian":          "repos.json",
+		repo:                                   "repo.json",
+		repo + "/hooks":                        "hooks.json",
+		repo + "/hooks/" + hook:                "hook.json",
+		repo + "/pullrequests":                 "pulls.json",
+		repo + "/pullrequests/2":               "pr_create.json",
+		repo + "/pullrequests/2/diffstat":      "pr_diffstat.json",
+		repo + "/src/master/README":            "content.txt",
+		repo + "/refs/branches":                "branches.json",
+		repo + "/refs/branches/master":         "branch.json",
+		repo + "/refs/tags":                    "tags.json",
+		repo + "/refs/tags/v1.0.0":             "tag.json",
+		repo + "/commits/master":               "commits.json",
+		repo + "/commit/" + sha:                "commit.json",
+		repo + "/commit/" + sha + "/statuses":  "statuses.json",
+		repo + "/diffstat/" + sha:              "diffstat.json",
+		repo + "/diffstat/" + sha + ".." + sha: "diffstat.json",
+	}
+	server := standIn(t, "../driver/bitbucket/testdata", routes)
+
+	return Target{
+		Name:      "bitbucket",
+		NewClient: func() (*scm.Client, error) { return bitbucket.New(server.URL) },
+		Fixture: Fixture{
+			Repo:        "atlassian/stash-example-plugin",
+			Org:         "atlassian",
+			User:        "brydzewski",
+			PullRequest: 2,
+			Issue:       1,
+			Branch:      "master",
+			Commit:      sha,
+			Path:        "README",
+			Tag:         "v1.0.0",
+			Hook:        hook,
+		},
+		// the testdata holds no comments, which the driver
+		// lists with the same endpoint for both services and
+		// parses the labels out of, nor members of the
+		// workspace.
+		Skip: []string{
+			"PullRequests.ListComments",
+			"PullRequests.ListLabels",
+			"Issues.ListComments",
+			"Issues.ListLabels",
+			"Organizations.IsMember",
+		},
+	}
+}
+
+// azureTarget returns a target of the Azure DevOps driver,
+// backed by a stand-in server replying with the testdata of
+// the driver.
+func azureTarget(t *testing.T) Target {
+	const repo = "/tphoney/test_project/_apis/git/repositories/test_repo"
+	routes := map[string]string{
+		"/tphoney/_apis/git/repositories":              "repos.json",
+		"/tphoney/test_project/_apis/git/repositories": "repos.json",
+		"/tphoney/_apis/projects/test_project":         "project.json",
+		repo:                                           "repo.json",
+		repo + "/pullrequests/19":                      "pr_active.json",
+		repo + "/pullRequests/19/commits":              "commits.json",
+		repo + "/items":                                "content.json",
+		repo + "/refs":                                 "branches.json",
+		repo + "/commits":                              "commits.json",
+		repo + "/diffs/commits":                        "compare.json",
+		repo + "/commits/14897f4465d2d63508242b5cbf68aa2865f693e7": "commit.json",
+	}
+	server := standIn(t, "../driver/azure/testdata", routes)
+
+	return Target{
+		Name:      "azure",
+		NewClient: func() (*scm.Client, error) { return azure.New(server.URL) },
+		Fixture: Fixture{
+			Repo:        "tphoney/test_project/test_repo",
+			Org:         "tphoney",
+			User:        "tp",
+			PullRequest: 19,
+			Issue:       19,
+			Branch:      "main",
+			Commit:      "14897f4465d2d63508242b5cbf68aa2865f693e7",
+			Path:        "README",
+			Tag:         "v1.0.0",
+		},
+		// the testdata holds no list of pull requests, and the
+		// driver finds and lists the contents with the same
+		// endpoint.
+		Skip: []string{"PullRequests.List", "Contents.List"},
+	}
+}
+
+// standIn returns a server replying to the GET requests of
+// the routes with the files of the testdata directory, and
+// with 404 otherwise.
+func standIn(t *testing.T, dir string, routes map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.Path]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		http.ServeFile(w, r, filepath.Join(dir, file))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
@@ -31,6 +31,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverAzure
+	client.SetCapabilities(capabilities)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -76,11 +77,18 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	}
 	defer res.Body.Close()
 
+	// parse the azure activity id.
+	res.ID = res.Header.Get("ActivityId")
+
 	// error response.
 	if res.Status > 300 {
-		err := new(Error)
-		_ = json.NewDecoder(res.Body).Decode(err)
-		return res, err
+		apiErr := scm.NewError(scm.DriverAzure, res)
+		out := new(Error)
+		if json.NewDecoder(res.Body).Decode(out) == nil && out.Message != "" {
+			apiErr.Message = out.Message
+			apiErr.Err = out
+		}
+		return res, apiErr
 	}
 	// the following is used for debugging purposes.
 	// bytes, err := io.ReadAll(res.Body)
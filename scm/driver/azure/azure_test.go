@@ -6,6 +6,8 @@ package azure
 
 import (
 	"testing"
+
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
 )
 
 func TestClient_Base(t *testing.T) {
@@ -58,3 +60,7 @@ func TestSanitizeBranchName(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Capabilities(t *testing.T) {
+	captest.Verify(t, NewDefault())
+}
@@ -0,0 +1,109 @@
+package azure
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// Azure DevOps driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapGitDeleteRef,
+		scm.CapGitFindBranch,
+		scm.CapGitFindRef,
+		scm.CapGitFindTag,
+		scm.CapGitGetDefaultBranch,
+		scm.CapGitListChanges,
+		scm.CapGitListTags,
+		scm.CapOrganizationAcceptOrganizationInvitation,
+		scm.CapOrganizationCreate,
+		scm.CapOrganizationDelete,
+		scm.CapOrganizationFind,
+		scm.CapOrganizationIsAdmin,
+		scm.CapOrganizationIsMember,
+		scm.CapOrganizationList,
+		scm.CapOrganizationListMemberships,
+		scm.CapOrganizationListOrgMembers,
+		scm.CapOrganizationListPendingInvitations,
+		scm.CapOrganizationListTeamMembers,
+		scm.CapOrganizationListTeams,
+		scm.CapIssueAddLabel,
+		scm.CapIssueAssignIssue,
+		scm.CapIssueClearMilestone,
+		scm.CapIssueClose,
+		scm.CapIssueCreate,
+		scm.CapIssueCreateComment,
+		scm.CapIssueDeleteComment,
+		scm.CapIssueDeleteLabel,
+		scm.CapIssueEditComment,
+		scm.CapIssueFind,
+		scm.CapIssueFindComment,
+		scm.CapIssueList,
+		scm.CapIssueListComments,
+		scm.CapIssueListEvents,
+		scm.CapIssueListLabels,
+		scm.CapIssueLock,
+		scm.CapIssueReopen,
+		scm.CapIssueSearch,
+		scm.CapIssueSetMilestone,
+		scm.CapIssueUnassignIssue,
+		scm.CapIssueUnlock,
+		scm.CapPullRequestAddLabel,
+		scm.CapPullRequestAssignIssue,
+		scm.CapPullRequestClearMilestone,
+		scm.CapPullRequestCreateComment,
+		scm.CapPullRequestDeleteComment,
+		scm.CapPullRequestDeleteLabel,
+		scm.CapPullRequestDeletePullRequest,
+		scm.CapPullRequestEditComment,
+		scm.CapPullRequestFindComment,
+		scm.CapPullRequestListChanges,
+		scm.CapPullRequestListComments,
+		scm.CapPullRequestListEvents,
+		scm.CapPullRequestListLabels,
+		scm.CapPullRequestReopen,
+		scm.CapPullRequestRequestReview,
+		scm.CapPullRequestSetMilestone,
+		scm.CapPullRequestUnassignIssue,
+		scm.CapPullRequestUnrequestReview,
+		scm.CapRepositoryAddCollaborator,
+		scm.CapRepositoryCreateHook,
+		scm.CapRepositoryCreateStatus,
+		scm.CapRepositoryDeleteHook,
+		scm.CapRepositoryFindCombinedStatus,
+		scm.CapRepositoryFindHook,
+		scm.CapRepositoryFindPerms,
+		scm.CapRepositoryFindUserPermission,
+		scm.CapRepositoryFork,
+		scm.CapRepositoryIsCollaborator,
+		scm.CapRepositoryList,
+		scm.CapRepositoryListCollaborators,
+		scm.CapRepositoryListHooks,
+		scm.CapRepositoryListLabels,
+		scm.CapRepositoryListStatus,
+		scm.CapRepositoryListUser,
+		scm.CapRepositoryUpdateHook,
+		scm.CapReviewCreate,
+		scm.CapReviewDelete,
+		scm.CapReviewDismiss,
+		scm.CapReviewFind,
+		scm.CapReviewList,
+		scm.CapReviewListComments,
+		scm.CapReviewSubmit,
+		scm.CapReviewUpdate,
+		scm.CapUserAcceptInvitation,
+		scm.CapUserCreateToken,
+		scm.CapUserDeleteToken,
+		scm.CapUserFind,
+		scm.CapUserFindEmail,
+		scm.CapUserFindLogin,
+		scm.CapUserListInvitations,
+	},
+	Webhooks: []scm.WebhookKind{
+		scm.WebhookKindIssueComment,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPush,
+	},
+	MergeMethods: []string{
+		scm.MergeMethodMerge,
+	},
+	Labels: scm.LabelsNotSupported,
+}
@@ -3,7 +3,9 @@ package integration_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/jenkins-x/go-scm/scm"
 	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/jenkins-x/go-scm/scm/transport"
 )
 
 var (
@@ -24,7 +27,7 @@ var (
 	// this project should be safe to create/delete repositories in
 	project   = os.Getenv("AZURE_PROJECT")
 	projectFQ = fmt.Sprintf("%s/%s", organization, project)
-	client    = makeClient()
+	client    *scm.Client
 )
 
 type TestCase struct {
@@ -32,11 +35,20 @@ type TestCase struct {
 	Test func(t *testing.T)
 }
 
+// canRun configures the client of the test, whose interactions
+// are recorded to testdata/<test>.yaml when SCM_RECORD=true.
 func canRun(t *testing.T) {
-	if (token != "") && (project != "") && (organization != "") {
+	if token == "" || project == "" || organization == "" {
+		t.Skip("Acceptance tests not configured (need AZURE_TOKEN, AZURE_ORG, AZURE_PROJECT)")
+	}
+	client = makeClient()
+	recorder := transport.NewRecorder(filepath.Join("testdata", t.Name()+".yaml"), transport.RecorderModeFromEnv())
+	if recorder.Mode != transport.ModeRecord {
 		return
 	}
-	t.Skip("Acceptance tests not configured (need AZURE_TOKEN, AZURE_ORG, AZURE_PROJECT)")
+	recorder.Base = client.Client.Transport
+	recorder.Secrets = []string{token}
+	client.Client = &http.Client{Transport: recorder}
 }
 
 func repoFQ(repositoryName string) string {
@@ -48,6 +60,9 @@ func makeClient() *scm.Client {
 	if err != nil {
 		panic("could not create azure client")
 	}
+	if cl.Client == nil {
+		cl.Client = http.DefaultClient
+	}
 	return cl
 }
 
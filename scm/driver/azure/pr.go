@@ -77,7 +77,7 @@ func (s *pullService) List(ctx context.Context, repo string, opts *scm.PullReque
 	}
 
 	top := opts.Size
-	skip := (opts.Page - 1) * opts.Size
+	skip := max(opts.Page-1, 0) * opts.Size
 
 	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/get-pull-request?view=azure-devops-rest-6.0
 	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests?api-version=6.0&$skip=%d&$top=%d",
@@ -225,7 +225,7 @@ func convertRepositoryList(from *repositories, options *scm.ListOptions) []*scm.
 	var to []*scm.Repository
 
 	paging := options.Size > 0
-	start := safecast.MustConvert[uint64](options.Page * options.Size)
+	start := safecast.MustConvert[uint64](max(options.Page-1, 0) * options.Size)
 	end := start + safecast.MustConvert[uint64](options.Size)
 
 	var curr uint64
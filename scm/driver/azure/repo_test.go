@@ -163,3 +163,34 @@ func TestRepositoryDelete(t *testing.T) {
 		return
 	}
 }
+
+func TestConvertRepositoryListPaging(t *testing.T) {
+	from := &repositories{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		from.Value = append(from.Value, &repository{Name: name})
+	}
+
+	tests := []struct {
+		page, size int
+		want       []string
+	}{
+		{page: 0, size: 0, want: []string{"a", "b", "c", "d", "e"}},
+		{page: 0, size: 2, want: []string{"a", "b"}},
+		{page: 1, size: 2, want: []string{"a", "b"}},
+		{page: 2, size: 2, want: []string{"c", "d"}},
+		{page: 3, size: 2, want: []string{"e"}},
+		{page: 4, size: 2, want: nil},
+		{page: 1, size: 5, want: []string{"a", "b", "c", "d", "e"}},
+		{page: 2, size: 5, want: nil},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, repo := range convertRepositoryList(from, &scm.ListOptions{Page: test.page, Size: test.size}) {
+			got = append(got, repo.Name)
+		}
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("Unexpected Results for page %d of size %d", test.page, test.size)
+			t.Log(diff)
+		}
+	}
+}
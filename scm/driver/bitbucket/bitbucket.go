@@ -9,10 +9,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
-	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -36,6 +35,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverBitbucket
+	client.SetCapabilities(capabilities)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -90,12 +90,31 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	}
 	defer res.Body.Close()
 
+	// parse the bitbucket request id.
+	res.ID = res.Header.Get("X-Request-Id")
+
 	// if an error is encountered, unmarshal and return the
 	// error response.
 	if res.Status > 300 {
-		return res, errors.New(
-			http.StatusText(res.Status),
-		)
+		apiErr := scm.NewError(scm.DriverBitbucket, res)
+		out := new(Error)
+		if json.NewDecoder(res.Body).Decode(out) == nil {
+			apiErr.Message = out.Data.Message
+			fields := make([]string, 0, len(out.Data.Fields))
+			for field := range out.Data.Fields {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				for _, e := range out.Data.Fields[field] {
+					apiErr.Errors = append(apiErr.Errors, scm.FieldError{
+						Field:   field,
+						Message: e,
+					})
+				}
+			}
+		}
+		return res, apiErr
 	}
 
 	if out == nil {
@@ -127,7 +146,8 @@ type pagination struct {
 type Error struct {
 	Type string `json:"type"`
 	Data struct {
-		Message string `json:"message"`
+		Message string              `json:"message"`
+		Fields  map[string][]string `json:"fields"`
 	} `json:"error"`
 }
 
@@ -5,9 +5,12 @@
 package bitbucket
 
 import (
+	"context"
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestClient(t *testing.T) {
@@ -54,3 +57,39 @@ func testPage(res *scm.Response) func(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_Capabilities(t *testing.T) {
+	captest.Verify(t, NewDefault())
+}
+
+func TestClient_Rate(t *testing.T) {
+	defer gock.Off()
+
+	// the headers of a Bitbucket Cloud response to the
+	// user endpoint.
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/user").
+		Reply(200).
+		Type("application/json").
+		SetHeader("X-Accepted-Oauth-Scopes", "account").
+		SetHeader("X-Credential-Type", "apppassword").
+		SetHeader("X-Ratelimit-Limit", "1000").
+		SetHeader("X-Ratelimit-Resource", "api-user").
+		SetHeader("X-Ratelimit-Nearlimit", "false").
+		SetHeader("X-Request-Count", "22").
+		SetHeader("X-Usage-Input-Bytes", "0").
+		File("testdata/user.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, res, err := client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := scm.Rate{Limit: 1000, Remaining: 1000}
+	if got := res.Rate; got != want {
+		t.Errorf("Want response rate %+v, got %+v", want, got)
+	}
+	if got := client.Rate(); got != want {
+		t.Errorf("Want client rate %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,88 @@
+package bitbucket
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// Bitbucket driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapContentCreate,
+		scm.CapContentDelete,
+		scm.CapContentList,
+		scm.CapContentUpdate,
+		scm.CapGitCreateRef,
+		scm.CapGitDeleteRef,
+		scm.CapOrganizationAcceptOrganizationInvitation,
+		scm.CapOrganizationCreate,
+		scm.CapOrganizationDelete,
+		scm.CapOrganizationIsAdmin,
+		scm.CapOrganizationListMemberships,
+		scm.CapOrganizationListOrgMembers,
+		scm.CapOrganizationListPendingInvitations,
+		scm.CapOrganizationListTeamMembers,
+		scm.CapOrganizationListTeams,
+		scm.CapIssueAssignIssue,
+		scm.CapIssueClearMilestone,
+		scm.CapIssueClose,
+		scm.CapIssueCreate,
+		scm.CapIssueEditComment,
+		scm.CapIssueFind,
+		scm.CapIssueFindComment,
+		scm.CapIssueList,
+		scm.CapIssueListEvents,
+		scm.CapIssueLock,
+		scm.CapIssueReopen,
+		scm.CapIssueSearch,
+		scm.CapIssueSetMilestone,
+		scm.CapIssueUnassignIssue,
+		scm.CapIssueUnlock,
+		scm.CapMilestoneCreate,
+		scm.CapMilestoneDelete,
+		scm.CapMilestoneFind,
+		scm.CapMilestoneList,
+		scm.CapMilestoneUpdate,
+		scm.CapPullRequestAssignIssue,
+		scm.CapPullRequestClearMilestone,
+		scm.CapPullRequestClose,
+		scm.CapPullRequestDeletePullRequest,
+		scm.CapPullRequestEditComment,
+		scm.CapPullRequestFindComment,
+		scm.CapPullRequestListCommits,
+		scm.CapPullRequestListEvents,
+		scm.CapPullRequestReopen,
+		scm.CapPullRequestRequestReview,
+		scm.CapPullRequestSetMilestone,
+		scm.CapPullRequestUnassignIssue,
+		scm.CapPullRequestUnrequestReview,
+		scm.CapPullRequestUpdate,
+		scm.CapRepositoryDelete,
+		scm.CapRepositoryFindUserPermission,
+		scm.CapRepositoryFork,
+		scm.CapRepositoryListUser,
+		scm.CapRepositoryUpdateHook,
+		scm.CapReviewCreate,
+		scm.CapReviewDelete,
+		scm.CapReviewDismiss,
+		scm.CapReviewFind,
+		scm.CapReviewList,
+		scm.CapReviewListComments,
+		scm.CapReviewSubmit,
+		scm.CapReviewUpdate,
+		scm.CapUserAcceptInvitation,
+		scm.CapUserCreateToken,
+		scm.CapUserDeleteToken,
+		scm.CapUserFindEmail,
+		scm.CapUserListInvitations,
+	},
+	Webhooks: []scm.WebhookKind{
+		scm.WebhookKindBranch,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPullRequestComment,
+		scm.WebhookKindPush,
+		scm.WebhookKindTag,
+	},
+	MergeMethods: []string{
+		scm.MergeMethodMerge,
+	},
+	Labels: scm.LabelsComments,
+}
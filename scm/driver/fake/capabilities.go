@@ -0,0 +1,37 @@
+package fake
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// fake driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapOrganizationDelete,
+		scm.CapOrganizationListOrgMembers,
+		scm.CapIssueClearMilestone,
+		scm.CapIssueEditComment,
+		scm.CapIssueSetMilestone,
+		scm.CapPullRequestClearMilestone,
+		scm.CapPullRequestDeletePullRequest,
+		scm.CapPullRequestEditComment,
+		scm.CapPullRequestListCommits,
+		scm.CapPullRequestListEvents,
+		scm.CapPullRequestRequestReview,
+		scm.CapPullRequestSetMilestone,
+		scm.CapPullRequestUnrequestReview,
+		scm.CapRepositoryUpdateHook,
+		scm.CapReviewDismiss,
+		scm.CapReviewListComments,
+		scm.CapReviewSubmit,
+		scm.CapReviewUpdate,
+		scm.CapUserCreateToken,
+		scm.CapUserDeleteToken,
+	},
+	MergeMethods: []string{
+		scm.MergeMethodMerge,
+		scm.MergeMethodSquash,
+		scm.MergeMethodRebase,
+		scm.MergeMethodRebaseMerge,
+	},
+	Labels: scm.LabelsNative,
+}
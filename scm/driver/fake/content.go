@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/jenkins-x/go-scm/scm"
 	"github.com/pkg/errors"
 )
@@ -22,6 +25,9 @@ type contentService struct {
 }
 
 func (c contentService) Find(_ context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	if r := c.data.GitRepository(repo); r != nil {
+		return c.findGit(r, path, ref)
+	}
 	f, err := c.path(repo, path, ref)
 	if err != nil {
 		return nil, nil, err
@@ -44,6 +50,9 @@ func (c contentService) Find(_ context.Context, repo, path, ref string) (*scm.Co
 }
 
 func (c contentService) List(_ context.Context, repo, path, ref string, opts *scm.ListOptions) ([]*scm.FileEntry, *scm.Response, error) {
+	if r := c.data.GitRepository(repo); r != nil {
+		return c.listGit(r, repo, path, ref)
+	}
 	dir, err := c.path(repo, path, ref)
 	if err != nil {
 		return nil, nil, err
@@ -78,6 +87,9 @@ func (c contentService) List(_ context.Context, repo, path, ref string, opts *sc
 }
 
 func (c contentService) Create(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if r := c.data.GitRepository(repo); r != nil {
+		return c.writeGit(r, repo, path, params, false)
+	}
 	f, err := c.path(repo, path, "")
 	if err != nil {
 		return nil, err
@@ -90,6 +102,9 @@ func (c contentService) Create(_ context.Context, repo, path string, params *scm
 }
 
 func (c contentService) Update(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if r := c.data.GitRepository(repo); r != nil {
+		return c.writeGit(r, repo, path, params, true)
+	}
 	f, err := c.path(repo, path, "")
 	if err != nil {
 		return nil, err
@@ -102,6 +117,9 @@ func (c contentService) Update(_ context.Context, repo, path string, params *scm
 }
 
 func (c contentService) Delete(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if r := c.data.GitRepository(repo); r != nil {
+		return c.deleteGit(r, repo, path, params)
+	}
 	f, err := c.path(repo, path, params.Ref)
 	if err != nil {
 		return nil, err
@@ -113,6 +131,127 @@ func (c contentService) Delete(_ context.Context, repo, path string, params *scm
 	return nil, nil
 }
 
+func (c contentService) findGit(r *git.Repository, path, ref string) (*scm.Content, *scm.Response, error) {
+	commit, err := resolveCommit(r, ref)
+	if err != nil {
+		return nil, notFound(), errors.Wrapf(err, "ref %s", ref)
+	}
+	file, err := commit.File(strings.Join(splitPath(path), "/"))
+	if err != nil {
+		return nil, notFound(), errors.Wrapf(scm.ErrNotFound, "file %s does not exist", path)
+	}
+	data, err := readBlob(file)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read file %s", path)
+	}
+	return &scm.Content{
+		Path:   path,
+		Data:   data,
+		Sha:    file.Hash.String(),
+		BlobID: file.Hash.String(),
+	}, nil, nil
+}
+
+func (c contentService) listGit(r *git.Repository, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	commit, err := resolveCommit(r, ref)
+	if err != nil {
+		return nil, notFound(), errors.Wrapf(err, "ref %s", ref)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, nil, err
+	}
+	dir := strings.Join(splitPath(path), "/")
+	if dir != "" {
+		if tree, err = tree.Tree(dir); err != nil {
+			return nil, notFound(), errors.Wrapf(scm.ErrNotFound, "directory %s does not exist", path)
+		}
+	}
+	var answer []*scm.FileEntry
+	for _, entry := range tree.Entries {
+		name := entry.Name
+		t := "file"
+		size := int64(0)
+		if entry.Mode == filemode.Dir {
+			t = "dir"
+		} else if size, err = tree.Size(name); err != nil {
+			return nil, nil, fmt.Errorf("cannot get info for file %s: %v", name, err)
+		}
+		answer = append(answer, &scm.FileEntry{
+			Name: name,
+			Path: filepath.ToSlash(filepath.Join(dir, name)),
+			Type: t,
+			Size: int(size),
+			Sha:  entry.Hash.String(),
+			Link: fmt.Sprintf("https://fake.com/%s/blob/%s/%s", repo, commit.Hash, filepath.ToSlash(filepath.Join(dir, name))),
+		})
+	}
+	return answer, nil, nil
+}
+
+// writeGit commits the file to the branch of the params,
+// which defaults to the default branch. The file must exist
+// to be updated, and must not exist to be created.
+func (c contentService) writeGit(r *git.Repository, repo, path string, params *scm.ContentParams, update bool) (*scm.Response, error) {
+	branch, err := c.branch(r, params)
+	if err != nil {
+		return nil, err
+	}
+	current, res, err := c.findGit(r, path, branch)
+	switch {
+	case update && err != nil:
+		return res, err
+	case update && params.Sha != "" && params.Sha != current.Sha:
+		return &scm.Response{Status: 409}, errors.Errorf("file %s has sha %s, not %s", path, current.Sha, params.Sha)
+	case !update && err == nil:
+		return &scm.Response{Status: 422}, errors.Errorf("file %s already exists", path)
+	}
+	data := params.Data
+	if data == nil {
+		data = []byte{}
+	}
+	commit, err := writeFile(r, branch, path, data, c.message(params, path), c.data.signature(params.Signature))
+	if err != nil {
+		return nil, err
+	}
+	action := scm.ActionCreate
+	if update {
+		action = scm.ActionUpdate
+	}
+	c.data.emitPush(repo, branch, commit, path, action)
+	return nil, nil
+}
+
+func (c contentService) deleteGit(r *git.Repository, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	branch, err := c.branch(r, params)
+	if err != nil {
+		return nil, err
+	}
+	if _, res, err := c.findGit(r, path, branch); err != nil {
+		return res, err
+	}
+	commit, err := writeFile(r, branch, path, nil, c.message(params, path), c.data.signature(params.Signature))
+	if err != nil {
+		return nil, err
+	}
+	c.data.emitPush(repo, branch, commit, path, scm.ActionDelete)
+	return nil, nil
+}
+
+func (c contentService) branch(r *git.Repository, params *scm.ContentParams) (string, error) {
+	if params.Branch != "" {
+		return params.Branch, nil
+	}
+	return headBranch(r)
+}
+
+func (c contentService) message(params *scm.ContentParams, path string) string {
+	if params.Message != "" {
+		return params.Message
+	}
+	return "Update " + strings.TrimPrefix(path, "/")
+}
+
 func (c contentService) path(repo, path, ref string) (string, error) {
 	if c.data.ContentDir == "" {
 		return "", errors.Errorf("no data.ContentDir configured")
@@ -1,6 +1,13 @@
 package fake
 
-import "github.com/jenkins-x/go-scm/scm"
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/jenkins-x/go-scm/scm"
+)
 
 // Data is used to store/represent test data for the fake client
 type Data struct {
@@ -76,6 +83,67 @@ type Data struct {
 
 	// ContentDir the directory used to implement the Content service to access files and directories
 	ContentDir string
+
+	// GitRepositories the in-memory git repositories backing the Git and Content services,
+	// keyed by the full name of the repository. See InitRepository
+	GitRepositories map[string]*git.Repository `json:"-"`
+
+	// WebhookID the GUID of the last webhook emitted. See Subscribe
+	WebhookID int
+
+	// HookSecrets the secrets signing the webhooks POSTed to the Hooks, keyed by hook ID
+	HookSecrets map[string]string
+
+	// Deliveries the webhooks POSTed to the Hooks
+	Deliveries []*Delivery
+
+	// HookClient the client POSTing the webhooks to the Hooks, a client timing out after
+	// DefaultHookTimeout if nil
+	HookClient *http.Client `json:"-"`
+
+	// Faults the faults injected in the calls of the fake clients
+	Faults []*Fault `json:"-"`
+
+	// Calls the calls made to the fake clients, in order
+	Calls []*Call `json:"-"`
+
+	// Clock returns the time of the resources and commits created, time.Now if nil,
+	// such as a fixed time for golden dumps. See Dump
+	Clock func() time.Time `json:"-"`
+
+	subscribers []func(scm.Webhook)
+	faultLock   sync.Mutex
+	results     map[string][][]byte
+
+	// mu serializes the calls of the fake clients, see lock.
+	mu      sync.Mutex
+	emitted []*emission
+}
+
+// lock locks the data for a call of the fake clients, so
+// that the clients and the Server can be used concurrently.
+func (d *Data) lock() {
+	d.mu.Lock()
+}
+
+// unlock unlocks the data, then dispatches the webhooks
+// emitted by the call, so that the subscribers and the hook
+// targets can call the fake clients back.
+func (d *Data) unlock() {
+	emitted := d.emitted
+	d.emitted = nil
+	d.mu.Unlock()
+	for _, e := range emitted {
+		d.dispatch(e)
+	}
+}
+
+// now returns the time of the clock of the data.
+func (d *Data) now() time.Time {
+	if d.Clock != nil {
+		return d.Clock()
+	}
+	return time.Now()
 }
 
 // DeletedRef represents a ref that has been deleted
@@ -117,5 +185,7 @@ func NewData() *Data {
 		Hooks:                     map[string][]*scm.Hook{},
 		Deployments:               map[string][]*scm.Deployment{},
 		DeploymentStatus:          map[string][]*scm.DeploymentStatus{},
+		GitRepositories:           map[string]*git.Repository{},
+		HookSecrets:               map[string]string{},
 	}
 }
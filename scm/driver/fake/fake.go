@@ -10,11 +10,21 @@ import (
 // The Data object lets you pre-load resources into the fake driver or check for results after the
 // scm operations have been performed
 func NewDefault() (*scm.Client, *Data) {
+	data := defaultData()
+	return newClient(data), data
+}
+
+// defaultData returns the data of the default fake client.
+func defaultData() *Data {
 	data := NewData()
 	data.CurrentUser.Login = "fakeuser"
 	data.CurrentUser.Name = "fakeuser"
 	data.ContentDir = "testdata"
+	return data
+}
 
+// newClient returns a fake client of the data.
+func newClient(data *Data) *scm.Client {
 	client := &wrapper{new(scm.Client)}
 	client.BaseURL = &url.URL{
 		Host: "fake.com",
@@ -22,6 +32,7 @@ func NewDefault() (*scm.Client, *Data) {
 	}
 	// initialize services
 	client.Driver = scm.DriverFake
+	client.SetCapabilities(capabilities)
 
 	client.Contents = &contentService{client: client, data: data}
 	client.Deployments = &deploymentService{client: client, data: data}
@@ -33,10 +44,13 @@ func NewDefault() (*scm.Client, *Data) {
 	client.Releases = &releaseService{client: client, data: data}
 	client.Reviews = &reviewService{client: client, data: data}
 	client.Users = &userService{client: client, data: data}
+	client.Webhooks = &webhookService{}
 
 	client.Username = data.CurrentUser.Login
 
-	return client.Client, data
+	injectFaults(client.Client, data)
+
+	return client.Client
 }
 
 type wrapper struct {
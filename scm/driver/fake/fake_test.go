@@ -0,0 +1,12 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
+)
+
+func TestClient_Capabilities(t *testing.T) {
+	client, _ := NewDefault()
+	captest.Verify(t, client)
+}
@@ -0,0 +1,223 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+//go:generate go run ./internal/faultgen
+
+// Fault makes the matching calls of the fake clients of the
+// data misbehave: fail, take longer or return stale data.
+//
+// A call matches the fault if its operation and repository
+// match. The first fault of the data applying to a call
+// intercepts it, see Call and Times.
+type Fault struct {
+	// Op the operation of the calls, such as
+	// scm.CapPullRequestMerge, or their service, such as
+	// PullRequests. Empty matches all the operations.
+	Op scm.Capability
+	// Repo the full name of the repository of the calls.
+	// Empty matches all the repositories.
+	Repo string
+
+	// Call the number of the only matching call the fault
+	// applies to, starting at one, such as 3 to fail the
+	// third call. Zero applies to every matching call.
+	Call int
+	// Times the maximum number of calls the fault applies
+	// to, zero for no limit.
+	Times int
+
+	// Latency the delay before the call proceeds or fails.
+	// A call whose context is done in the meantime returns
+	// the error of the context.
+	Latency time.Duration
+	// Err the error returned by the calls.
+	Err error
+	// Status the HTTP status of the scm.Error returned by
+	// the calls if Err is nil, such as http.StatusConflict,
+	// http.StatusServiceUnavailable or, for a rate limit,
+	// http.StatusTooManyRequests.
+	Status int
+	// Message the message of the scm.Error, the status text
+	// if empty.
+	Message string
+	// Stale returns the results of the last successful call
+	// of the operation with the same arguments, as a lagging
+	// replica of the provider would. A call without such
+	// results proceeds.
+	Stale bool
+
+	calls   int
+	applied int
+}
+
+// Call records a call to the fake clients of the data. See
+// Data.Calls.
+type Call struct {
+	Op scm.Capability
+	// Repo the full name of the repository of the call, if
+	// the operation takes one.
+	Repo string
+	// Args the arguments of the call, after the context.
+	Args []interface{}
+	// Err the error returned by the call.
+	Err error
+	// Fault the fault which intercepted the call, if any.
+	Fault *Fault
+	// Stale reports whether the call returned stale data.
+	Stale bool
+
+	ctx  context.Context
+	data *Data
+	key  string
+}
+
+// CallsOf returns the calls of the operation, or of the
+// service, such as PullRequests, in the order they were
+// made. See Calls.
+func (d *Data) CallsOf(op scm.Capability) []*Call {
+	d.faultLock.Lock()
+	defer d.faultLock.Unlock()
+	var calls []*Call
+	for _, call := range d.Calls {
+		if call.Op == op || string(op) == call.Op.Service() {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// call logs the call and returns it with the fault applying
+// to it, if any.
+func (d *Data) call(ctx context.Context, op scm.Capability, repo string, args ...interface{}) *Call {
+	d.faultLock.Lock()
+	defer d.faultLock.Unlock()
+	call := &Call{Op: op, Repo: repo, Args: args, ctx: ctx, data: d}
+	call.key = resultKey(op, args)
+	d.Calls = append(d.Calls, call)
+	for _, fault := range d.Faults {
+		if !fault.matches(op, repo) {
+			continue
+		}
+		fault.calls++
+		if call.Fault != nil || (fault.Call != 0 && fault.calls != fault.Call) || (fault.Times != 0 && fault.applied >= fault.Times) {
+			continue
+		}
+		fault.applied++
+		call.Fault = fault
+	}
+	return call
+}
+
+// matches reports whether the calls of the operation on the
+// repository match the fault.
+func (f *Fault) matches(op scm.Capability, repo string) bool {
+	if f.Op != "" && f.Op != op && string(f.Op) != op.Service() {
+		return false
+	}
+	return f.Repo == "" || f.Repo == repo
+}
+
+// error returns the error of the fault, nil if none.
+func (f *Fault) error() error {
+	if f.Err != nil || f.Status == 0 {
+		return f.Err
+	}
+	message := f.Message
+	if message == "" {
+		message = http.StatusText(f.Status)
+	}
+	return &scm.Error{Driver: scm.DriverFake, Status: f.Status, Message: message}
+}
+
+// intercept applies the fault of the call, if any. It
+// reports whether the fault intercepted the call, in which
+// case it sets the response, error and results the call
+// returns.
+func (c *Call) intercept(res **scm.Response, err *error, results ...interface{}) bool {
+	fault := c.Fault
+	if fault == nil {
+		return false
+	}
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		defer timer.Stop()
+		select {
+		case <-c.ctx.Done():
+			*err = c.ctx.Err()
+			c.done(*err)
+			return true
+		case <-timer.C:
+		}
+	}
+	if e := fault.error(); e != nil {
+		*res = &scm.Response{Status: scm.ErrorStatus(e), Header: http.Header{}}
+		if scm.IsRateLimited(e) {
+			(*res).Rate = scm.Rate{Reset: time.Now().Add(time.Minute).Unix()}
+		}
+		*err = e
+		c.done(e)
+		return true
+	}
+	if fault.Stale && c.stale(results) {
+		c.done(nil)
+		return true
+	}
+	return false
+}
+
+// stale sets the results to those of the last successful
+// call of the operation with the same arguments, and reports
+// whether there was one.
+func (c *Call) stale(results []interface{}) bool {
+	c.data.faultLock.Lock()
+	recorded, ok := c.data.results[c.key]
+	c.data.faultLock.Unlock()
+	if !ok || len(recorded) != len(results) {
+		return false
+	}
+	for i, result := range results {
+		if err := json.Unmarshal(recorded[i], result); err != nil {
+			return false
+		}
+	}
+	c.Stale = true
+	return true
+}
+
+// done records the error and, for a successful call, the
+// results of the call.
+func (c *Call) done(err error, results ...interface{}) {
+	c.data.faultLock.Lock()
+	defer c.data.faultLock.Unlock()
+	c.Err = err
+	if err != nil || len(results) == 0 || c.Stale {
+		return
+	}
+	recorded := make([][]byte, len(results))
+	for i, result := range results {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return
+		}
+		recorded[i] = b
+	}
+	if c.data.results == nil {
+		c.data.results = map[string][][]byte{}
+	}
+	c.data.results[c.key] = recorded
+}
+
+// resultKey returns the key of the results of the calls of
+// the operation with the arguments.
+func resultKey(op scm.Capability, args []interface{}) string {
+	b, _ := json.Marshal(args)
+	return string(op) + string(b)
+}
@@ -0,0 +1,1586 @@
+// Code generated by faultgen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// injectFaults decorates the services of the client with the
+// faults and the lock of the data.
+func injectFaults(client *scm.Client, data *Data) {
+	client.Contents = &faultContentService{next: client.Contents, data: data}
+	client.Deployments = &faultDeploymentService{next: client.Deployments, data: data}
+	client.Git = &faultGitService{next: client.Git, data: data}
+	client.Issues = &faultIssueService{next: client.Issues, data: data}
+	client.Organizations = &faultOrganizationService{next: client.Organizations, data: data}
+	client.PullRequests = &faultPullRequestService{next: client.PullRequests, data: data}
+	client.Repositories = &faultRepositoryService{next: client.Repositories, data: data}
+	client.Releases = &faultReleaseService{next: client.Releases, data: data}
+	client.Reviews = &faultReviewService{next: client.Reviews, data: data}
+	client.Users = &faultUserService{next: client.Users, data: data}
+}
+
+type faultContentService struct {
+	next scm.ContentService
+	data *Data
+}
+
+func (s *faultContentService) Create(ctx context.Context, p1 string, p2 string, p3 *scm.ContentParams) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapContentCreate, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Create(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultContentService) Delete(ctx context.Context, p1 string, p2 string, p3 *scm.ContentParams) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapContentDelete, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Delete(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultContentService) Find(ctx context.Context, p1 string, p2 string, p3 string) (r0 *scm.Content, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapContentFind, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultContentService) List(ctx context.Context, p1 string, p2 string, p3 string, p4 *scm.ListOptions) (r0 []*scm.FileEntry, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapContentList, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultContentService) Update(ctx context.Context, p1 string, p2 string, p3 *scm.ContentParams) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapContentUpdate, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Update(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+type faultDeploymentService struct {
+	next scm.DeploymentService
+	data *Data
+}
+
+func (s *faultDeploymentService) Create(ctx context.Context, p1 string, p2 *scm.DeploymentInput) (r0 *scm.Deployment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapDeploymentCreate, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultDeploymentService) CreateStatus(ctx context.Context, p1 string, p2 string, p3 *scm.DeploymentStatusInput) (r0 *scm.DeploymentStatus, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapDeploymentCreateStatus, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CreateStatus(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultDeploymentService) Delete(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapDeploymentDelete, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Delete(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultDeploymentService) Find(ctx context.Context, p1 string, p2 string) (r0 *scm.Deployment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapDeploymentFind, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultDeploymentService) FindStatus(ctx context.Context, p1 string, p2 string, p3 string) (r0 *scm.DeploymentStatus, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapDeploymentFindStatus, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindStatus(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultDeploymentService) List(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Deployment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapDeploymentList, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultDeploymentService) ListStatus(ctx context.Context, p1 string, p2 string, p3 *scm.ListOptions) (r0 []*scm.DeploymentStatus, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapDeploymentListStatus, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListStatus(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+type faultGitService struct {
+	next scm.GitService
+	data *Data
+}
+
+func (s *faultGitService) CompareCommits(ctx context.Context, p1 string, p2 string, p3 string, p4 *scm.ListOptions) (r0 []*scm.Change, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitCompareCommits, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CompareCommits(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) CreateRef(ctx context.Context, p1 string, p2 string, p3 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitCreateRef, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CreateRef(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) DeleteRef(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitDeleteRef, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteRef(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) FindBranch(ctx context.Context, p1 string, p2 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitFindBranch, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindBranch(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) FindCommit(ctx context.Context, p1 string, p2 string) (r0 *scm.Commit, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitFindCommit, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindCommit(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) FindRef(ctx context.Context, p1 string, p2 string) (r0 string, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitFindRef, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindRef(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) FindTag(ctx context.Context, p1 string, p2 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitFindTag, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindTag(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) GetDefaultBranch(ctx context.Context, p1 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitGetDefaultBranch, p1, p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.GetDefaultBranch(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) ListBranches(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Reference, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitListBranches, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListBranches(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) ListChanges(ctx context.Context, p1 string, p2 string, p3 *scm.ListOptions) (r0 []*scm.Change, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitListChanges, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListChanges(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) ListCommits(ctx context.Context, p1 string, p2 scm.CommitListOptions) (r0 []*scm.Commit, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitListCommits, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListCommits(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultGitService) ListTags(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Reference, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapGitListTags, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListTags(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+type faultIssueService struct {
+	next scm.IssueService
+	data *Data
+}
+
+func (s *faultIssueService) AddLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueAddLabel, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.AddLabel(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) AssignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueAssignIssue, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.AssignIssue(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) ClearMilestone(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueClearMilestone, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.ClearMilestone(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) Close(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueClose, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Close(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) Create(ctx context.Context, p1 string, p2 *scm.IssueInput) (r0 *scm.Issue, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueCreate, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) CreateComment(ctx context.Context, p1 string, p2 int, p3 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueCreateComment, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CreateComment(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) DeleteComment(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueDeleteComment, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteComment(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) DeleteLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueDeleteLabel, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteLabel(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) EditComment(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueEditComment, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.EditComment(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) Find(ctx context.Context, p1 string, p2 int) (r0 *scm.Issue, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueFind, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) FindComment(ctx context.Context, p1 string, p2 int, p3 int) (r0 *scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueFindComment, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindComment(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) List(ctx context.Context, p1 string, p2 scm.IssueListOptions) (r0 []*scm.Issue, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueList, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) ListComments(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueListComments, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListComments(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) ListEvents(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.ListedIssueEvent, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueListEvents, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListEvents(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) ListLabels(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Label, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueListLabels, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListLabels(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) Lock(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueLock, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Lock(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) Reopen(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueReopen, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Reopen(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) Search(ctx context.Context, p1 scm.SearchOptions) (r0 []*scm.SearchIssue, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueSearch, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Search(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) SetMilestone(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueSetMilestone, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.SetMilestone(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) UnassignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueUnassignIssue, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.UnassignIssue(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultIssueService) Unlock(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapIssueUnlock, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Unlock(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+type faultOrganizationService struct {
+	next scm.OrganizationService
+	data *Data
+}
+
+func (s *faultOrganizationService) AcceptOrganizationInvitation(ctx context.Context, p1 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationAcceptOrganizationInvitation, "", p1)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.AcceptOrganizationInvitation(ctx, p1)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) Create(ctx context.Context, p1 *scm.OrganizationInput) (r0 *scm.Organization, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationCreate, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Create(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) Delete(ctx context.Context, p1 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationDelete, "", p1)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Delete(ctx, p1)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) Find(ctx context.Context, p1 string) (r0 *scm.Organization, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationFind, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) IsAdmin(ctx context.Context, p1 string, p2 string) (r0 bool, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationIsAdmin, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.IsAdmin(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) IsMember(ctx context.Context, p1 string, p2 string) (r0 bool, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationIsMember, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.IsMember(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) List(ctx context.Context, p1 *scm.ListOptions) (r0 []*scm.Organization, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationList, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) ListMemberships(ctx context.Context, p1 *scm.ListOptions) (r0 []*scm.Membership, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationListMemberships, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListMemberships(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) ListOrgMembers(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.TeamMember, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationListOrgMembers, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListOrgMembers(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) ListPendingInvitations(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.OrganizationPendingInvite, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationListPendingInvitations, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListPendingInvitations(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) ListTeamMembers(ctx context.Context, p1 int, p2 string, p3 *scm.ListOptions) (r0 []*scm.TeamMember, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationListTeamMembers, "", p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListTeamMembers(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultOrganizationService) ListTeams(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Team, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapOrganizationListTeams, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListTeams(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+type faultPullRequestService struct {
+	next scm.PullRequestService
+	data *Data
+}
+
+func (s *faultPullRequestService) AddLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestAddLabel, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.AddLabel(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) AssignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestAssignIssue, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.AssignIssue(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) ClearMilestone(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestClearMilestone, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.ClearMilestone(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) Close(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestClose, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Close(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) Create(ctx context.Context, p1 string, p2 *scm.PullRequestInput) (r0 *scm.PullRequest, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestCreate, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) CreateComment(ctx context.Context, p1 string, p2 int, p3 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestCreateComment, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CreateComment(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) DeleteComment(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestDeleteComment, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteComment(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) DeleteLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestDeleteLabel, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteLabel(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) DeletePullRequest(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestDeletePullRequest, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeletePullRequest(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) EditComment(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestEditComment, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.EditComment(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) Find(ctx context.Context, p1 string, p2 int) (r0 *scm.PullRequest, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestFind, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) FindComment(ctx context.Context, p1 string, p2 int, p3 int) (r0 *scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestFindComment, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindComment(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) List(ctx context.Context, p1 string, p2 *scm.PullRequestListOptions) (r0 []*scm.PullRequest, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestList, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) ListChanges(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Change, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestListChanges, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListChanges(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) ListComments(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Comment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestListComments, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListComments(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) ListCommits(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Commit, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestListCommits, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListCommits(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) ListEvents(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.ListedIssueEvent, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestListEvents, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListEvents(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) ListLabels(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Label, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestListLabels, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListLabels(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) Merge(ctx context.Context, p1 string, p2 int, p3 *scm.PullRequestMergeOptions) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestMerge, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Merge(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) Reopen(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestReopen, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Reopen(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) RequestReview(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestRequestReview, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.RequestReview(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) SetMilestone(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestSetMilestone, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.SetMilestone(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) UnassignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestUnassignIssue, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.UnassignIssue(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) UnrequestReview(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestUnrequestReview, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.UnrequestReview(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultPullRequestService) Update(ctx context.Context, p1 string, p2 int, p3 *scm.PullRequestInput) (r0 *scm.PullRequest, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapPullRequestUpdate, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Update(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+type faultRepositoryService struct {
+	next scm.RepositoryService
+	data *Data
+}
+
+func (s *faultRepositoryService) AddCollaborator(ctx context.Context, p1 string, p2 string, p3 string) (r0 bool, r1 bool, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryAddCollaborator, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0, &r1) {
+		return
+	}
+	s.data.lock()
+	r0, r1, res, err = s.next.AddCollaborator(ctx, p1, p2, p3)
+	call.done(err, r0, r1)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) Create(ctx context.Context, p1 *scm.RepositoryInput) (r0 *scm.Repository, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryCreate, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Create(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) CreateHook(ctx context.Context, p1 string, p2 *scm.HookInput) (r0 *scm.Hook, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryCreateHook, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CreateHook(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) CreateStatus(ctx context.Context, p1 string, p2 string, p3 *scm.StatusInput) (r0 *scm.Status, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryCreateStatus, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CreateStatus(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) Delete(ctx context.Context, p1 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryDelete, p1, p1)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Delete(ctx, p1)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) DeleteHook(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryDeleteHook, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteHook(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) Find(ctx context.Context, p1 string) (r0 *scm.Repository, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryFind, p1, p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) FindCombinedStatus(ctx context.Context, p1 string, p2 string) (r0 *scm.CombinedStatus, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryFindCombinedStatus, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindCombinedStatus(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) FindHook(ctx context.Context, p1 string, p2 string) (r0 *scm.Hook, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryFindHook, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindHook(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) FindPerms(ctx context.Context, p1 string) (r0 *scm.Perm, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryFindPerms, p1, p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindPerms(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) FindUserPermission(ctx context.Context, p1 string, p2 string) (r0 string, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryFindUserPermission, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindUserPermission(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) Fork(ctx context.Context, p1 *scm.RepositoryInput, p2 string) (r0 *scm.Repository, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryFork, p2, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Fork(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) IsCollaborator(ctx context.Context, p1 string, p2 string) (r0 bool, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryIsCollaborator, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.IsCollaborator(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) List(ctx context.Context, p1 *scm.ListOptions) (r0 []*scm.Repository, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryList, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) ListCollaborators(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []scm.User, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryListCollaborators, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListCollaborators(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) ListHooks(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Hook, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryListHooks, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListHooks(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) ListLabels(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Label, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryListLabels, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListLabels(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) ListOrganisation(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Repository, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryListOrganisation, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListOrganisation(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) ListStatus(ctx context.Context, p1 string, p2 string, p3 *scm.ListOptions) (r0 []*scm.Status, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryListStatus, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListStatus(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) ListUser(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Repository, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryListUser, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListUser(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultRepositoryService) UpdateHook(ctx context.Context, p1 string, p2 *scm.HookInput) (r0 *scm.Hook, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapRepositoryUpdateHook, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.UpdateHook(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+type faultReleaseService struct {
+	next scm.ReleaseService
+	data *Data
+}
+
+func (s *faultReleaseService) Create(ctx context.Context, p1 string, p2 *scm.ReleaseInput) (r0 *scm.Release, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseCreate, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReleaseService) Delete(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseDelete, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Delete(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReleaseService) DeleteByTag(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseDeleteByTag, p1, p1, p2)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteByTag(ctx, p1, p2)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReleaseService) Find(ctx context.Context, p1 string, p2 int) (r0 *scm.Release, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseFind, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReleaseService) FindByTag(ctx context.Context, p1 string, p2 string) (r0 *scm.Release, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseFindByTag, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindByTag(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReleaseService) List(ctx context.Context, p1 string, p2 scm.ReleaseListOptions) (r0 []*scm.Release, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseList, p1, p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReleaseService) Update(ctx context.Context, p1 string, p2 int, p3 *scm.ReleaseInput) (r0 *scm.Release, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseUpdate, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Update(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReleaseService) UpdateByTag(ctx context.Context, p1 string, p2 string, p3 *scm.ReleaseInput) (r0 *scm.Release, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReleaseUpdateByTag, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.UpdateByTag(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+type faultReviewService struct {
+	next scm.ReviewService
+	data *Data
+}
+
+func (s *faultReviewService) Create(ctx context.Context, p1 string, p2 int, p3 *scm.ReviewInput) (r0 *scm.Review, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewCreate, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Create(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReviewService) Delete(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewDelete, p1, p1, p2, p3)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.Delete(ctx, p1, p2, p3)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReviewService) Dismiss(ctx context.Context, p1 string, p2 int, p3 int, p4 string) (r0 *scm.Review, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewDismiss, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Dismiss(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReviewService) Find(ctx context.Context, p1 string, p2 int, p3 int) (r0 *scm.Review, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewFind, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReviewService) List(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Review, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewList, p1, p1, p2, p3)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.List(ctx, p1, p2, p3)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReviewService) ListComments(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.ListOptions) (r0 []*scm.ReviewComment, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewListComments, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListComments(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReviewService) Submit(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.ReviewSubmitInput) (r0 *scm.Review, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewSubmit, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Submit(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultReviewService) Update(ctx context.Context, p1 string, p2 int, p3 int, p4 string) (r0 *scm.Review, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapReviewUpdate, p1, p1, p2, p3, p4)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Update(ctx, p1, p2, p3, p4)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+type faultUserService struct {
+	next scm.UserService
+	data *Data
+}
+
+func (s *faultUserService) AcceptInvitation(ctx context.Context, p1 int64) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapUserAcceptInvitation, "", p1)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.AcceptInvitation(ctx, p1)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultUserService) CreateToken(ctx context.Context, p1 string, p2 string) (r0 *scm.UserToken, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapUserCreateToken, "", p1, p2)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.CreateToken(ctx, p1, p2)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultUserService) DeleteToken(ctx context.Context, p1 int64) (res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapUserDeleteToken, "", p1)
+	if call.intercept(&res, &err) {
+		return
+	}
+	s.data.lock()
+	res, err = s.next.DeleteToken(ctx, p1)
+	call.done(err)
+	s.data.unlock()
+	return
+}
+
+func (s *faultUserService) Find(ctx context.Context) (r0 *scm.User, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapUserFind, "")
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.Find(ctx)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultUserService) FindEmail(ctx context.Context) (r0 string, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapUserFindEmail, "")
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindEmail(ctx)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultUserService) FindLogin(ctx context.Context, p1 string) (r0 *scm.User, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapUserFindLogin, "", p1)
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.FindLogin(ctx, p1)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
+
+func (s *faultUserService) ListInvitations(ctx context.Context) (r0 []*scm.Invitation, res *scm.Response, err error) {
+	call := s.data.call(ctx, scm.CapUserListInvitations, "")
+	if call.intercept(&res, &err, &r0) {
+		return
+	}
+	s.data.lock()
+	r0, res, err = s.next.ListInvitations(ctx)
+	call.done(err, r0)
+	s.data.unlock()
+	return
+}
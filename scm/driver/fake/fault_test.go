@@ -0,0 +1,115 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaults(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "Add docs", Head: "docs", Base: "master"})
+	require.NoError(t, err)
+
+	data.Faults = append(data.Faults,
+		&fake.Fault{Op: scm.CapPullRequestMerge, Status: http.StatusConflict},
+		&fake.Fault{Op: "Issues", Repo: repo, Call: 2, Err: scm.ErrNotFound},
+		&fake.Fault{Op: scm.CapRepositoryFind, Times: 1, Status: http.StatusTooManyRequests},
+	)
+
+	res, err := client.PullRequests.Merge(ctx, repo, pr.Number, &scm.PullRequestMergeOptions{})
+	assert.True(t, scm.IsConflict(err))
+	assert.Equal(t, http.StatusConflict, res.Status)
+	assert.False(t, data.PullRequests[pr.Number].Merged, "Want the failed merge not applied")
+
+	_, _, err = client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+	_, _, err = client.Issues.Find(ctx, repo, pr.Number+1)
+	assert.ErrorIs(t, err, scm.ErrNotFound, "Want the second call failed")
+	_, _, err = client.Issues.Find(ctx, repo, pr.Number+1)
+	require.NoError(t, err)
+	_, _, err = client.Issues.Create(ctx, "myorg/other", &scm.IssueInput{Title: "Elsewhere"})
+	require.NoError(t, err)
+
+	_, res, err = client.Repositories.Find(ctx, repo)
+	assert.True(t, scm.IsRateLimited(err))
+	assert.Zero(t, res.Rate.Remaining)
+	assert.NotZero(t, res.Rate.Reset)
+	_, _, err = client.Repositories.Find(ctx, repo)
+	assert.False(t, scm.IsRateLimited(err), "Want the fault applied once")
+
+	calls := data.CallsOf("Issues")
+	require.Len(t, calls, 4)
+	assert.Equal(t, scm.CapIssueFind, calls[1].Op)
+	assert.Equal(t, repo, calls[1].Repo)
+	assert.Equal(t, []interface{}{repo, pr.Number + 1}, calls[1].Args)
+	assert.Same(t, data.Faults[1], calls[1].Fault)
+	assert.Nil(t, calls[2].Fault)
+	assert.Equal(t, "myorg/other", calls[3].Repo)
+	assert.Len(t, data.Calls, 8)
+}
+
+func TestFaultLatency(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.Faults = append(data.Faults, &fake.Fault{Op: scm.CapUserFind, Latency: 50 * time.Millisecond})
+
+	start := time.Now()
+	user, _, err := client.Users.Find(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "fakeuser", user.Login)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, _, err = client.Users.Find(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, err, data.Calls[1].Err)
+}
+
+func TestFaultStale(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "Add docs", Head: "docs", Base: "master"})
+	require.NoError(t, err)
+	_, _, err = client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err)
+
+	data.Faults = append(data.Faults, &fake.Fault{Op: scm.CapPullRequestFind, Stale: true, Times: 1})
+	_, err = client.PullRequests.Merge(ctx, repo, pr.Number, &scm.PullRequestMergeOptions{})
+	require.NoError(t, err)
+
+	stale, _, err := client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err)
+	assert.False(t, stale.Merged, "Want the pull request found before the merge")
+	assert.True(t, data.Calls[len(data.Calls)-1].Stale)
+	found, _, err := client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err)
+	assert.True(t, found.Merged)
+}
+
+func TestFaultServer(t *testing.T) {
+	_, data := fake.NewDefault()
+	server := httptest.NewServer(fake.NewServer(data))
+	defer server.Close()
+	client, err := github.New(server.URL)
+	require.NoError(t, err)
+
+	data.Faults = append(data.Faults, &fake.Fault{Repo: "myorg/myrepo", Status: http.StatusServiceUnavailable})
+	_, res, err := client.Repositories.Find(context.Background(), "myorg/myrepo")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.Status)
+}
@@ -0,0 +1,222 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// NewFromFixture returns a new fake client of the data of the
+// YAML or JSON fixture, such as written by Data.Save, loaded
+// on top of the data of NewDefault.
+func NewFromFixture(path string) (*scm.Client, *Data, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	data := defaultData()
+	if err := data.decode(b); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse fixture %s", path)
+	}
+	return newClient(data), data, nil
+}
+
+// LoadData loads the data of the YAML or JSON fixture, such
+// as written by Data.Save.
+func LoadData(path string) (*Data, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ParseData(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse fixture %s", path)
+	}
+	return data, nil
+}
+
+// ParseData parses the data of the YAML or JSON fixture, on
+// top of the data of NewData. The fields of the fixture are
+// named after the fields of the Data and scm types, such as
+//
+//	PullRequests:
+//	  1:
+//	    Number: 1
+//	    Title: Add docs
+//
+// The git repositories, hook client, faults and calls of the
+// data are not part of the fixtures.
+func ParseData(b []byte) (*Data, error) {
+	data := NewData()
+	if err := data.decode(b); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Save writes the data to the fixture, in JSON if its
+// extension is .json and in YAML otherwise.
+func (d *Data) Save(path string) error {
+	dump := d.Dump
+	if filepath.Ext(path) == ".json" {
+		dump = d.DumpJSON
+	}
+	b, err := dump()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+// Dump returns the YAML fixture of the data. The fields with
+// a zero value are omitted and the keys sorted, so that the
+// fixture of the end state of a test can be compared to a
+// golden file, see AssertGolden.
+func (d *Data) Dump() ([]byte, error) {
+	v, err := d.values()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DumpJSON returns the JSON fixture of the data, see Dump.
+func (d *Data) DumpJSON() ([]byte, error) {
+	v, err := d.values()
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// values returns the generic JSON values of the data,
+// without the zero values.
+func (d *Data) values() (interface{}, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	v, _ = prune(v)
+	if v == nil {
+		v = map[string]interface{}{}
+	}
+	return v, nil
+}
+
+// prune removes the fields with a zero value of the JSON
+// value, and reports whether the value is zero.
+func prune(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if value, zero := prune(value); zero {
+				delete(v, key)
+			} else {
+				v[key] = value
+			}
+		}
+		return v, len(v) == 0
+	case []interface{}:
+		for i, value := range v {
+			v[i], _ = prune(value)
+		}
+		return v, len(v) == 0
+	case string:
+		return v, v == "" || v == zeroTime
+	case float64:
+		return v, v == 0
+	case bool:
+		return v, !v
+	}
+	return v, v == nil
+}
+
+// zeroTime the JSON encoding of the zero time.
+var zeroTime = time.Time{}.Format(time.RFC3339)
+
+// decode decodes the YAML or JSON fixture into the data,
+// rejecting the unknown fields.
+func (d *Data) decode(b []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	v, err := nodeValue(&node)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	// YAML mappings may have non string keys, such as the
+	// numbers of the pull requests, so the fixture is decoded
+	// through JSON rather than directly into the data.
+	b, err = json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	return dec.Decode(d)
+}
+
+// nodeValue returns the generic JSON value of the YAML node.
+func nodeValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return nodeValue(node.Content[0])
+	case yaml.AliasNode:
+		return nodeValue(node.Alias)
+	case yaml.MappingNode:
+		m := map[string]interface{}{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := nodeValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[node.Content[i].Value] = value
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := []interface{}{}
+		for _, child := range node.Content {
+			value, err := nodeValue(child)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, value)
+		}
+		return s, nil
+	}
+	var v interface{}
+	if err := node.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
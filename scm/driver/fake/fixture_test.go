@@ -0,0 +1,67 @@
+package fake_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixture(t *testing.T) {
+	client, data, err := fake.NewFromFixture("testdata/fixtures/review.yaml")
+	require.NoError(t, err)
+	data.Clock = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	pr, _, err := client.PullRequests.Find(ctx, repo, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", pr.Author.Login)
+	assert.Equal(t, repo, pr.Base.Repo.FullName)
+	release, _, err := client.Releases.FindByTag(ctx, repo, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", release.Title)
+
+	_, _, err = client.PullRequests.CreateComment(ctx, repo, pr.Number, &scm.CommentInput{Body: "/lgtm"})
+	require.NoError(t, err)
+	_, err = client.PullRequests.AddLabel(ctx, repo, pr.Number, "lgtm")
+	require.NoError(t, err)
+	_, _, err = client.Repositories.CreateStatus(ctx, repo, pr.Sha, &scm.StatusInput{State: scm.StateSuccess, Label: "ci"})
+	require.NoError(t, err)
+	_, err = client.PullRequests.Merge(ctx, repo, pr.Number, &scm.PullRequestMergeOptions{})
+	require.NoError(t, err)
+	_, _, err = client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Release v1.1.0"})
+	require.NoError(t, err)
+
+	fake.AssertGolden(t, data, "testdata/fixtures/review.golden.yaml")
+}
+
+func TestFixtureRoundTrip(t *testing.T) {
+	_, data, err := fake.NewFromFixture("testdata/fixtures/review.yaml")
+	require.NoError(t, err)
+	want, err := data.Dump()
+	require.NoError(t, err)
+
+	for _, name := range []string{"data.yaml", "data.json"} {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, data.Save(path))
+		loaded, err := fake.LoadData(path)
+		require.NoError(t, err)
+		got, err := loaded.Dump()
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "Want the data of %s unchanged", name)
+	}
+}
+
+func TestFixtureUnknownField(t *testing.T) {
+	_, err := fake.ParseData([]byte("PullRequest:\n  1:\n    Title: typo\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PullRequest")
+}
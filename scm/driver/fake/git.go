@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
 )
 
 type gitService struct {
@@ -15,11 +19,37 @@ type gitService struct {
 
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
 	f := s.data
-	return f.TestRef, nil, nil
+	r := f.GitRepository(repo)
+	if r == nil {
+		return f.TestRef, nil, nil
+	}
+	commit, err := resolveCommit(r, ref)
+	if err != nil {
+		return "", notFound(), err
+	}
+	return commit.Hash.String(), nil, nil
 }
 
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	name := plumbing.ReferenceName(scm.ExpandRef(ref, "refs/heads"))
+	if _, err := r.Reference(name, false); err == nil {
+		return nil, &scm.Response{Status: 422}, errors.Errorf("reference %s already exists", name)
+	}
+	commit, err := resolveCommit(r, sha)
+	if err != nil {
+		return nil, &scm.Response{Status: 422}, errors.Wrapf(err, "commit %s", sha)
+	}
+	created := plumbing.NewHashReference(name, commit.Hash)
+	if err := r.Storer.SetReference(created); err != nil {
+		return nil, nil, err
+	}
+	answer := convertRef(r, created)
+	s.data.emitRef(repo, scm.ActionCreate, answer)
+	return answer, nil, nil
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
@@ -31,42 +61,196 @@ func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Resp
 	org := paths[0]
 	name := paths[1]
 	f.RefsDeleted = append(f.RefsDeleted, DeletedRef{Org: org, Repo: name, Ref: ref})
+	if r := f.GitRepository(repo); r != nil {
+		refName := plumbing.ReferenceName(scm.ExpandRef(ref, "refs/heads"))
+		deleted, err := r.Reference(refName, false)
+		if err != nil {
+			return notFound(), scm.ErrNotFound
+		}
+		answer := convertRef(r, deleted)
+		if err := r.Storer.RemoveReference(refName); err != nil {
+			return nil, err
+		}
+		f.emitRef(repo, scm.ActionDelete, answer)
+	}
 	return nil, nil
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	return s.findRef(repo, plumbing.NewBranchReferenceName(name))
 }
 
 func (s *gitService) FindCommit(ctx context.Context, repo, sha string) (*scm.Commit, *scm.Response, error) {
 	f := s.data
-	return f.Commits[sha], nil, nil
+	if commit, ok := f.Commits[sha]; ok {
+		return commit, nil, nil
+	}
+	r := f.GitRepository(repo)
+	if r == nil {
+		return nil, nil, nil
+	}
+	commit, err := resolveCommit(r, sha)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	return convertCommit(repo, commit), nil, nil
 }
 
 func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	return s.findRef(repo, plumbing.NewTagReferenceName(name))
 }
 
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts *scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	iter, err := r.Branches()
+	if err != nil {
+		return nil, nil, err
+	}
+	refs, err := listRefs(r, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(refs, opts), nil, nil
 }
 
 func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
-	panic("implement me")
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	rev := opts.Sha
+	if rev == "" {
+		rev = opts.Ref
+	}
+	from, err := resolveCommit(r, rev)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	logOpts := &git.LogOptions{From: from.Hash}
+	if opts.Path != "" {
+		prefix := strings.Join(splitPath(opts.Path), "/")
+		logOpts.PathFilter = func(p string) bool {
+			return p == prefix || strings.HasPrefix(p, prefix+"/")
+		}
+	}
+	iter, err := r.Log(logOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var commits []*scm.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, convertCommit(repo, c))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(commits, &scm.ListOptions{Page: opts.Page, Size: opts.Size}), nil, nil
 }
 
 func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	panic("implement me")
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	commit, err := resolveCommit(r, ref)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	var parent *object.Commit
+	if commit.NumParents() > 0 {
+		if parent, err = commit.Parent(0); err != nil {
+			return nil, nil, err
+		}
+	}
+	changes, err := diffCommits(ctx, parent, commit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(changes, opts), nil, nil
 }
 
 func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	panic("implement me")
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	base, err := resolveCommit(r, ref1)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	head, err := resolveCommit(r, ref2)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	// like the git servers, compare the head with its merge
+	// base rather than the base itself.
+	bases, err := base.MergeBase(head)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bases) > 0 {
+		base = bases[0]
+	}
+	changes, err := diffCommits(ctx, base, head)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(changes, opts), nil, nil
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, opts *scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	iter, err := r.Tags()
+	if err != nil {
+		return nil, nil, err
+	}
+	refs, err := listRefs(r, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(refs, opts), nil, nil
 }
 
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	branch, err := headBranch(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.findRef(repo, plumbing.NewBranchReferenceName(branch))
+}
+
+// repository returns the git repository of the repository.
+func (s *gitService) repository(repo string) (*git.Repository, error) {
+	r := s.data.GitRepository(repo)
+	if r == nil {
+		return nil, errors.Wrapf(scm.ErrNotFound, "no git repository %s", repo)
+	}
+	return r, nil
+}
+
+func (s *gitService) findRef(repo string, name plumbing.ReferenceName) (*scm.Reference, *scm.Response, error) {
+	r, err := s.repository(repo)
+	if err != nil {
+		return nil, notFound(), err
+	}
+	ref, err := r.Reference(name, true)
+	if err != nil {
+		return nil, notFound(), scm.ErrNotFound
+	}
+	return convertRef(r, ref), nil, nil
+}
+
+func notFound() *scm.Response {
+	return &scm.Response{Status: 404}
 }
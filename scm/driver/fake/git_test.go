@@ -0,0 +1,141 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitRepository(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "myrepo"})
+	require.NoError(t, err)
+	require.NotNil(t, data.GitRepository(repo))
+
+	_, err = client.Contents.Create(ctx, repo, "README.md", &scm.ContentParams{Message: "Add README", Data: []byte("hello\n")})
+	require.NoError(t, err)
+	_, err = client.Contents.Create(ctx, repo, "README.md", &scm.ContentParams{Data: []byte("again\n")})
+	assert.Error(t, err, "Want an error creating an existing file")
+	_, err = client.Contents.Create(ctx, repo, "docs/guide.md", &scm.ContentParams{Data: []byte("guide\n")})
+	require.NoError(t, err)
+
+	content, _, err := client.Contents.Find(ctx, repo, "README.md", "master")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(content.Data))
+
+	_, err = client.Contents.Update(ctx, repo, "README.md", &scm.ContentParams{Data: []byte("stale\n"), Sha: "abc"})
+	assert.Error(t, err, "Want an error updating a file with a stale sha")
+
+	files, _, err := client.Contents.List(ctx, repo, "", "master", &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "README.md", files[0].Name)
+	assert.Equal(t, "file", files[0].Type)
+	assert.Equal(t, 6, files[0].Size)
+	assert.Equal(t, "docs", files[1].Name)
+	assert.Equal(t, "dir", files[1].Type)
+
+	master, _, err := client.Git.FindBranch(ctx, repo, "master")
+	require.NoError(t, err)
+	sha, _, err := client.Git.FindRef(ctx, repo, "heads/master")
+	require.NoError(t, err)
+	assert.Equal(t, master.Sha, sha)
+
+	commits, _, err := client.Git.ListCommits(ctx, repo, scm.CommitListOptions{Ref: "master"})
+	require.NoError(t, err)
+	require.Len(t, commits, 3)
+	assert.Equal(t, master.Sha, commits[0].Sha)
+	assert.Equal(t, "Add README", commits[1].Message)
+	assert.Equal(t, "fakeuser", commits[1].Author.Name)
+
+	commits, _, err = client.Git.ListCommits(ctx, repo, scm.CommitListOptions{Path: "docs"})
+	require.NoError(t, err)
+	assert.Len(t, commits, 1)
+
+	commit, _, err := client.Git.FindCommit(ctx, repo, master.Sha)
+	require.NoError(t, err)
+	assert.Equal(t, master.Sha, commit.Sha)
+
+	// branch off and change the README.
+	_, _, err = client.Git.CreateRef(ctx, repo, "feature", master.Sha)
+	require.NoError(t, err)
+	_, _, err = client.Git.CreateRef(ctx, repo, "feature", master.Sha)
+	assert.Error(t, err, "Want an error creating an existing ref")
+	_, err = client.Contents.Update(ctx, repo, "README.md", &scm.ContentParams{Branch: "feature", Data: []byte("hello\nworld\n"), Sha: content.Sha})
+	require.NoError(t, err)
+
+	changes, _, err := client.Git.CompareCommits(ctx, repo, "master", "feature", &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "README.md", changes[0].Path)
+	assert.True(t, changes[0].Modified)
+	assert.Equal(t, 1, changes[0].Additions)
+	assert.Contains(t, changes[0].Patch, "+world")
+
+	changes, _, err = client.Git.ListChanges(ctx, repo, "feature", &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "README.md", changes[0].Path)
+
+	content, _, err = client.Contents.Find(ctx, repo, "README.md", "master")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(content.Data), "Want master unchanged")
+
+	_, err = client.Contents.Delete(ctx, repo, "docs/guide.md", &scm.ContentParams{})
+	require.NoError(t, err)
+	files, _, err = client.Contents.List(ctx, repo, "/", "master", &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, files, 1, "Want the emptied directory removed")
+
+	// tags
+	_, _, err = client.Git.CreateRef(ctx, repo, "refs/tags/v1.0.0", "feature")
+	require.NoError(t, err)
+	tag, _, err := client.Git.FindTag(ctx, repo, "v1.0.0")
+	require.NoError(t, err)
+	feature, _, err := client.Git.FindBranch(ctx, repo, "feature")
+	require.NoError(t, err)
+	assert.Equal(t, feature.Sha, tag.Sha)
+	tags, _, err := client.Git.ListTags(ctx, repo, &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []*scm.Reference{tag}, tags)
+
+	branches, _, err := client.Git.ListBranches(ctx, repo, &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	assert.Equal(t, "feature", branches[0].Name)
+	assert.Equal(t, "refs/heads/master", branches[1].Path)
+
+	branches, _, err = client.Git.ListBranches(ctx, repo, &scm.ListOptions{Page: 2, Size: 1})
+	require.NoError(t, err)
+	require.Len(t, branches, 1)
+	assert.Equal(t, "master", branches[0].Name)
+
+	def, _, err := client.Git.GetDefaultBranch(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, "master", def.Name)
+
+	_, err = client.Git.DeleteRef(ctx, repo, "feature")
+	require.NoError(t, err)
+	_, _, err = client.Git.FindBranch(ctx, repo, "feature")
+	assert.ErrorIs(t, err, scm.ErrNotFound)
+	assert.Len(t, data.RefsDeleted, 1)
+}
+
+func TestGitWithoutRepository(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+
+	sha, _, err := client.Git.FindRef(ctx, "myorg/myrepo", "heads/master")
+	require.NoError(t, err)
+	assert.Equal(t, data.TestRef, sha)
+
+	_, _, err = client.Git.FindBranch(ctx, "myorg/myrepo", "master")
+	assert.ErrorIs(t, err, scm.ErrNotFound)
+}
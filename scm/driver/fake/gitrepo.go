@@ -0,0 +1,397 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// defaultBranch is the default branch of the repositories
+// created by the fake driver.
+const defaultBranch = "master"
+
+// InitRepository creates the in-memory git repository of
+// the repository, with an initial empty commit on the
+// branch, which becomes its default branch. Once created,
+// the git and content services read and write the git
+// repository instead of using TestRef and ContentDir.
+func (d *Data) InitRepository(fullName, branch string) (*git.Repository, error) {
+	if d.GitRepositories == nil {
+		d.GitRepositories = map[string]*git.Repository{}
+	}
+	if _, ok := d.GitRepositories[fullName]; ok {
+		return nil, errors.Errorf("git repository %s already exists", fullName)
+	}
+	if branch == "" {
+		branch = defaultBranch
+	}
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create git repository %s", fullName)
+	}
+	head := plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch))
+	if err := repo.Storer.SetReference(head); err != nil {
+		return nil, errors.Wrapf(err, "failed to set the default branch of %s", fullName)
+	}
+	tree, err := storeTree(repo.Storer, nil)
+	if err != nil {
+		return nil, err
+	}
+	sig := d.signature(scm.Signature{})
+	if _, err := commitTree(repo, plumbing.NewBranchReferenceName(branch), tree, plumbing.ZeroHash, "Initial commit", sig); err != nil {
+		return nil, err
+	}
+	d.GitRepositories[fullName] = repo
+	return repo, nil
+}
+
+// GitRepository returns the in-memory git repository of the
+// repository, or nil if it has none.
+func (d *Data) GitRepository(fullName string) *git.Repository {
+	return d.GitRepositories[fullName]
+}
+
+// signature returns the signature, defaulting to the
+// current user.
+func (d *Data) signature(s scm.Signature) object.Signature {
+	sig := object.Signature{Name: s.Name, Email: s.Email, When: s.Date}
+	if sig.Name == "" {
+		sig.Name = d.CurrentUser.Login
+	}
+	if sig.Email == "" {
+		sig.Email = d.CurrentUser.Email
+	}
+	if sig.Email == "" {
+		sig.Email = sig.Name + "@fake.com"
+	}
+	if sig.When.IsZero() {
+		sig.When = d.now()
+	}
+	return sig
+}
+
+// resolveCommit returns the commit of the revision, which
+// may be a SHA, a branch, a tag or a reference such as
+// heads/master. An empty revision resolves to HEAD.
+func resolveCommit(repo *git.Repository, rev string) (*object.Commit, error) {
+	if rev == "" {
+		rev = string(plumbing.HEAD)
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, scm.ErrNotFound
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, scm.ErrNotFound
+	}
+	return commit, nil
+}
+
+// headBranch returns the name of the default branch.
+func headBranch(repo *git.Repository) (string, error) {
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", err
+	}
+	return head.Target().Short(), nil
+}
+
+// listRefs returns the references of the iterator sorted by
+// name, peeling annotated tags to their commit.
+func listRefs(repo *git.Repository, iter storer.ReferenceIter) ([]*scm.Reference, error) {
+	var refs []*scm.Reference
+	err := iter.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, convertRef(repo, ref))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name < refs[j].Name
+	})
+	return refs, nil
+}
+
+func convertRef(repo *git.Repository, ref *plumbing.Reference) *scm.Reference {
+	hash := ref.Hash()
+	if tag, err := repo.TagObject(hash); err == nil {
+		if commit, err := tag.Commit(); err == nil {
+			hash = commit.Hash
+		}
+	}
+	return &scm.Reference{
+		Name: ref.Name().Short(),
+		Path: ref.Name().String(),
+		Sha:  hash.String(),
+	}
+}
+
+func convertCommit(fullName string, c *object.Commit) *scm.Commit {
+	return &scm.Commit{
+		Sha:     c.Hash.String(),
+		Message: c.Message,
+		Tree: scm.CommitTree{
+			Sha: c.TreeHash.String(),
+		},
+		Author:    convertSignature(c.Author),
+		Committer: convertSignature(c.Committer),
+		Link:      fmt.Sprintf("https://fake.com/%s/commit/%s", fullName, c.Hash),
+	}
+}
+
+func convertSignature(s object.Signature) scm.Signature {
+	return scm.Signature{
+		Name:  s.Name,
+		Email: s.Email,
+		Date:  s.When,
+		Login: s.Name,
+	}
+}
+
+// diffCommits returns the changes between the trees of the
+// commits. A nil from commit compares against an empty
+// tree.
+func diffCommits(ctx context.Context, from, to *object.Commit) ([]*scm.Change, error) {
+	fromTree := &object.Tree{}
+	if from != nil {
+		var err error
+		if fromTree, err = from.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTreeWithOptions(ctx, fromTree, toTree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, err
+	}
+	var answer []*scm.Change
+	for _, change := range changes {
+		c, err := convertChange(ctx, change)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, c)
+	}
+	return answer, nil
+}
+
+func convertChange(ctx context.Context, change *object.Change) (*scm.Change, error) {
+	action, err := change.Action()
+	if err != nil {
+		return nil, err
+	}
+	patch, err := change.PatchContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c := &scm.Change{
+		Path:  change.To.Name,
+		Patch: patch.String(),
+		Sha:   change.To.TreeEntry.Hash.String(),
+	}
+	switch action {
+	case merkletrie.Insert:
+		c.Added = true
+	case merkletrie.Delete:
+		c.Path = change.From.Name
+		c.Deleted = true
+		c.Sha = change.From.TreeEntry.Hash.String()
+	case merkletrie.Modify:
+		c.Modified = true
+		if change.From.Name != change.To.Name {
+			c.Renamed = true
+			c.PreviousPath = change.From.Name
+		}
+	}
+	for _, stat := range patch.Stats() {
+		c.Additions += stat.Addition
+		c.Deletions += stat.Deletion
+	}
+	c.Changes = c.Additions + c.Deletions
+	return c, nil
+}
+
+// splitPath returns the elements of the path of a file.
+func splitPath(p string) []string {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// writeFile commits the data of the file to the branch,
+// deleting the file if data is nil, and returns the commit.
+func writeFile(repo *git.Repository, branch, file string, data []byte, message string, sig object.Signature) (*object.Commit, error) {
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return nil, errors.Wrapf(scm.ErrNotFound, "branch %s", branch)
+	}
+	parent, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := parent.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var blob *plumbing.Hash
+	if data != nil {
+		hash, err := storeBlob(repo.Storer, data)
+		if err != nil {
+			return nil, err
+		}
+		blob = &hash
+	}
+	hash, err := updateTree(repo.Storer, tree, splitPath(file), blob)
+	if err != nil {
+		return nil, err
+	}
+	return commitTree(repo, ref.Name(), hash, parent.Hash, message, sig)
+}
+
+// updateTree stores a copy of the tree with the blob at the
+// path, or without the file at the path if blob is nil,
+// and returns its hash.
+func updateTree(s storer.EncodedObjectStorer, tree *object.Tree, parts []string, blob *plumbing.Hash) (plumbing.Hash, error) {
+	if len(parts) == 0 {
+		return plumbing.ZeroHash, errors.New("missing the path of the file")
+	}
+	name := parts[0]
+	var entries []object.TreeEntry
+	var existing *object.TreeEntry
+	for i := range tree.Entries {
+		if tree.Entries[i].Name == name {
+			existing = &tree.Entries[i]
+			continue
+		}
+		entries = append(entries, tree.Entries[i])
+	}
+
+	if len(parts) == 1 {
+		if existing != nil && existing.Mode == filemode.Dir {
+			return plumbing.ZeroHash, errors.Errorf("%s is a directory", name)
+		}
+		if blob != nil {
+			entries = append(entries, object.TreeEntry{Name: name, Mode: filemode.Regular, Hash: *blob})
+		}
+		return storeTree(s, entries)
+	}
+
+	subtree := &object.Tree{}
+	if existing != nil {
+		if existing.Mode != filemode.Dir {
+			return plumbing.ZeroHash, errors.Errorf("%s is not a directory", name)
+		}
+		var err error
+		if subtree, err = object.GetTree(s, existing.Hash); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	hash, err := updateTree(s, subtree, parts[1:], blob)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	// git does not store empty directories.
+	if hash != emptyTreeHash {
+		entries = append(entries, object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: hash})
+	}
+	return storeTree(s, entries)
+}
+
+// emptyTreeHash is the hash of the empty tree.
+var emptyTreeHash = plumbing.NewHash("4b825dc642cb6eb9a060e54bf8d69288fbee4904")
+
+// storeTree stores the tree of the entries, sorted the way
+// git does, and returns its hash.
+func storeTree(s storer.EncodedObjectStorer, entries []object.TreeEntry) (plumbing.Hash, error) {
+	sort.Slice(entries, func(i, j int) bool {
+		return treeEntryKey(entries[i]) < treeEntryKey(entries[j])
+	})
+	tree := &object.Tree{Entries: entries}
+	obj := s.NewEncodedObject()
+	if err := tree.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
+
+// treeEntryKey returns the key git sorts tree entries by,
+// where directories sort as if their name ended with a
+// slash.
+func treeEntryKey(e object.TreeEntry) string {
+	if e.Mode == filemode.Dir {
+		return e.Name + "/"
+	}
+	return e.Name
+}
+
+func storeBlob(s storer.EncodedObjectStorer, data []byte) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	w, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := w.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
+
+// commitTree stores a commit of the tree and points the
+// reference to it.
+func commitTree(repo *git.Repository, ref plumbing.ReferenceName, tree, parent plumbing.Hash, message string, sig object.Signature) (*object.Commit, error) {
+	commit := &object.Commit{
+		Author:    sig,
+		Committer: sig,
+		Message:   message,
+		TreeHash:  tree,
+	}
+	if !parent.IsZero() {
+		commit.ParentHashes = []plumbing.Hash{parent}
+	}
+	obj := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return nil, err
+	}
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(ref, hash)); err != nil {
+		return nil, err
+	}
+	return repo.CommitObject(hash)
+}
+
+// readBlob returns the content of the file.
+func readBlob(f *object.File) ([]byte, error) {
+	r, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
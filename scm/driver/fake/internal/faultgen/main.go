@@ -0,0 +1,241 @@
+// Command faultgen generates the decorators of the services
+// of the fake driver that inject the faults of the data,
+// record the calls and lock the data during the calls, see
+// fake.Fault.
+//
+// It is run by go generate from the directory of the fake
+// driver.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// services the services of the client implemented by the
+// fake driver, except the webhooks which make no calls.
+var services = []string{
+	"Contents",
+	"Deployments",
+	"Git",
+	"Issues",
+	"Organizations",
+	"PullRequests",
+	"Repositories",
+	"Releases",
+	"Reviews",
+	"Users",
+}
+
+// unscoped the services whose operations do not take the
+// full name of a repository.
+var unscoped = map[string]bool{
+	"Organizations": true,
+	"Users":         true,
+}
+
+// unscopedOps the operations of the other services whose
+// first string argument is not the full name of a
+// repository.
+var unscopedOps = map[string]bool{
+	"Repositories.ListOrganisation": true,
+	"Repositories.ListUser":         true,
+}
+
+func main() {
+	dir := flag.String("scm", "../..", "the directory of the scm package")
+	out := flag.String("o", "fault_gen.go", "the generated file")
+	flag.Parse()
+
+	pkg, consts, err := load(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkg, consts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load type checks the scm package and returns it with the
+// names of the capability constants keyed by their value.
+func load(dir string) (*types.Package, map[string]string, error) {
+	fset := token.NewFileSet()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var files []*ast.File
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, match, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("github.com/jenkins-x/go-scm/scm", fset, files, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	consts := map[string]string{}
+	capability := pkg.Scope().Lookup("Capability").Type()
+	for _, name := range pkg.Scope().Names() {
+		c, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if ok && c.Exported() && types.Identical(c.Type(), capability) {
+			consts[strings.Trim(c.Val().ExactString(), `"`)] = name
+		}
+	}
+	return pkg, consts, nil
+}
+
+// generate returns the source of the decorators of the
+// services.
+func generate(pkg *types.Package, consts map[string]string) ([]byte, error) {
+	client, ok := pkg.Scope().Lookup("Client").Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("scm.Client is not a struct")
+	}
+	fields := map[string]*types.Var{}
+	for i := 0; i < client.NumFields(); i++ {
+		fields[client.Field(i).Name()] = client.Field(i)
+	}
+
+	imports := map[string]string{"context": "context"}
+	qualifier := func(p *types.Package) string {
+		imports[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// injectFaults decorates the services of the client with the\n")
+	fmt.Fprintf(&body, "// faults and the lock of the data.\n")
+	fmt.Fprintf(&body, "func injectFaults(client *scm.Client, data *Data) {\n")
+	for _, service := range services {
+		named := fields[service].Type().(*types.Named)
+		fmt.Fprintf(&body, "\tclient.%s = &fault%s{next: client.%s, data: data}\n", service, named.Obj().Name(), service)
+	}
+	fmt.Fprintf(&body, "}\n")
+
+	for _, service := range services {
+		field, ok := fields[service]
+		if !ok {
+			return nil, fmt.Errorf("scm.Client has no %s service", service)
+		}
+		named := field.Type().(*types.Named)
+		iface := named.Underlying().(*types.Interface)
+		typ := "fault" + named.Obj().Name()
+
+		fmt.Fprintf(&body, "\ntype %s struct {\n", typ)
+		fmt.Fprintf(&body, "\tnext %s\n", types.TypeString(named, qualifier))
+		fmt.Fprintf(&body, "\tdata *Data\n")
+		fmt.Fprintf(&body, "}\n")
+
+		for i := 0; i < iface.NumMethods(); i++ {
+			method := iface.Method(i)
+			op := service + "." + method.Name()
+			name, ok := consts[op]
+			if !ok {
+				return nil, fmt.Errorf("no capability for %s", op)
+			}
+			if err := writeMethod(&body, typ, method, name, !unscoped[service] && !unscopedOps[op], qualifier); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by faultgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package fake\n\n")
+	fmt.Fprintf(&src, "import (\n")
+	var paths []string
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for i, path := range paths {
+		if i > 0 && !strings.Contains(paths[i-1], ".") && strings.Contains(path, ".") {
+			fmt.Fprintf(&src, "\n")
+		}
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	fmt.Fprintf(&src, ")\n\n")
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// writeMethod writes the method of the decorator, which
+// passes the call to the next service, holding the lock of
+// the data, unless a fault of the data intercepts it.
+func writeMethod(w *bytes.Buffer, typ string, method *types.Func, op string, scoped bool, qualifier types.Qualifier) error {
+	sig := method.Type().(*types.Signature)
+	params, results := sig.Params(), sig.Results()
+	if params.Len() == 0 || types.TypeString(params.At(0).Type(), nil) != "context.Context" {
+		return fmt.Errorf("want a context.Context first parameter")
+	}
+	n := results.Len()
+	if n < 2 || types.TypeString(results.At(n-2).Type(), nil) != "*github.com/jenkins-x/go-scm/scm.Response" ||
+		types.TypeString(results.At(n-1).Type(), nil) != "error" {
+		return fmt.Errorf("want *scm.Response and error last results")
+	}
+
+	var decls, args, logged []string
+	repo := `""`
+	for i := 1; i < params.Len(); i++ {
+		name := fmt.Sprintf("p%d", i)
+		t := params.At(i).Type()
+		if sig.Variadic() && i == params.Len()-1 {
+			decls = append(decls, name+" ..."+types.TypeString(t.(*types.Slice).Elem(), qualifier))
+			args = append(args, name+"...")
+		} else {
+			decls = append(decls, name+" "+types.TypeString(t, qualifier))
+			args = append(args, name)
+		}
+		logged = append(logged, name)
+		if scoped && repo == `""` && types.Identical(t, types.Typ[types.String]) {
+			repo = name
+		}
+	}
+	var outs, values, pointers []string
+	for i := 0; i < n-2; i++ {
+		name := fmt.Sprintf("r%d", i)
+		outs = append(outs, name+" "+types.TypeString(results.At(i).Type(), qualifier))
+		values = append(values, name)
+		pointers = append(pointers, "&"+name)
+	}
+	outs = append(outs, "res *scm.Response", "err error")
+
+	call := strings.Join(append([]string{"ctx"}, args...), ", ")
+	record := strings.Join(append([]string{"ctx", "scm." + op, repo}, logged...), ", ")
+	fmt.Fprintf(w, "\nfunc (s *%s) %s(%s) (%s) {\n", typ, method.Name(), strings.Join(append([]string{"ctx context.Context"}, decls...), ", "), strings.Join(outs, ", "))
+	fmt.Fprintf(w, "\tcall := s.data.call(%s)\n", record)
+	fmt.Fprintf(w, "\tif call.intercept(%s) {\n", strings.Join(append([]string{"&res", "&err"}, pointers...), ", "))
+	fmt.Fprintf(w, "\t\treturn\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\ts.data.lock()\n")
+	fmt.Fprintf(w, "\t%s = s.next.%s(%s)\n", strings.Join(append(values, "res", "err"), ", "), method.Name(), call)
+	fmt.Fprintf(w, "\tcall.done(%s)\n", strings.Join(append([]string{"err"}, values...), ", "))
+	fmt.Fprintf(w, "\ts.data.unlock()\n")
+	fmt.Fprintf(w, "\treturn\n")
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -27,15 +29,7 @@ func (s *issueService) ListEvents(ctx context.Context, repo string, number int,
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
-	f := s.data
-	for _, slice := range f.Issues {
-		for _, issue := range slice {
-			if issue.Number == number {
-				return issue, nil, nil
-			}
-		}
-	}
-	return nil, nil, nil
+	return s.data.findIssue(number), nil, nil
 }
 
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
@@ -62,11 +56,13 @@ func (s *issueService) AddLabel(ctx context.Context, repo string, number int, la
 	}
 	if f.RepoLabelsExisting == nil {
 		f.IssueLabelsAdded = append(f.IssueLabelsAdded, labelString)
+		s.emitLabel(repo, number, scm.ActionLabel)
 		return nil, nil
 	}
 	for _, l := range f.RepoLabelsExisting {
 		if label == l {
 			f.IssueLabelsAdded = append(f.IssueLabelsAdded, labelString)
+			s.emitLabel(repo, number, scm.ActionLabel)
 			return nil, nil
 		}
 	}
@@ -79,11 +75,19 @@ func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int,
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
 	if !sets.NewString(f.IssueLabelsRemoved...).Has(labelString) {
 		f.IssueLabelsRemoved = append(f.IssueLabelsRemoved, labelString)
+		s.emitLabel(repo, number, scm.ActionUnlabel)
 		return nil, nil
 	}
 	return nil, fmt.Errorf("cannot remove %v from %s/#%d", label, repo, number)
 }
 
+// emitLabel emits the labeling of the issue, if it exists.
+func (s *issueService) emitLabel(repo string, number int, action scm.Action) {
+	if issue := s.data.findIssue(number); issue != nil {
+		s.data.emitIssue(repo, action, issue)
+	}
+}
+
 // FindIssues returns f.Issues
 func (s *issueService) FindIssues(query, sort string, asc bool) ([]scm.Issue, error) {
 	f := s.data
@@ -114,15 +118,47 @@ func (s *issueService) AssignIssue(ctx context.Context, repo string, number int,
 }
 
 func (s *issueService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	panic("implement me")
+	s.data.unassign(repo, number, logins)
+	return nil, nil
 }
 
-func (s *issueService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
-	panic("implement me")
+// unassign removes the assignees added to the issue or pull
+// request.
+func (d *Data) unassign(repo string, number int, logins []string) {
+	removed := sets.NewString()
+	for _, login := range logins {
+		removed.Insert(fmt.Sprintf("%s#%d:%s", repo, number, login))
+	}
+	assignees := []string{}
+	for _, a := range d.AssigneesAdded {
+		if !removed.Has(a) {
+			assignees = append(assignees, a)
+		}
+	}
+	d.AssigneesAdded = assignees
 }
 
-func (s *issueService) List(context.Context, string, scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
-	panic("implement me")
+func (s *issueService) FindComment(_ context.Context, repo string, number, id int) (*scm.Comment, *scm.Response, error) {
+	return findComment(s.data.IssueComments[number], repo, number, id)
+}
+
+func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
+	f := s.data
+	keys := make([]int, 0, len(f.Issues))
+	for number := range f.Issues {
+		keys = append(keys, number)
+	}
+	sort.Ints(keys)
+	issues := []*scm.Issue{}
+	for _, number := range keys {
+		for _, issue := range f.Issues[number] {
+			if (opts.Open && !issue.Closed) || (opts.Closed && issue.Closed) {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	start, end := paginated(opts.Page, opts.Size, len(issues))
+	return issues[start:end], nil, nil
 }
 
 func (s *issueService) ListComments(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
@@ -130,8 +166,33 @@ func (s *issueService) ListComments(ctx context.Context, repo string, number int
 	return append([]*scm.Comment{}, f.IssueComments[number]...), nil, nil
 }
 
-func (s *issueService) Create(context.Context, string, *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	panic("implement me")
+// Create creates an issue. Like on GitHub, issues and pull
+// requests share their numbers.
+func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	f := s.data
+	number := f.PullRequestID
+	for n := range f.Issues {
+		number = max(number, n)
+	}
+	for n := range f.PullRequests {
+		number = max(number, n)
+	}
+	number++
+	f.PullRequestID = number
+	now := f.now()
+	answer := &scm.Issue{
+		Number:  number,
+		Title:   input.Title,
+		Body:    input.Body,
+		Link:    fmt.Sprintf("https://fake.com/%s/issues/%d", repo, number),
+		State:   "open",
+		Author:  f.CurrentUser,
+		Created: now,
+		Updated: now,
+	}
+	f.Issues[number] = append(f.Issues[number], answer)
+	f.emitIssue(repo, scm.ActionOpen, answer)
+	return answer, nil, nil
 }
 
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, comment *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
@@ -144,6 +205,7 @@ func (s *issueService) CreateComment(ctx context.Context, repo string, number in
 	}
 	f.IssueComments[number] = append(f.IssueComments[number], answer)
 	f.IssueCommentID++
+	f.emitComment(repo, number, scm.ActionCreate, answer)
 	return answer, nil, nil
 }
 
@@ -154,6 +216,7 @@ func (s *issueService) DeleteComment(ctx context.Context, repo string, number, i
 		for i, ic := range ics {
 			if ic.ID == id {
 				f.IssueComments[num] = append(ics[:i], ics[i+1:]...)
+				f.emitComment(repo, num, scm.ActionDelete, ic)
 				return nil, nil
 			}
 		}
@@ -165,20 +228,58 @@ func (s *issueService) EditComment(ctx context.Context, repo string, number, id
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *issueService) Close(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setState(repo, number, "closed")
+}
+
+func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setState(repo, number, "open")
 }
 
-func (s *issueService) Reopen(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) setState(repo string, number int, state string) (*scm.Response, error) {
+	issue, _, _ := s.Find(context.Background(), repo, number)
+	if issue == nil {
+		return &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "issue %s#%d", repo, number)
+	}
+	issue.State = state
+	issue.Closed = state == "closed"
+	issue.Updated = s.data.now()
+	action := scm.ActionReopen
+	if issue.Closed {
+		action = scm.ActionClose
+	}
+	s.data.emitIssue(repo, action, issue)
+	return nil, nil
 }
 
-func (s *issueService) Lock(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Lock(_ context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setLocked(repo, number, true)
 }
 
-func (s *issueService) Unlock(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Unlock(_ context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setLocked(repo, number, false)
+}
+
+// setLocked locks or unlocks the conversation of the issue.
+func (s *issueService) setLocked(repo string, number int, locked bool) (*scm.Response, error) {
+	issue := s.data.findIssue(number)
+	if issue == nil {
+		return &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "issue %s#%d", repo, number)
+	}
+	issue.Locked = locked
+	issue.Updated = s.data.now()
+	return nil, nil
+}
+
+// findComment returns the comment of the issue or pull
+// request with the id.
+func findComment(comments []*scm.Comment, repo string, number, id int) (*scm.Comment, *scm.Response, error) {
+	for _, comment := range comments {
+		if comment.ID == id {
+			return comment, nil, nil
+		}
+	}
+	return nil, &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "comment %d of %s#%d", id, repo, number)
 }
 
 func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID, number int) (*scm.Response, error) {
@@ -0,0 +1,41 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueCommentsLockAssignees(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	repo := "myorg/myrepo"
+
+	issue, _, err := client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+	comment, _, err := client.Issues.CreateComment(ctx, repo, issue.Number, &scm.CommentInput{Body: "/assign"})
+	require.NoError(t, err)
+
+	found, _, err := client.Issues.FindComment(ctx, repo, issue.Number, comment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "/assign", found.Body)
+	_, _, err = client.Issues.FindComment(ctx, repo, issue.Number, comment.ID+1)
+	assert.ErrorIs(t, err, scm.ErrNotFound)
+
+	_, err = client.Issues.Lock(ctx, repo, issue.Number)
+	require.NoError(t, err)
+	assert.True(t, issue.Locked)
+	_, err = client.Issues.Unlock(ctx, repo, issue.Number)
+	require.NoError(t, err)
+	assert.False(t, issue.Locked)
+
+	_, err = client.Issues.AssignIssue(ctx, repo, issue.Number, []string{"alice", "bob"})
+	require.NoError(t, err)
+	_, err = client.Issues.UnassignIssue(ctx, repo, issue.Number, []string{"alice"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"myorg/myrepo#1:bob"}, data.AssigneesAdded)
+}
@@ -27,8 +27,19 @@ type organizationService struct {
 	data   *Data
 }
 
-func (s *organizationService) Create(context.Context, *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
-	panic("implement me")
+func (s *organizationService) Create(_ context.Context, input *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
+	for _, org := range s.data.Organizations {
+		if org.Name == input.Name {
+			return nil, &scm.Response{Status: 422}, fmt.Errorf("organization %s already exists", input.Name)
+		}
+	}
+	org := &scm.Organization{
+		ID:     len(s.data.Organizations) + 1,
+		Name:   input.Name,
+		Avatar: fmt.Sprintf("https://github.com/%s.png", input.Name),
+	}
+	s.data.Organizations = append(s.data.Organizations, org)
+	return org, &scm.Response{Status: 201}, nil
 }
 
 func (s *organizationService) Delete(context.Context, string) (*scm.Response, error) {
@@ -36,7 +47,12 @@ func (s *organizationService) Delete(context.Context, string) (*scm.Response, er
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org, user string) (bool, *scm.Response, error) {
-	panic("implement me")
+	for _, member := range s.data.OrgMembers[org] {
+		if NormLogin(member) == NormLogin(user) {
+			return true, nil, nil
+		}
+	}
+	return false, nil, nil
 }
 
 func (s *organizationService) IsAdmin(ctx context.Context, org, user string) (bool, *scm.Response, error) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -20,13 +21,13 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 	f := s.data
 	val, exists := f.PullRequests[number]
 	if !exists {
-		return nil, nil, fmt.Errorf("pull request number %d does not exit", number)
+		return nil, &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "pull request number %d does not exit", number)
 	}
 	return val, nil, nil
 }
 
-func (s *pullService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
-	panic("implement me")
+func (s *pullService) FindComment(_ context.Context, repo string, number, id int) (*scm.Comment, *scm.Response, error) {
+	return findComment(s.data.PullRequestComments[number], repo, number, id)
 }
 
 func (s *pullService) List(ctx context.Context, fullName string, opts *scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
@@ -192,11 +193,13 @@ func (s *pullService) AddLabel(ctx context.Context, repo string, number int, lab
 	}
 	if f.RepoLabelsExisting == nil {
 		f.PullRequestLabelsAdded = append(f.PullRequestLabelsAdded, labelString)
+		s.emitLabel(repo, number, scm.ActionLabel, label)
 		return nil, nil
 	}
 	for _, l := range f.RepoLabelsExisting {
 		if label == l {
 			f.PullRequestLabelsAdded = append(f.PullRequestLabelsAdded, labelString)
+			s.emitLabel(repo, number, scm.ActionLabel, label)
 			return nil, nil
 		}
 	}
@@ -209,11 +212,20 @@ func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int,
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
 	if !sets.NewString(f.PullRequestLabelsRemoved...).Has(labelString) {
 		f.PullRequestLabelsRemoved = append(f.PullRequestLabelsRemoved, labelString)
+		s.emitLabel(repo, number, scm.ActionUnlabel, label)
 		return nil, nil
 	}
 	return nil, fmt.Errorf("cannot remove %v from %s/#%d", label, repo, number)
 }
 
+// emitLabel emits the labeling of the pull request, if it
+// exists.
+func (s *pullService) emitLabel(repo string, number int, action scm.Action, label string) {
+	if pr := s.data.PullRequests[number]; pr != nil {
+		s.data.emitPullRequest(repo, action, pr, &scm.Label{Name: label})
+	}
+}
+
 func (s *pullService) Merge(ctx context.Context, repo string, number int, mergeOpts *scm.PullRequestMergeOptions) (*scm.Response, error) {
 	pr, ok := s.data.PullRequests[number]
 	if !ok || pr == nil {
@@ -223,6 +235,8 @@ func (s *pullService) Merge(ctx context.Context, repo string, number int, mergeO
 	pr.State = "closed"
 	pr.Closed = true
 	pr.Mergeable = false
+	// like GitHub, a merged pull request is closed.
+	s.data.emitPullRequest(repo, scm.ActionClose, pr, nil)
 	return nil, nil
 }
 
@@ -253,6 +267,7 @@ func (s *pullService) Update(_ context.Context, fullName string, number int, inp
 	}
 	f.PullRequestsCreated[number] = input
 	f.PullRequests[number] = answer
+	f.emitPullRequest(fullName, scm.ActionEdited, answer, nil)
 	return answer, nil, nil
 }
 
@@ -263,11 +278,22 @@ func (s *pullService) Close(_ context.Context, fullName string, number int) (*sc
 	}
 	pr.State = "closed"
 	pr.Closed = true
+	s.data.emitPullRequest(fullName, scm.ActionClose, pr, nil)
 	return nil, nil
 }
 
-func (s *pullService) Reopen(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *pullService) Reopen(_ context.Context, fullName string, number int) (*scm.Response, error) {
+	pr, ok := s.data.PullRequests[number]
+	if !ok || pr == nil {
+		return &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "pull request %d", number)
+	}
+	if pr.Merged {
+		return &scm.Response{Status: 422}, fmt.Errorf("pull request %d is merged", number)
+	}
+	pr.State = "open"
+	pr.Closed = false
+	s.data.emitPullRequest(fullName, scm.ActionReopen, pr, nil)
+	return nil, nil
 }
 
 func (s *pullService) CreateComment(ctx context.Context, repo string, number int, comment *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
@@ -280,6 +306,7 @@ func (s *pullService) CreateComment(ctx context.Context, repo string, number int
 	}
 	f.PullRequestComments[number] = append(f.PullRequestComments[number], answer)
 	f.IssueCommentID++
+	f.emitComment(repo, number, scm.ActionCreate, answer)
 	return answer, nil, nil
 }
 
@@ -290,6 +317,7 @@ func (s *pullService) DeleteComment(ctx context.Context, repo string, number, id
 		for i, ic := range ics {
 			if ic.ID == id {
 				f.PullRequestComments[num] = append(ics[:i], ics[i+1:]...)
+				f.emitComment(repo, num, scm.ActionDelete, ic)
 				return nil, nil
 			}
 		}
@@ -318,7 +346,8 @@ func (s *pullService) AssignIssue(ctx context.Context, repo string, number int,
 }
 
 func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	panic("implement me")
+	s.data.unassign(repo, number, logins)
+	return nil, nil
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -366,6 +395,7 @@ func (s *pullService) Create(_ context.Context, fullName string, input *scm.Pull
 	}
 	f.PullRequestsCreated[f.PullRequestID] = input
 	f.PullRequests[f.PullRequestID] = answer
+	f.emitPullRequest(fullName, scm.ActionOpen, answer, nil)
 	return answer, nil, nil
 }
 
@@ -3,7 +3,6 @@ package fake
 import (
 	"context"
 	"strconv"
-	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -56,7 +55,7 @@ func (r *releaseService) List(_ context.Context, repo string, options scm.Releas
 func (r *releaseService) Create(_ context.Context, repo string, input *scm.ReleaseInput) (*scm.Release, *scm.Response, error) {
 	m := r.releaseMap(repo)
 	id := len(m)
-	now := time.Now()
+	now := r.data.now()
 	release := &scm.Release{
 		ID:          id,
 		Title:       input.Title,
@@ -70,6 +69,7 @@ func (r *releaseService) Create(_ context.Context, repo string, input *scm.Relea
 		Published:   now,
 	}
 	m[id] = release
+	r.emit(repo, scm.ActionCreate, release)
 	return release, nil, nil
 }
 
@@ -92,6 +92,7 @@ func (r *releaseService) Update(_ context.Context, repo string, number int, inpu
 	}
 	rel.Draft = input.Draft
 	rel.Prerelease = input.Prerelease
+	r.emit(repo, scm.ActionUpdate, rel)
 	return nil, nil, nil
 }
 
@@ -102,7 +103,10 @@ func (r *releaseService) UpdateByTag(ctx context.Context, repo, tag string, inpu
 
 func (r *releaseService) Delete(_ context.Context, repo string, number int) (*scm.Response, error) {
 	m := r.releaseMap(repo)
-	delete(m, number)
+	if rel := m[number]; rel != nil {
+		delete(m, number)
+		r.emit(repo, scm.ActionDelete, rel)
+	}
 	return nil, nil
 }
 
@@ -110,3 +114,12 @@ func (r *releaseService) DeleteByTag(ctx context.Context, repo, tag string) (*sc
 	rel, _, _ := r.FindByTag(ctx, repo, tag)
 	return r.Delete(ctx, repo, rel.ID)
 }
+
+func (r *releaseService) emit(repo string, action scm.Action, release *scm.Release) {
+	r.data.emit(&scm.ReleaseHook{
+		Action:  action,
+		Repo:    r.data.hookRepository(repo),
+		Release: *release,
+		Sender:  r.data.CurrentUser,
+	})
+}
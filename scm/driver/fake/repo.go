@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
-	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
 )
 
 type repositoryService struct {
@@ -39,20 +39,52 @@ func (s *repositoryService) FindUserPermission(ctx context.Context, repo, user s
 // NormLogin normalizes login strings
 var NormLogin = strings.ToLower
 
-func (s *repositoryService) FindHook(context.Context, string, string) (*scm.Hook, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) FindHook(_ context.Context, fullName, hookID string) (*scm.Hook, *scm.Response, error) {
+	for _, hook := range s.data.Hooks[fullName] {
+		if hook.ID == hookID {
+			return hook, nil, nil
+		}
+	}
+	return nil, &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "hook %s of %s", hookID, fullName)
+}
+
+// FindPerms returns the permissions of the current user, see
+// UserPermissions, or of the repository if the user has none.
+func (s *repositoryService) FindPerms(ctx context.Context, fullName string) (*scm.Perm, *scm.Response, error) {
+	switch s.data.UserPermissions[fullName][s.data.CurrentUser.Login] {
+	case scm.AdminPermission:
+		return &scm.Perm{Pull: true, Push: true, Admin: true}, nil, nil
+	case scm.WritePermission:
+		return &scm.Perm{Pull: true, Push: true}, nil, nil
+	case scm.ReadPermission:
+		return &scm.Perm{Pull: true}, nil, nil
+	}
+	repo, res, err := s.Find(ctx, fullName)
+	if err != nil {
+		return nil, res, err
+	}
+	if repo.Perm == nil {
+		return &scm.Perm{}, nil, nil
+	}
+	return repo.Perm, nil, nil
 }
 
-func (s *repositoryService) FindPerms(context.Context, string) (*scm.Perm, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) ListOrganisation(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return s.listNamespace(org, opts), nil, nil
 }
 
-func (s *repositoryService) ListOrganisation(context.Context, string, *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) ListUser(ctx context.Context, user string, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return s.listNamespace(user, opts), nil, nil
 }
 
-func (s *repositoryService) ListUser(context.Context, string, *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) listNamespace(namespace string, opts *scm.ListOptions) []*scm.Repository {
+	repos := []*scm.Repository{}
+	for _, repo := range s.data.Repositories {
+		if repo.Namespace == namespace {
+			repos = append(repos, repo)
+		}
+	}
+	return paginate(repos, opts)
 }
 
 func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
@@ -93,7 +125,7 @@ func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, per
 			Invitee:     &scm.User{},
 			Inviter:     &scm.User{},
 			Permissions: permission,
-			Created:     time.Now(),
+			Created:     s.data.now(),
 		})
 	}
 
@@ -163,9 +195,20 @@ func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInp
 		FullName:  fullName,
 		Link:      link,
 		Clone:     link,
-		Created:   time.Now(),
+		Branch:    defaultBranch,
+		Created:   s.data.now(),
+	}
+	if s.data.GitRepository(fullName) == nil {
+		if _, err := s.data.InitRepository(fullName, repo.Branch); err != nil {
+			return nil, nil, err
+		}
 	}
 	s.data.Repositories = append(s.data.Repositories, repo)
+	s.data.emit(&scm.RepositoryHook{
+		Action: scm.ActionCreate,
+		Repo:   *repo,
+		Sender: s.data.CurrentUser,
+	})
 	return repo, nil, nil
 }
 
@@ -181,10 +224,18 @@ func (s *repositoryService) ListHooks(ctx context.Context, fullName string, opts
 func (s *repositoryService) CreateHook(ctx context.Context, fullName string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	hook := &scm.Hook{
 		//nolint:gosec
-		ID:     fmt.Sprintf("%d", rand.Int()),
-		Name:   input.Name,
-		Target: input.Target,
-		Active: true,
+		ID:         fmt.Sprintf("%d", rand.Int()),
+		Name:       input.Name,
+		Target:     input.Target,
+		Events:     append(hookEvents(input.Events), input.NativeEvents...),
+		Active:     true,
+		SkipVerify: input.SkipVerify,
+	}
+	if input.Secret != "" {
+		if s.data.HookSecrets == nil {
+			s.data.HookSecrets = map[string]string{}
+		}
+		s.data.HookSecrets[hook.ID] = input.Secret
 	}
 	s.data.Hooks[fullName] = append(s.data.Hooks[fullName], hook)
 	return hook, nil, nil
@@ -200,6 +251,7 @@ func (s *repositoryService) DeleteHook(ctx context.Context, fullName, hookID str
 		if h.ID == hookID {
 			hooks = append(hooks[0:i], hooks[i+1:]...)
 			s.data.Hooks[fullName] = hooks
+			delete(s.data.HookSecrets, hookID)
 			break
 		}
 	}
@@ -215,14 +267,32 @@ func (s *repositoryService) CreateStatus(ctx context.Context, repo, ref string,
 	for _, existing := range statuses {
 		if existing.Label == status.Label {
 			*existing = *status
+			s.emitStatus(repo, scm.ActionUpdate, status)
 			return status, nil, nil
 		}
 	}
 	statuses = append(statuses, status)
 	s.data.Statuses[ref] = statuses
+	s.emitStatus(repo, scm.ActionCreate, status)
 	return status, nil, nil
 }
 
-func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
-	panic("implement me")
+// emitStatus emits the status, labeled by its context.
+func (s *repositoryService) emitStatus(repo string, action scm.Action, status *scm.Status) {
+	s.data.emit(&scm.StatusHook{
+		Action: action,
+		Repo:   s.data.hookRepository(repo),
+		Sender: s.data.CurrentUser,
+		Label:  scm.Label{Name: status.Label, Description: status.Desc},
+	})
+}
+
+func (s *repositoryService) Delete(_ context.Context, fullName string) (*scm.Response, error) {
+	for i, repo := range s.data.Repositories {
+		if repo.FullName == fullName {
+			s.data.Repositories = append(s.data.Repositories[:i], s.data.Repositories[i+1:]...)
+			return &scm.Response{Status: 204}, nil
+		}
+	}
+	return &scm.Response{Status: 404}, scm.ErrNotFound
 }
@@ -45,6 +45,10 @@ func TestHookCreateDelete(t *testing.T) {
 		t.Fatalf("hook id mismatch got\n%s", diff)
 	}
 
+	found, _, err := client.Repositories.FindHook(context.Background(), "foo/repo", id)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", found.Target)
+
 	// delete by hook ID
 	_, err = client.Repositories.DeleteHook(context.Background(), "foo/repo", id)
 	if err != nil {
@@ -96,3 +100,25 @@ func TestForkRepository(t *testing.T) {
 	repository := fake.AssertRepoExists(ctx, t, client, forkFullName)
 	assert.Equal(t, expectedGitURL, repository.Clone, "forked repository clone URL")
 }
+
+func TestRepositoryPermsDelete(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	data.CurrentUser.Login = "bob"
+	data.Repositories = []*scm.Repository{{Namespace: "myorg", Name: "myrepo", FullName: "myorg/myrepo"}}
+
+	perm, _, err := client.Repositories.FindPerms(ctx, "myorg/myrepo")
+	require.NoError(t, err)
+	assert.Equal(t, &scm.Perm{}, perm)
+
+	_, _, _, err = client.Repositories.AddCollaborator(ctx, "myorg/myrepo", "bob", scm.WritePermission)
+	require.NoError(t, err)
+	perm, _, err = client.Repositories.FindPerms(ctx, "myorg/myrepo")
+	require.NoError(t, err)
+	assert.Equal(t, &scm.Perm{Pull: true, Push: true}, perm)
+
+	_, err = client.Repositories.Delete(ctx, "myorg/myrepo")
+	require.NoError(t, err)
+	_, err = client.Repositories.Delete(ctx, "myorg/myrepo")
+	assert.ErrorIs(t, err, scm.ErrNotFound)
+}
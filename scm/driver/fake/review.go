@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
 )
 
 type reviewService struct {
@@ -41,8 +42,15 @@ func (s *reviewService) Create(ctx context.Context, repo string, number int, inp
 	return review, nil, nil
 }
 
-func (s *reviewService) Delete(context.Context, string, int, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *reviewService) Delete(_ context.Context, repo string, number, id int) (*scm.Response, error) {
+	f := s.data
+	for i, review := range f.Reviews[number] {
+		if review.ID == id {
+			f.Reviews[number] = append(f.Reviews[number][:i], f.Reviews[number][i+1:]...)
+			return nil, nil
+		}
+	}
+	return &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "review %d of %s#%d", id, repo, number)
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID, reviewID int, options *scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
@@ -0,0 +1,824 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// Server serves the data of the fake driver over HTTP
+// through a subset of the GitHub REST API: repositories,
+// pull requests, issues, comments, labels, statuses, hooks,
+// contents and git references. Tools speaking HTTP, and the
+// github driver, can then run against the fake driver:
+//
+//	client, data := fake.NewDefault()
+//	server := httptest.NewServer(fake.NewServer(data))
+//	defer server.Close()
+//	gh, _ := github.New(server.URL)
+//
+// The requests go through the services of a fake client of
+// the data, so they see and make the same changes as the
+// fake clients of the data. Mount the server under a prefix
+// with http.StripPrefix to mimic GitHub Enterprise.
+//
+// The calls of the server and of the fake clients of the
+// data lock the data, and POST the webhooks they emit once
+// they release it, so that the hook targets can call them
+// back. The server and the clients can be used concurrently.
+type Server struct {
+	client *scm.Client
+	data   *Data
+	mux    *http.ServeMux
+}
+
+// NewServer returns a server of the data.
+func NewServer(data *Data) *Server {
+	s := &Server{
+		client: newClient(data),
+		data:   data,
+		mux:    http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+// ServeHTTP serves the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handlerFunc handles a request, returning the status and
+// the body of the response.
+type handlerFunc func(ctx context.Context, r *request) (int, interface{}, error)
+
+// request is a request to a route, with the full name of
+// the repository of the /repos routes.
+type request struct {
+	*http.Request
+	repo string
+}
+
+func (s *Server) handle(pattern string, h handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		req := &request{Request: r}
+		if owner := r.PathValue("owner"); owner != "" {
+			req.repo = scm.Join(owner, r.PathValue("repo"))
+		}
+		status, out, err := h(r.Context(), req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if out == nil {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(out)
+	})
+}
+
+func (s *Server) routes() {
+	s.handle("GET /user", s.findUser)
+	s.handle("GET /users/{login}", s.findLogin)
+	s.handle("GET /user/repos", s.listRepos)
+	s.handle("GET /users/{login}/repos", s.listRepos)
+	s.handle("GET /orgs/{org}/repos", s.listRepos)
+	s.handle("POST /user/repos", s.createRepo)
+	s.handle("POST /orgs/{org}/repos", s.createRepo)
+
+	const repo = "/repos/{owner}/{repo}"
+	s.handle("GET "+repo, s.findRepo)
+	s.handle("GET "+repo+"/labels", s.listRepoLabels)
+	s.handle("GET "+repo+"/hooks", s.listHooks)
+	s.handle("POST "+repo+"/hooks", s.createHook)
+	s.handle("DELETE "+repo+"/hooks/{id}", s.deleteHook)
+	s.handle("GET "+repo+"/statuses/{ref}", s.listStatuses)
+	s.handle("GET "+repo+"/commits/{ref}/statuses", s.listStatuses)
+	s.handle("POST "+repo+"/statuses/{ref}", s.createStatus)
+	s.handle("GET "+repo+"/commits/{ref}/status", s.combinedStatus)
+
+	s.handle("GET "+repo+"/pulls", s.listPullRequests)
+	s.handle("POST "+repo+"/pulls", s.createPullRequest)
+	s.handle("GET "+repo+"/pulls/{number}", s.findPullRequest)
+	s.handle("PATCH "+repo+"/pulls/{number}", s.updatePullRequest)
+	s.handle("PUT "+repo+"/pulls/{number}/merge", s.mergePullRequest)
+	s.handle("GET "+repo+"/pulls/{number}/files", s.listPullRequestFiles)
+
+	s.handle("GET "+repo+"/issues", s.listIssues)
+	s.handle("POST "+repo+"/issues", s.createIssue)
+	s.handle("GET "+repo+"/issues/{number}", s.findIssue)
+	s.handle("PATCH "+repo+"/issues/{number}", s.updateIssue)
+	s.handle("GET "+repo+"/issues/{number}/comments", s.listComments)
+	s.handle("POST "+repo+"/issues/{number}/comments", s.createComment)
+	s.handle("DELETE "+repo+"/issues/comments/{id}", s.deleteComment)
+	s.handle("GET "+repo+"/issues/{number}/labels", s.listLabels)
+	s.handle("POST "+repo+"/issues/{number}/labels", s.addLabels)
+	s.handle("DELETE "+repo+"/issues/{number}/labels/{label}", s.deleteLabel)
+
+	s.handle("GET "+repo+"/contents/{path...}", s.findContent)
+	s.handle("PUT "+repo+"/contents/{path...}", s.writeContent)
+	s.handle("DELETE "+repo+"/contents/{path...}", s.deleteContent)
+
+	s.handle("GET "+repo+"/git/refs/{ref...}", s.findRef)
+	s.handle("POST "+repo+"/git/refs", s.createRef)
+	s.handle("DELETE "+repo+"/git/refs/{ref...}", s.deleteRef)
+	s.handle("GET "+repo+"/branches", s.listBranches)
+	s.handle("GET "+repo+"/branches/{branch...}", s.findBranch)
+	s.handle("GET "+repo+"/tags", s.listTags)
+	s.handle("GET "+repo+"/commits", s.listCommits)
+	s.handle("GET "+repo+"/commits/{ref}", s.findCommit)
+	s.handle("GET "+repo+"/compare/{basehead...}", s.compareCommits)
+
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, scm.ErrNotFound)
+	})
+}
+
+// statusError is an error with the status of the response.
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return e.message
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &statusError{status: http.StatusBadRequest, message: errors.Errorf(format, args...).Error()}
+}
+
+// writeError writes the error as a GitHub error response.
+// Errors not found and not supported map to 404 and 501,
+// the scm.Error of the faults of the data keep their status
+// and the errors of the fake services map to 422.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusUnprocessableEntity
+	message := err.Error()
+	var statusErr *statusError
+	var scmErr *scm.Error
+	switch {
+	case errors.As(err, &statusErr):
+		status = statusErr.status
+	case errors.As(err, &scmErr) && scmErr.Status != 0:
+		status = scmErr.Status
+		if scmErr.Message != "" {
+			message = scmErr.Message
+		}
+	case errors.Is(err, scm.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, scm.ErrNotSupported):
+		status = http.StatusNotImplemented
+	}
+	if status == http.StatusNotFound {
+		message = "Not Found"
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+// decode decodes the JSON body of the request.
+func decode(r *request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest("Problems parsing JSON: %v", err)
+	}
+	return nil
+}
+
+// number returns the number of the path of the request.
+func number(r *request) (int, error) {
+	n, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		return 0, scm.ErrNotFound
+	}
+	return n, nil
+}
+
+// listOptions returns the pagination of the request.
+func listOptions(r *request) *scm.ListOptions {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	size, _ := strconv.Atoi(q.Get("per_page"))
+	return &scm.ListOptions{Page: page, Size: size}
+}
+
+// state returns the open and closed filters of the state
+// parameter, which defaults to open.
+func state(r *request) (open, closed bool) {
+	switch r.URL.Query().Get("state") {
+	case "closed":
+		return false, true
+	case "all":
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// isPullRequest reports whether the number is the one of a
+// pull request rather than an issue, since the issue
+// routes serve both.
+func (s *Server) isPullRequest(number int) bool {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	_, ok := s.data.PullRequests[number]
+	return ok
+}
+
+func (s *Server) findUser(ctx context.Context, r *request) (int, interface{}, error) {
+	user, _, err := s.client.Users.Find(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toUser(user), nil
+}
+
+func (s *Server) findLogin(ctx context.Context, r *request) (int, interface{}, error) {
+	user, _, err := s.client.Users.FindLogin(ctx, r.PathValue("login"))
+	if err != nil {
+		return 0, nil, err
+	}
+	if user == nil {
+		return 0, nil, scm.ErrNotFound
+	}
+	return http.StatusOK, toUser(user), nil
+}
+
+func (s *Server) listRepos(ctx context.Context, r *request) (int, interface{}, error) {
+	var repos []*scm.Repository
+	var err error
+	switch {
+	case r.PathValue("org") != "":
+		repos, _, err = s.client.Repositories.ListOrganisation(ctx, r.PathValue("org"), listOptions(r))
+	case r.PathValue("login") != "":
+		repos, _, err = s.client.Repositories.ListUser(ctx, r.PathValue("login"), listOptions(r))
+	default:
+		repos, _, err = s.client.Repositories.List(ctx, listOptions(r))
+		repos = paginate(repos, listOptions(r))
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(repos, toRepository), nil
+}
+
+func (s *Server) createRepo(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghRepositoryInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	repo, _, err := s.client.Repositories.Create(ctx, &scm.RepositoryInput{
+		Namespace:   r.PathValue("org"),
+		Name:        in.Name,
+		Description: in.Description,
+		Homepage:    in.Homepage,
+		Private:     in.Private,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toRepository(repo), nil
+}
+
+func (s *Server) findRepo(ctx context.Context, r *request) (int, interface{}, error) {
+	repo, _, err := s.client.Repositories.Find(ctx, r.repo)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toRepository(repo), nil
+}
+
+func (s *Server) listRepoLabels(ctx context.Context, r *request) (int, interface{}, error) {
+	labels, _, err := s.client.Repositories.ListLabels(ctx, r.repo, listOptions(r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(labels, toLabel), nil
+}
+
+func (s *Server) listHooks(ctx context.Context, r *request) (int, interface{}, error) {
+	hooks, _, err := s.client.Repositories.ListHooks(ctx, r.repo, listOptions(r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(hooks, toHook), nil
+}
+
+func (s *Server) createHook(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghHook)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	hook, _, err := s.client.Repositories.CreateHook(ctx, r.repo, &scm.HookInput{
+		Name:         in.Name,
+		Target:       in.Config.URL,
+		Secret:       in.Config.Secret,
+		SkipVerify:   in.Config.InsecureSSL == "1",
+		NativeEvents: in.Events,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toHook(hook), nil
+}
+
+func (s *Server) deleteHook(ctx context.Context, r *request) (int, interface{}, error) {
+	if _, err := s.client.Repositories.DeleteHook(ctx, r.repo, r.PathValue("id")); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) listStatuses(ctx context.Context, r *request) (int, interface{}, error) {
+	statuses, _, err := s.client.Repositories.ListStatus(ctx, r.repo, r.PathValue("ref"), listOptions(r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(statuses, toStatus), nil
+}
+
+func (s *Server) createStatus(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghStatus)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	status, _, err := s.client.Repositories.CreateStatus(ctx, r.repo, r.PathValue("ref"), &scm.StatusInput{
+		State:  fromStatusState(in.State),
+		Label:  in.Context,
+		Desc:   in.Description,
+		Target: in.TargetURL,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toStatus(status), nil
+}
+
+func (s *Server) combinedStatus(ctx context.Context, r *request) (int, interface{}, error) {
+	combined, _, err := s.client.Repositories.FindCombinedStatus(ctx, r.repo, r.PathValue("ref"))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, &ghCombinedStatus{
+		Sha:      combined.Sha,
+		State:    toStatusState(combined.State),
+		Statuses: toList(combined.Statuses, toStatus),
+	}, nil
+}
+
+func (s *Server) listPullRequests(ctx context.Context, r *request) (int, interface{}, error) {
+	opts := &scm.PullRequestListOptions{}
+	opts.Open, opts.Closed = state(r)
+	list := listOptions(r)
+	opts.Page, opts.Size = list.Page, list.Size
+	prs, _, err := s.client.PullRequests.List(ctx, r.repo, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(prs, toPullRequest), nil
+}
+
+func (s *Server) createPullRequest(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghPullRequestInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	pr, _, err := s.client.PullRequests.Create(ctx, r.repo, &scm.PullRequestInput{
+		Title: in.Title,
+		Body:  in.Body,
+		Head:  in.Head,
+		Base:  in.Base,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toPullRequest(pr), nil
+}
+
+func (s *Server) findPullRequest(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	pr, _, err := s.client.PullRequests.Find(ctx, r.repo, n)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toPullRequest(pr), nil
+}
+
+func (s *Server) updatePullRequest(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	in := new(ghPullRequestInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	switch in.State {
+	case "closed":
+		_, err = s.client.PullRequests.Close(ctx, r.repo, n)
+	case "open":
+		_, err = s.client.PullRequests.Reopen(ctx, r.repo, n)
+	case "":
+		_, _, err = s.client.PullRequests.Update(ctx, r.repo, n, &scm.PullRequestInput{
+			Title: in.Title,
+			Body:  in.Body,
+			Head:  in.Head,
+			Base:  in.Base,
+		})
+	default:
+		err = badRequest("invalid state %q", in.State)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return s.findPullRequest(ctx, r)
+}
+
+func (s *Server) mergePullRequest(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	in := new(ghMergeInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	_, err = s.client.PullRequests.Merge(ctx, r.repo, n, &scm.PullRequestMergeOptions{
+		CommitTitle: in.CommitTitle,
+		SHA:         in.Sha,
+		MergeMethod: in.MergeMethod,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	pr, _, err := s.client.PullRequests.Find(ctx, r.repo, n)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{
+		"sha":     pr.MergeSha,
+		"merged":  true,
+		"message": "Pull Request successfully merged",
+	}, nil
+}
+
+func (s *Server) listPullRequestFiles(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	changes, _, err := s.client.PullRequests.ListChanges(ctx, r.repo, n, listOptions(r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(changes, toFile), nil
+}
+
+func (s *Server) listIssues(ctx context.Context, r *request) (int, interface{}, error) {
+	opts := scm.IssueListOptions{}
+	opts.Open, opts.Closed = state(r)
+	list := listOptions(r)
+	opts.Page, opts.Size = list.Page, list.Size
+	issues, _, err := s.client.Issues.List(ctx, r.repo, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(issues, toIssue), nil
+}
+
+func (s *Server) createIssue(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghIssueInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	issue, _, err := s.client.Issues.Create(ctx, r.repo, &scm.IssueInput{Title: in.Title, Body: in.Body})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toIssue(issue), nil
+}
+
+func (s *Server) findIssue(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	issue, _, err := s.client.Issues.Find(ctx, r.repo, n)
+	if err != nil {
+		return 0, nil, err
+	}
+	if issue == nil {
+		return 0, nil, scm.ErrNotFound
+	}
+	return http.StatusOK, toIssue(issue), nil
+}
+
+func (s *Server) updateIssue(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	in := new(ghIssueInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	switch in.State {
+	case "closed":
+		_, err = s.client.Issues.Close(ctx, r.repo, n)
+	case "open":
+		_, err = s.client.Issues.Reopen(ctx, r.repo, n)
+	default:
+		err = badRequest("invalid state %q", in.State)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return s.findIssue(ctx, r)
+}
+
+func (s *Server) listComments(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	var comments []*scm.Comment
+	if s.isPullRequest(n) {
+		comments, _, err = s.client.PullRequests.ListComments(ctx, r.repo, n, listOptions(r))
+	} else {
+		comments, _, err = s.client.Issues.ListComments(ctx, r.repo, n, listOptions(r))
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(comments, toComment), nil
+}
+
+func (s *Server) createComment(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	in := new(ghComment)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	input := &scm.CommentInput{Body: in.Body}
+	var comment *scm.Comment
+	if s.isPullRequest(n) {
+		comment, _, err = s.client.PullRequests.CreateComment(ctx, r.repo, n, input)
+	} else {
+		comment, _, err = s.client.Issues.CreateComment(ctx, r.repo, n, input)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toComment(comment), nil
+}
+
+func (s *Server) deleteComment(ctx context.Context, r *request) (int, interface{}, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		return 0, nil, scm.ErrNotFound
+	}
+	// the comment ids are unique across the issues and the
+	// pull requests.
+	if _, err := s.client.Issues.DeleteComment(ctx, r.repo, 0, id); err == nil {
+		return http.StatusNoContent, nil, nil
+	}
+	if _, err := s.client.PullRequests.DeleteComment(ctx, r.repo, 0, id); err != nil {
+		return 0, nil, errors.Wrap(scm.ErrNotFound, err.Error())
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) listLabels(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	var labels []*scm.Label
+	if s.isPullRequest(n) {
+		labels, _, err = s.client.PullRequests.ListLabels(ctx, r.repo, n, listOptions(r))
+	} else {
+		labels, _, err = s.client.Issues.ListLabels(ctx, r.repo, n, listOptions(r))
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(labels, toLabel), nil
+}
+
+func (s *Server) addLabels(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	var labels []string
+	if err := decode(r, &labels); err != nil {
+		return 0, nil, err
+	}
+	for _, label := range labels {
+		if s.isPullRequest(n) {
+			_, err = s.client.PullRequests.AddLabel(ctx, r.repo, n, label)
+		} else {
+			_, err = s.client.Issues.AddLabel(ctx, r.repo, n, label)
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	return s.listLabels(ctx, r)
+}
+
+func (s *Server) deleteLabel(ctx context.Context, r *request) (int, interface{}, error) {
+	n, err := number(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	label := r.PathValue("label")
+	if s.isPullRequest(n) {
+		_, err = s.client.PullRequests.DeleteLabel(ctx, r.repo, n, label)
+	} else {
+		_, err = s.client.Issues.DeleteLabel(ctx, r.repo, n, label)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return s.listLabels(ctx, r)
+}
+
+// findContent returns the file of the path, or the entries
+// of the directory of the path.
+func (s *Server) findContent(ctx context.Context, r *request) (int, interface{}, error) {
+	path := r.PathValue("path")
+	ref := r.URL.Query().Get("ref")
+	content, _, err := s.client.Contents.Find(ctx, r.repo, path, ref)
+	if err == nil {
+		return http.StatusOK, toContent(content), nil
+	}
+	entries, _, listErr := s.client.Contents.List(ctx, r.repo, path, ref, listOptions(r))
+	if listErr != nil {
+		return 0, nil, errors.Wrap(scm.ErrNotFound, err.Error())
+	}
+	return http.StatusOK, toList(entries, toEntry), nil
+}
+
+func (s *Server) writeContent(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghContentInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	path := r.PathValue("path")
+	params := in.params()
+	status := http.StatusOK
+	var err error
+	if in.Sha == "" {
+		status = http.StatusCreated
+		_, err = s.client.Contents.Create(ctx, r.repo, path, params)
+	} else {
+		_, err = s.client.Contents.Update(ctx, r.repo, path, params)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	content, _, err := s.client.Contents.Find(ctx, r.repo, path, in.Branch)
+	if err != nil {
+		return 0, nil, err
+	}
+	sha, _, err := s.client.Git.FindRef(ctx, r.repo, in.Branch)
+	if err != nil {
+		return 0, nil, err
+	}
+	out := &ghContentResult{Content: toContent(content)}
+	out.Content.Content = ""
+	out.Commit.Sha = sha
+	return status, out, nil
+}
+
+func (s *Server) deleteContent(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghContentInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	if _, err := s.client.Contents.Delete(ctx, r.repo, r.PathValue("path"), in.params()); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, &ghContentResult{}, nil
+}
+
+func (s *Server) findRef(ctx context.Context, r *request) (int, interface{}, error) {
+	ref := r.PathValue("ref")
+	sha, _, err := s.client.Git.FindRef(ctx, r.repo, ref)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toRef(scm.ExpandRef(ref, "refs"), sha), nil
+}
+
+func (s *Server) createRef(ctx context.Context, r *request) (int, interface{}, error) {
+	in := new(ghRefInput)
+	if err := decode(r, in); err != nil {
+		return 0, nil, err
+	}
+	if !strings.HasPrefix(in.Ref, "refs/") || strings.Count(in.Ref, "/") < 2 {
+		return 0, nil, badRequest("Reference name must start with 'refs/' and have at least two slashes")
+	}
+	ref, _, err := s.client.Git.CreateRef(ctx, r.repo, in.Ref, in.Sha)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toRef(ref.Path, ref.Sha), nil
+}
+
+func (s *Server) deleteRef(ctx context.Context, r *request) (int, interface{}, error) {
+	ref := scm.ExpandRef(r.PathValue("ref"), "refs")
+	if _, err := s.client.Git.DeleteRef(ctx, r.repo, ref); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) listBranches(ctx context.Context, r *request) (int, interface{}, error) {
+	refs, _, err := s.client.Git.ListBranches(ctx, r.repo, listOptions(r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(refs, toBranch), nil
+}
+
+func (s *Server) findBranch(ctx context.Context, r *request) (int, interface{}, error) {
+	ref, _, err := s.client.Git.FindBranch(ctx, r.repo, r.PathValue("branch"))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toBranch(ref), nil
+}
+
+func (s *Server) listTags(ctx context.Context, r *request) (int, interface{}, error) {
+	refs, _, err := s.client.Git.ListTags(ctx, r.repo, listOptions(r))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(refs, toBranch), nil
+}
+
+func (s *Server) listCommits(ctx context.Context, r *request) (int, interface{}, error) {
+	q := r.URL.Query()
+	list := listOptions(r)
+	commits, _, err := s.client.Git.ListCommits(ctx, r.repo, scm.CommitListOptions{
+		Ref:  q.Get("ref"),
+		Sha:  q.Get("sha"),
+		Path: q.Get("path"),
+		Page: list.Page,
+		Size: list.Size,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toList(commits, func(c *scm.Commit) *ghCommit {
+		return toCommit(c, nil)
+	}), nil
+}
+
+func (s *Server) findCommit(ctx context.Context, r *request) (int, interface{}, error) {
+	ref := r.PathValue("ref")
+	commit, _, err := s.client.Git.FindCommit(ctx, r.repo, ref)
+	if err != nil {
+		return 0, nil, err
+	}
+	if commit == nil {
+		return 0, nil, scm.ErrNotFound
+	}
+	changes, _, err := s.client.Git.ListChanges(ctx, r.repo, ref, &scm.ListOptions{})
+	if err != nil && !errors.Is(err, scm.ErrNotFound) {
+		return 0, nil, err
+	}
+	return http.StatusOK, toCommit(commit, changes), nil
+}
+
+func (s *Server) compareCommits(ctx context.Context, r *request) (int, interface{}, error) {
+	base, head, ok := strings.Cut(r.PathValue("basehead"), "...")
+	if !ok {
+		return 0, nil, scm.ErrNotFound
+	}
+	changes, _, err := s.client.Git.CompareCommits(ctx, r.repo, base, head, &scm.ListOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, &ghCompare{Files: toList(changes, toFile)}, nil
+}
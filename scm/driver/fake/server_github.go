@@ -0,0 +1,567 @@
+package fake
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// the GitHub representations of the resources served by the
+// server, after the ones decoded by the github driver.
+
+type ghUser struct {
+	ID        int       `json:"id"`
+	Login     string    `json:"login"`
+	Name      string    `json:"name,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	AvatarURL string    `json:"avatar_url"`
+	HTMLURL   string    `json:"html_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ghRepository struct {
+	ID            int       `json:"id"`
+	Owner         ghUser    `json:"owner"`
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Private       bool      `json:"private"`
+	Archived      bool      `json:"archived"`
+	HTMLURL       string    `json:"html_url"`
+	SSHURL        string    `json:"ssh_url"`
+	CloneURL      string    `json:"clone_url"`
+	DefaultBranch string    `json:"default_branch"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Permissions   struct {
+		Admin bool `json:"admin"`
+		Push  bool `json:"push"`
+		Pull  bool `json:"pull"`
+	} `json:"permissions"`
+}
+
+type ghRepositoryInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Homepage    string `json:"homepage"`
+	Private     bool   `json:"private"`
+}
+
+type ghHook struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	Config struct {
+		URL         string `json:"url"`
+		Secret      string `json:"secret,omitempty"`
+		ContentType string `json:"content_type"`
+		InsecureSSL string `json:"insecure_ssl"`
+	} `json:"config"`
+}
+
+type ghLabel struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type ghStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+type ghCombinedStatus struct {
+	Sha      string      `json:"sha"`
+	State    string      `json:"state"`
+	Statuses []*ghStatus `json:"statuses"`
+}
+
+type ghBranch struct {
+	Ref  string        `json:"ref"`
+	Sha  string        `json:"sha"`
+	User ghUser        `json:"user"`
+	Repo *ghRepository `json:"repo"`
+}
+
+type ghPullRequest struct {
+	Number             int        `json:"number"`
+	State              string     `json:"state"`
+	Title              string     `json:"title"`
+	Body               string     `json:"body"`
+	Labels             []*ghLabel `json:"labels"`
+	DiffURL            string     `json:"diff_url"`
+	HTMLURL            string     `json:"html_url"`
+	User               ghUser     `json:"user"`
+	RequestedReviewers []*ghUser  `json:"requested_reviewers"`
+	Assignees          []*ghUser  `json:"assignees"`
+	Head               ghBranch   `json:"head"`
+	Base               ghBranch   `json:"base"`
+	Draft              bool       `json:"draft"`
+	Merged             bool       `json:"merged"`
+	Mergeable          bool       `json:"mergeable"`
+	MergeableState     string     `json:"mergeable_state"`
+	Rebaseable         bool       `json:"rebaseable"`
+	MergeSha           string     `json:"merge_commit_sha"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+type ghPullRequestInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	State string `json:"state"`
+}
+
+type ghMergeInput struct {
+	CommitTitle string `json:"commit_title"`
+	MergeMethod string `json:"merge_method"`
+	Sha         string `json:"sha"`
+}
+
+type ghIssue struct {
+	ID        int        `json:"id"`
+	HTMLURL   string     `json:"html_url"`
+	Number    int        `json:"number"`
+	State     string     `json:"state"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	User      ghUser     `json:"user"`
+	Labels    []*ghLabel `json:"labels"`
+	Assignees []*ghUser  `json:"assignees"`
+	Locked    bool       `json:"locked"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// PullRequest links the pull request of the issue, nil
+	// if the issue is not a pull request.
+	PullRequest *ghIssuePullRequest `json:"pull_request,omitempty"`
+}
+
+type ghIssuePullRequest struct {
+	HTMLURL string `json:"html_url"`
+	DiffURL string `json:"diff_url"`
+}
+
+type ghIssueInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	State string `json:"state"`
+}
+
+type ghComment struct {
+	ID        int       `json:"id"`
+	HTMLURL   string    `json:"html_url"`
+	User      ghUser    `json:"user"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ghContent struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Sha      string `json:"sha"`
+	Type     string `json:"type"`
+	Encoding string `json:"encoding,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+type ghContentInput struct {
+	Message string `json:"message"`
+	Content []byte `json:"content"`
+	Sha     string `json:"sha"`
+	Branch  string `json:"branch"`
+}
+
+func (in *ghContentInput) params() *scm.ContentParams {
+	return &scm.ContentParams{
+		Branch:  in.Branch,
+		Message: in.Message,
+		Data:    in.Content,
+		Sha:     in.Sha,
+	}
+}
+
+type ghContentResult struct {
+	Content *ghContent `json:"content"`
+	Commit  struct {
+		Sha string `json:"sha,omitempty"`
+	} `json:"commit"`
+}
+
+type ghEntry struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Size int    `json:"size"`
+	Sha  string `json:"sha"`
+	URL  string `json:"url"`
+}
+
+type ghRef struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		Type string `json:"type"`
+		Sha  string `json:"sha"`
+	} `json:"object"`
+}
+
+type ghRefInput struct {
+	Ref string `json:"ref"`
+	Sha string `json:"sha"`
+}
+
+type ghNamedRef struct {
+	Name   string `json:"name"`
+	Commit struct {
+		Sha string `json:"sha"`
+	} `json:"commit"`
+}
+
+type ghSignature struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+type ghCommit struct {
+	Sha     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Tree struct {
+			Sha string `json:"sha"`
+			URL string `json:"url"`
+		} `json:"tree"`
+		Author    ghSignature `json:"author"`
+		Committer ghSignature `json:"committer"`
+		Message   string      `json:"message"`
+	} `json:"commit"`
+	Author    *ghUser   `json:"author"`
+	Committer *ghUser   `json:"committer"`
+	Files     []*ghFile `json:"files,omitempty"`
+}
+
+type ghFile struct {
+	Sha              string `json:"sha"`
+	Filename         string `json:"filename"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+	Patch            string `json:"patch,omitempty"`
+	BlobURL          string `json:"blob_url,omitempty"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+}
+
+type ghCompare struct {
+	Files []*ghFile `json:"files"`
+}
+
+// toList converts the resources with the function, always
+// returning a non-nil list so that it encodes as an array.
+func toList[F, T any](from []F, convert func(F) T) []T {
+	to := make([]T, 0, len(from))
+	for _, v := range from {
+		to = append(to, convert(v))
+	}
+	return to
+}
+
+func toUser(from *scm.User) *ghUser {
+	return &ghUser{
+		ID:        from.ID,
+		Login:     from.Login,
+		Name:      from.Name,
+		Email:     from.Email,
+		AvatarURL: from.Avatar,
+		HTMLURL:   from.Link,
+		CreatedAt: from.Created,
+		UpdatedAt: from.Updated,
+	}
+}
+
+func toUsers(from []scm.User) []*ghUser {
+	return toList(from, func(u scm.User) *ghUser {
+		return toUser(&u)
+	})
+}
+
+func toRepository(from *scm.Repository) *ghRepository {
+	id, _ := strconv.Atoi(from.ID)
+	owner := from.Namespace
+	if owner == "" {
+		owner, _ = scm.Split(from.FullName)
+	}
+	to := &ghRepository{
+		ID:            id,
+		Owner:         ghUser{Login: owner},
+		Name:          from.Name,
+		FullName:      from.FullName,
+		Private:       from.Private,
+		Archived:      from.Archived,
+		HTMLURL:       from.Link,
+		SSHURL:        from.CloneSSH,
+		CloneURL:      from.Clone,
+		DefaultBranch: from.Branch,
+		CreatedAt:     from.Created,
+		UpdatedAt:     from.Updated,
+	}
+	if from.Perm != nil {
+		to.Permissions.Admin = from.Perm.Admin
+		to.Permissions.Push = from.Perm.Push
+		to.Permissions.Pull = from.Perm.Pull
+	}
+	return to
+}
+
+func toHook(from *scm.Hook) *ghHook {
+	id, _ := strconv.Atoi(from.ID)
+	to := &ghHook{
+		ID:     id,
+		Name:   from.Name,
+		Events: from.Events,
+		Active: from.Active,
+	}
+	if to.Events == nil {
+		to.Events = []string{}
+	}
+	to.Config.URL = from.Target
+	to.Config.ContentType = "json"
+	to.Config.InsecureSSL = "0"
+	if from.SkipVerify {
+		to.Config.InsecureSSL = "1"
+	}
+	return to
+}
+
+func toLabel(from *scm.Label) *ghLabel {
+	return &ghLabel{
+		ID:          from.ID,
+		Name:        from.Name,
+		Color:       from.Color,
+		Description: from.Description,
+	}
+}
+
+func toStatus(from *scm.Status) *ghStatus {
+	return &ghStatus{
+		State:       toStatusState(from.State),
+		TargetURL:   from.Target,
+		URL:         from.Link,
+		Description: from.Desc,
+		Context:     from.Label,
+	}
+}
+
+// toStatusState returns the GitHub state of the status,
+// like the github driver.
+func toStatusState(from scm.State) string {
+	switch from {
+	case scm.StatePending, scm.StateRunning:
+		return "pending"
+	case scm.StateSuccess:
+		return "success"
+	case scm.StateFailure:
+		return "failure"
+	default:
+		return "error"
+	}
+}
+
+func fromStatusState(from string) scm.State {
+	switch from {
+	case "error":
+		return scm.StateError
+	case "failure":
+		return scm.StateFailure
+	case "pending":
+		return scm.StatePending
+	case "success":
+		return scm.StateSuccess
+	default:
+		return scm.StateUnknown
+	}
+}
+
+// toState returns the state of an issue or a pull request,
+// which the fake services may leave empty.
+func toState(state string, closed bool) string {
+	switch {
+	case state != "":
+		return state
+	case closed:
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+func toPullRequestBranch(from *scm.PullRequestBranch) ghBranch {
+	to := ghBranch{Ref: from.Ref, Sha: from.Sha}
+	if from.Repo.FullName != "" {
+		to.Repo = toRepository(&from.Repo)
+		to.User = to.Repo.Owner
+	}
+	return to
+}
+
+func toPullRequest(from *scm.PullRequest) *ghPullRequest {
+	to := &ghPullRequest{
+		Number:             from.Number,
+		State:              toState(from.State, from.Closed),
+		Title:              from.Title,
+		Body:               from.Body,
+		Labels:             toList(from.Labels, toLabel),
+		DiffURL:            from.DiffLink,
+		HTMLURL:            from.Link,
+		User:               *toUser(&from.Author),
+		RequestedReviewers: toUsers(from.Reviewers),
+		Assignees:          toUsers(from.Assignees),
+		Head:               toPullRequestBranch(&from.Head),
+		Base:               toPullRequestBranch(&from.Base),
+		Draft:              from.Draft,
+		Merged:             from.Merged,
+		Mergeable:          from.Mergeable,
+		MergeableState:     from.MergeableState.String(),
+		Rebaseable:         from.Rebaseable,
+		MergeSha:           from.MergeSha,
+		CreatedAt:          from.Created,
+		UpdatedAt:          from.Updated,
+	}
+	// the fake pull requests may only record the branches
+	// and the sha on the pull request itself.
+	if to.Head.Ref == "" {
+		to.Head.Ref = from.Source
+	}
+	if to.Head.Sha == "" {
+		to.Head.Sha = from.Sha
+	}
+	if to.Base.Ref == "" {
+		to.Base.Ref = from.Target
+	}
+	return to
+}
+
+func toIssue(from *scm.Issue) *ghIssue {
+	return &ghIssue{
+		ID:      from.Number,
+		HTMLURL: from.Link,
+		Number:  from.Number,
+		State:   toState(from.State, from.Closed),
+		Title:   from.Title,
+		Body:    from.Body,
+		User:    *toUser(&from.Author),
+		Labels: toList(from.Labels, func(name string) *ghLabel {
+			return &ghLabel{Name: name}
+		}),
+		Assignees: toUsers(from.Assignees),
+		Locked:    from.Locked,
+		CreatedAt: from.Created,
+		UpdatedAt: from.Updated,
+	}
+}
+
+func toComment(from *scm.Comment) *ghComment {
+	return &ghComment{
+		ID:        from.ID,
+		HTMLURL:   from.Link,
+		User:      *toUser(&from.Author),
+		Body:      from.Body,
+		CreatedAt: from.Created,
+		UpdatedAt: from.Updated,
+	}
+}
+
+func toContent(from *scm.Content) *ghContent {
+	path := strings.Trim(from.Path, "/")
+	return &ghContent{
+		Name:     path[strings.LastIndex(path, "/")+1:],
+		Path:     path,
+		Sha:      from.Sha,
+		Type:     "file",
+		Encoding: "base64",
+		Content:  base64.StdEncoding.EncodeToString(from.Data),
+	}
+}
+
+func toEntry(from *scm.FileEntry) *ghEntry {
+	return &ghEntry{
+		Name: from.Name,
+		Type: from.Type,
+		Path: from.Path,
+		Size: from.Size,
+		Sha:  from.Sha,
+		URL:  from.Link,
+	}
+}
+
+func toRef(ref, sha string) *ghRef {
+	to := &ghRef{Ref: ref}
+	to.Object.Type = "commit"
+	to.Object.Sha = sha
+	return to
+}
+
+func toBranch(from *scm.Reference) *ghNamedRef {
+	to := &ghNamedRef{Name: from.Name}
+	to.Commit.Sha = from.Sha
+	return to
+}
+
+func toSignature(from scm.Signature) ghSignature {
+	return ghSignature{Name: from.Name, Email: from.Email, Date: from.Date}
+}
+
+// toCommit converts the commit and the files it changes.
+func toCommit(from *scm.Commit, changes []*scm.Change) *ghCommit {
+	to := &ghCommit{
+		Sha:     from.Sha,
+		HTMLURL: from.Link,
+		Files:   toList(changes, toFile),
+	}
+	to.Commit.Tree.Sha = from.Tree.Sha
+	to.Commit.Tree.URL = from.Tree.Link
+	to.Commit.Author = toSignature(from.Author)
+	to.Commit.Committer = toSignature(from.Committer)
+	to.Commit.Message = from.Message
+	if from.Author.Login != "" {
+		to.Author = &ghUser{Login: from.Author.Login, AvatarURL: from.Author.Avatar}
+	}
+	if from.Committer.Login != "" {
+		to.Committer = &ghUser{Login: from.Committer.Login, AvatarURL: from.Committer.Avatar}
+	}
+	return to
+}
+
+func toFile(from *scm.Change) *ghFile {
+	status := "modified"
+	switch {
+	case from.Added:
+		status = "added"
+	case from.Deleted:
+		status = "removed"
+	case from.Renamed:
+		status = "renamed"
+	}
+	return &ghFile{
+		Sha:              from.Sha,
+		Filename:         from.Path,
+		Status:           status,
+		Additions:        from.Additions,
+		Deletions:        from.Deletions,
+		Changes:          from.Changes,
+		Patch:            from.Patch,
+		BlobURL:          from.BlobURL,
+		PreviousFilename: from.PreviousPath,
+	}
+}
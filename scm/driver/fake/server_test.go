@@ -0,0 +1,225 @@
+package fake_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	_, data := fake.NewDefault()
+	server := httptest.NewServer(fake.NewServer(data))
+	defer server.Close()
+	client, err := github.New(server.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	user, _, err := client.Users.Find(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "fakeuser", user.Login)
+
+	// repositories
+	created, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "myrepo"})
+	require.NoError(t, err)
+	assert.Equal(t, repo, created.FullName)
+	assert.Equal(t, "master", created.Branch)
+	found, _, err := client.Repositories.Find(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, "myorg", found.Namespace)
+	repos, _, err := client.Repositories.ListOrganisation(ctx, "myorg", &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, repos, 1)
+	_, res, err := client.Repositories.Find(ctx, "myorg/missing")
+	assert.ErrorIs(t, err, scm.ErrNotFound)
+	assert.Equal(t, http.StatusNotFound, res.Status)
+
+	// contents and refs
+	_, err = client.Contents.Create(ctx, repo, "README.md", &scm.ContentParams{Message: "Add README", Data: []byte("hello\n")})
+	require.NoError(t, err)
+	content, _, err := client.Contents.Find(ctx, repo, "README.md", "master")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(content.Data))
+	entries, _, err := client.Contents.List(ctx, repo, "", "master", &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "README.md", entries[0].Name)
+
+	master, _, err := client.Git.FindBranch(ctx, repo, "master")
+	require.NoError(t, err)
+	sha, _, err := client.Git.FindRef(ctx, repo, "heads/master")
+	require.NoError(t, err)
+	assert.Equal(t, master.Sha, sha)
+	ref, _, err := client.Git.CreateRef(ctx, repo, "refs/heads/feature", sha)
+	require.NoError(t, err)
+	assert.Equal(t, sha, ref.Sha)
+	_, res, err = client.Git.CreateRef(ctx, repo, "refs/heads/feature", sha)
+	require.Error(t, err, "Want an error creating an existing ref")
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+
+	_, err = client.Contents.Update(ctx, repo, "README.md", &scm.ContentParams{Branch: "feature", Data: []byte("hello\nworld\n"), Sha: content.Sha})
+	require.NoError(t, err)
+	changes, _, err := client.Git.CompareCommits(ctx, repo, "master", "feature", &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "README.md", changes[0].Path)
+	assert.Equal(t, 1, changes[0].Additions)
+	commit, _, err := client.Git.FindCommit(ctx, repo, "feature")
+	require.NoError(t, err)
+	assert.Equal(t, "fakeuser", commit.Author.Name)
+
+	// pull requests, shared with the fake clients of the data
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "Say world", Head: "feature", Base: "master"})
+	require.NoError(t, err)
+	assert.Equal(t, "Say world", data.PullRequests[pr.Number].Title)
+	_, err = client.PullRequests.AddLabel(ctx, repo, pr.Number, "lgtm")
+	require.NoError(t, err)
+	_, _, err = client.PullRequests.CreateComment(ctx, repo, pr.Number, &scm.CommentInput{Body: "/lgtm"})
+	require.NoError(t, err)
+	assert.Len(t, data.PullRequestComments[pr.Number], 1)
+	_, err = client.PullRequests.Close(ctx, repo, pr.Number)
+	require.NoError(t, err)
+	prs, _, err := client.PullRequests.List(ctx, repo, &scm.PullRequestListOptions{Closed: true})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.True(t, prs[0].Closed)
+	assert.Equal(t, "lgtm", prs[0].Labels[0].Name)
+	_, err = client.PullRequests.Reopen(ctx, repo, pr.Number)
+	require.NoError(t, err)
+	_, err = client.PullRequests.Merge(ctx, repo, pr.Number, &scm.PullRequestMergeOptions{})
+	require.NoError(t, err)
+	pr, _, err = client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err)
+	assert.True(t, pr.Merged)
+
+	// issues share their numbers with the pull requests
+	issue, _, err := client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Say more"})
+	require.NoError(t, err)
+	assert.Equal(t, pr.Number+1, issue.Number)
+	_, _, err = client.Issues.CreateComment(ctx, repo, issue.Number, &scm.CommentInput{Body: "soon"})
+	require.NoError(t, err)
+	comments, _, err := client.Issues.ListComments(ctx, repo, issue.Number, &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "soon", comments[0].Body)
+	_, err = client.Issues.Close(ctx, repo, issue.Number)
+	require.NoError(t, err)
+	issue, _, err = client.Issues.Find(ctx, repo, issue.Number)
+	require.NoError(t, err)
+	assert.True(t, issue.Closed)
+	_, _, err = client.Issues.Find(ctx, repo, 100)
+	assert.ErrorIs(t, err, scm.ErrNotFound)
+
+	// statuses and hooks
+	_, _, err = client.Repositories.CreateStatus(ctx, repo, sha, &scm.StatusInput{State: scm.StateSuccess, Label: "ci"})
+	require.NoError(t, err)
+	statuses, _, err := client.Repositories.ListStatus(ctx, repo, sha, &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, scm.StateSuccess, statuses[0].State)
+	hook, _, err := client.Repositories.CreateHook(ctx, repo, &scm.HookInput{Target: "https://example.com/hook", Events: scm.HookEvents{Push: true}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"push"}, hook.Events)
+	_, err = client.Repositories.DeleteHook(ctx, repo, hook.ID)
+	require.NoError(t, err)
+	assert.Empty(t, data.Hooks[repo])
+}
+
+func TestServerConcurrentRequests(t *testing.T) {
+	_, data := fake.NewDefault()
+	server := httptest.NewServer(fake.NewServer(data))
+	defer server.Close()
+	client, err := github.New(server.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	// the hook target calls the server back, as a bot would
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := client.Issues.ListComments(r.Context(), repo, 1, &scm.ListOptions{}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer target.Close()
+
+	_, _, err = client.Repositories.CreateHook(ctx, repo, &scm.HookInput{Target: target.URL, Events: scm.HookEvents{IssueComment: true}})
+	require.NoError(t, err)
+	issue, _, err := client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Issues.CreateComment(ctx, repo, issue.Number, &scm.CommentInput{Body: "/lgtm"})
+			assert.NoError(t, err)
+			_, _, err = client.Issues.ListComments(ctx, repo, issue.Number, &scm.ListOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	comments, _, err := client.Issues.ListComments(ctx, repo, issue.Number, &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, comments, n)
+	require.Len(t, data.Deliveries, n)
+	for _, delivery := range data.Deliveries {
+		assert.Equal(t, http.StatusOK, delivery.Status)
+	}
+}
+
+func TestServerConcurrentClients(t *testing.T) {
+	fakeClient, data := fake.NewDefault()
+	server := httptest.NewServer(fake.NewServer(data))
+	defer server.Close()
+	client, err := github.New(server.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	issue, _, err := fakeClient.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+
+	// the subscriber uses the fake client while the server
+	// handles the request emitting the webhook, as a bot
+	// under test would
+	data.Subscribe(func(hook scm.Webhook) {
+		comment, ok := hook.(*scm.IssueCommentHook)
+		if !ok || comment.Comment.Body != "/ping" {
+			return
+		}
+		_, _, err := fakeClient.Issues.CreateComment(ctx, repo, issue.Number, &scm.CommentInput{Body: "pong"})
+		assert.NoError(t, err)
+	})
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Issues.CreateComment(ctx, repo, issue.Number, &scm.CommentInput{Body: "/ping"})
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, err := fakeClient.Issues.ListComments(ctx, repo, issue.Number, &scm.ListOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	comments, _, err := fakeClient.Issues.ListComments(ctx, repo, issue.Number, &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, comments, 2*n)
+}
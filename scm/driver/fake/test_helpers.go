@@ -2,6 +2,7 @@ package fake
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -34,3 +35,20 @@ func AssertNoRepoExists(ctx context.Context, t *testing.T, client *scm.Client, r
 	require.Error(t, err, "expected not found error when looking up repo %s", repo)
 	require.True(t, scm.IsScmNotFound(err), "should have returned an is not found error for repo %s", repo)
 }
+
+// AssertGolden asserts that the YAML dump of the data matches the
+// golden file, which is written instead when the SCM_UPDATE_GOLDEN
+// environment variable is true
+func AssertGolden(t *testing.T, data *Data, path string) {
+	t.Helper()
+	got, err := data.Dump()
+	require.NoError(t, err, "failed to dump the data")
+
+	if os.Getenv("SCM_UPDATE_GOLDEN") == "true" {
+		require.NoError(t, os.WriteFile(path, got, 0o600), "failed to write golden file %s", path)
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "failed to read golden file %s", path)
+	require.Equal(t, string(want), string(got), "data does not match golden file %s", path)
+}
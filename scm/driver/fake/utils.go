@@ -1,5 +1,7 @@
 package fake
 
+import "github.com/jenkins-x/go-scm/scm"
+
 func paginated(page, size, items int) (start, end int) {
 	// handle the default value case for ListOptions.
 	if page == 0 || size == 0 {
@@ -18,3 +20,12 @@ func paginated(page, size, items int) (start, end int) {
 	}
 	return
 }
+
+// paginate returns the page of the items.
+func paginate[T any](items []T, opts *scm.ListOptions) []T {
+	if opts == nil {
+		return items
+	}
+	start, end := paginated(opts.Page, opts.Size, len(items))
+	return items[start:end]
+}
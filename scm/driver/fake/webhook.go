@@ -0,0 +1,391 @@
+package fake
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/pkg/errors"
+)
+
+// The GitHub headers of the webhooks POSTed to the hooks of
+// the repositories.
+const (
+	// EventHeader holds the GitHub event of the webhook.
+	EventHeader = "X-GitHub-Event"
+	// DeliveryHeader holds the GUID of the webhook.
+	DeliveryHeader = "X-GitHub-Delivery"
+	// SignatureHeader holds the HMAC SHA-256 signature of
+	// the payload with the secret of the hook, prefixed
+	// with sha256=.
+	SignatureHeader = "X-Hub-Signature-256"
+)
+
+// DefaultHookTimeout is the timeout of the requests POSTing
+// the webhooks when the data has no HookClient.
+const DefaultHookTimeout = 10 * time.Second
+
+// defaultHookClient POSTs the webhooks when the data has no
+// HookClient, so that a hung hook does not block the
+// operation emitting the webhook.
+var defaultHookClient = &http.Client{Timeout: DefaultHookTimeout}
+
+// Delivery records a webhook POSTed to the hook of a
+// repository.
+type Delivery struct {
+	GUID   string
+	HookID string
+	Target string
+	Kind   scm.WebhookKind
+	// Status the status of the response, zero if the
+	// request failed.
+	Status int
+	// Error the error of the request, if any.
+	Error string
+}
+
+// Subscribe registers the function to receive the webhooks
+// of the mutating operations of the fake clients of the data,
+// such as a PullRequestHook when a pull request is created
+// or an IssueCommentHook when an issue is commented.
+//
+// The webhooks are delivered synchronously, before the
+// operation returns but once it released the data, so a
+// subscriber can drive a bot under test as a full event
+// loop calling the fake clients. They are also POSTed to
+// the active Hooks of their repository subscribed to their
+// event, as GitHub webhooks, see Deliveries.
+func (d *Data) Subscribe(fn func(scm.Webhook)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers = append(d.subscribers, fn)
+}
+
+// emission is a webhook emitted by a call of the fake
+// clients, dispatched once the call releases the data.
+type emission struct {
+	hook  scm.Webhook
+	posts []*heldDelivery
+}
+
+// emit records the deliveries of the webhook to the hooks
+// of its repository, and queues it until the call releases
+// the data.
+func (d *Data) emit(hook scm.Webhook) {
+	d.WebhookID++
+	guid := strconv.Itoa(d.WebhookID)
+	switch h := hook.(type) {
+	case *scm.PushHook:
+		h.GUID = guid
+	case *scm.PullRequestHook:
+		h.GUID = guid
+	case *scm.PullRequestCommentHook:
+		h.GUID = guid
+	case *scm.IssueCommentHook:
+		h.GUID = guid
+	}
+	e := &emission{hook: hook}
+	for _, h := range d.Hooks[hook.Repository().FullName] {
+		if h.Active && subscribed(h, hook.Kind()) {
+			d.deliver(e, h, guid, hook)
+		}
+	}
+	d.emitted = append(d.emitted, e)
+}
+
+// dispatch delivers the webhook to the subscribers, then
+// POSTs it to the hooks. It is called without the lock of
+// the data.
+func (d *Data) dispatch(e *emission) {
+	d.mu.Lock()
+	subscribers := slices.Clone(d.subscribers)
+	d.mu.Unlock()
+	for _, fn := range subscribers {
+		fn(e.hook)
+	}
+	for _, post := range e.posts {
+		status, err := d.post(post.request)
+		d.mu.Lock()
+		post.delivery.record(status, err)
+		d.mu.Unlock()
+	}
+}
+
+// deliver records the delivery of the webhook to the hook,
+// and adds the request POSTing it as a GitHub webhook,
+// signed with its secret, to the emission.
+func (d *Data) deliver(e *emission, hook *scm.Hook, guid string, webhook scm.Webhook) {
+	delivery := &Delivery{
+		GUID:   guid,
+		HookID: hook.ID,
+		Target: hook.Target,
+		Kind:   webhook.Kind(),
+	}
+	d.Deliveries = append(d.Deliveries, delivery)
+
+	req, err := d.hookRequest(hook, guid, webhook)
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+	e.posts = append(e.posts, &heldDelivery{delivery: delivery, request: req})
+}
+
+// hookRequest returns the request POSTing the webhook to the
+// hook.
+func (d *Data) hookRequest(hook *scm.Hook, guid string, webhook scm.Webhook) (*http.Request, error) {
+	event, payload := toGitHubWebhook(webhook)
+	if event == "" {
+		return nil, errors.Errorf("no GitHub event for the %s webhooks", webhook.Kind())
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, hook.Target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GitHub-Hookshot/fake")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, guid)
+	req.Header.Set("X-GitHub-Hook-ID", hook.ID)
+	if secret := d.HookSecrets[hook.ID]; secret != "" {
+		req.Header.Set("X-Hub-Signature", "sha1="+sign(sha1.New, body, secret))
+		req.Header.Set(SignatureHeader, "sha256="+sign(sha256.New, body, secret))
+	}
+	return req, nil
+}
+
+// post sends the request of a webhook, returning the status
+// of the response.
+func (d *Data) post(req *http.Request) (int, error) {
+	client := d.HookClient
+	if client == nil {
+		client = defaultHookClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	return res.StatusCode, nil
+}
+
+// heldDelivery is a delivery whose request is not sent yet.
+type heldDelivery struct {
+	delivery *Delivery
+	request  *http.Request
+}
+
+// record records the outcome of the request of the delivery.
+func (d *Delivery) record(status int, err error) {
+	if err != nil {
+		d.Error = err.Error()
+		return
+	}
+	d.Status = status
+}
+
+// sign returns the hex encoded HMAC of the payload.
+func sign(h func() hash.Hash, payload []byte, secret string) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// nativeEvents maps the kinds of webhooks to the GitHub events
+// of the hooks created through the server.
+var nativeEvents = map[scm.WebhookKind][]string{
+	scm.WebhookKindIssue:              {"issues"},
+	scm.WebhookKindPullRequestComment: {"issue_comment"},
+	scm.WebhookKindBranch:             {"create", "delete"},
+	scm.WebhookKindTag:                {"create", "delete"},
+}
+
+// subscribed reports whether the hook receives the webhooks
+// of the kind. Hooks without events receive them all.
+func subscribed(hook *scm.Hook, kind scm.WebhookKind) bool {
+	if len(hook.Events) == 0 || slices.Contains(hook.Events, "*") || slices.Contains(hook.Events, string(kind)) {
+		return true
+	}
+	for _, event := range nativeEvents[kind] {
+		if slices.Contains(hook.Events, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// hookEvents returns the kinds of webhooks of the events.
+func hookEvents(from scm.HookEvents) []string {
+	var events []string
+	add := func(on bool, kind scm.WebhookKind) {
+		if on {
+			events = append(events, string(kind))
+		}
+	}
+	add(from.Branch, scm.WebhookKindBranch)
+	add(from.Deployment, scm.WebhookKindDeploy)
+	add(from.DeploymentStatus, scm.WebhookKindDeploymentStatus)
+	add(from.Issue, scm.WebhookKindIssue)
+	add(from.IssueComment, scm.WebhookKindIssueComment)
+	add(from.PullRequest, scm.WebhookKindPullRequest)
+	add(from.PullRequestComment, scm.WebhookKindPullRequestComment)
+	add(from.Push, scm.WebhookKindPush)
+	add(from.Release, scm.WebhookKindRelease)
+	add(from.Review, scm.WebhookKindReview)
+	add(from.ReviewComment, scm.WebhookKindReviewCommentHook)
+	add(from.Tag, scm.WebhookKindTag)
+	return events
+}
+
+// hookRepository returns the repository of the webhooks of
+// the full name.
+func (d *Data) hookRepository(fullName string) scm.Repository {
+	for _, repo := range d.Repositories {
+		if repo.FullName == fullName {
+			return *repo
+		}
+	}
+	namespace, name := scm.Split(fullName)
+	return scm.Repository{Namespace: namespace, Name: name, FullName: fullName}
+}
+
+func (d *Data) emitPullRequest(repo string, action scm.Action, pr *scm.PullRequest, label *scm.Label) {
+	hook := &scm.PullRequestHook{
+		Action:      action,
+		Repo:        d.hookRepository(repo),
+		PullRequest: *pr,
+		Sender:      d.CurrentUser,
+	}
+	if label != nil {
+		hook.Label = *label
+	}
+	d.emit(hook)
+}
+
+func (d *Data) emitIssue(repo string, action scm.Action, issue *scm.Issue) {
+	d.emit(&scm.IssueHook{
+		Action: action,
+		Repo:   d.hookRepository(repo),
+		Issue:  *issue,
+		Sender: d.CurrentUser,
+	})
+}
+
+// emitComment emits a PullRequestCommentHook for the comments
+// of the pull requests, and an IssueCommentHook for the
+// comments of the issues.
+func (d *Data) emitComment(repo string, number int, action scm.Action, comment *scm.Comment) {
+	if pr, ok := d.PullRequests[number]; ok {
+		d.emit(&scm.PullRequestCommentHook{
+			Action:      action,
+			Repo:        d.hookRepository(repo),
+			PullRequest: *pr,
+			Comment:     *comment,
+			Sender:      d.CurrentUser,
+		})
+		return
+	}
+	issue := scm.Issue{Number: number}
+	if found := d.findIssue(number); found != nil {
+		issue = *found
+	}
+	d.emit(&scm.IssueCommentHook{
+		Action:  action,
+		Repo:    d.hookRepository(repo),
+		Issue:   issue,
+		Comment: *comment,
+		Sender:  d.CurrentUser,
+	})
+}
+
+// emitPush emits the push of the commit to the branch, which
+// creates, updates or deletes the file.
+func (d *Data) emitPush(repo, branch string, commit *object.Commit, file string, action scm.Action) {
+	before := ""
+	if len(commit.ParentHashes) > 0 {
+		before = commit.ParentHashes[0].String()
+	}
+	pushed := scm.PushCommit{ID: commit.Hash.String(), Message: commit.Message}
+	switch action {
+	case scm.ActionCreate:
+		pushed.Added = []string{file}
+	case scm.ActionDelete:
+		pushed.Removed = []string{file}
+	default:
+		pushed.Modified = []string{file}
+	}
+	d.emit(&scm.PushHook{
+		Ref:     "refs/heads/" + branch,
+		Repo:    d.hookRepository(repo),
+		Before:  before,
+		After:   commit.Hash.String(),
+		Commits: []scm.PushCommit{pushed},
+		Commit:  *convertCommit(repo, commit),
+		Sender:  d.CurrentUser,
+	})
+}
+
+// emitRef emits a TagHook for the tags, and a BranchHook for
+// the other references.
+func (d *Data) emitRef(repo string, action scm.Action, ref *scm.Reference) {
+	if scm.IsTag(ref.Path) {
+		d.emit(&scm.TagHook{
+			Ref:    *ref,
+			Repo:   d.hookRepository(repo),
+			Action: action,
+			Sender: d.CurrentUser,
+		})
+		return
+	}
+	d.emit(&scm.BranchHook{
+		Ref:    *ref,
+		Repo:   d.hookRepository(repo),
+		Action: action,
+		Sender: d.CurrentUser,
+	})
+}
+
+// findIssue returns the issue of the number, nil if none.
+func (d *Data) findIssue(number int) *scm.Issue {
+	for _, slice := range d.Issues {
+		for _, issue := range slice {
+			if issue.Number == number {
+				return issue
+			}
+		}
+	}
+	return nil
+}
+
+// NewWebHookService returns a new webhook service parsing the
+// webhooks POSTed to the hooks of the repositories, without
+// the rest of the client.
+func NewWebHookService() scm.WebhookService {
+	return &webhookService{}
+}
+
+type webhookService struct{}
+
+// Parse parses the GitHub webhooks POSTed to the hooks of the
+// repositories, validating their signature with the secret
+// returned by fn, like the github driver.
+func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return github.NewWebHookService().Parse(req, fn)
+}
@@ -0,0 +1,285 @@
+package fake
+
+import (
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// the GitHub payloads of the webhooks POSTed to the hooks of
+// the repositories, so that the bots consuming GitHub
+// webhooks can consume them.
+
+type ghPushSignature struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username,omitempty"`
+}
+
+type ghPushCommit struct {
+	ID        string          `json:"id"`
+	TreeID    string          `json:"tree_id"`
+	Distinct  bool            `json:"distinct"`
+	Message   string          `json:"message"`
+	Timestamp time.Time       `json:"timestamp"`
+	URL       string          `json:"url"`
+	Author    ghPushSignature `json:"author"`
+	Committer ghPushSignature `json:"committer"`
+	Added     []string        `json:"added"`
+	Removed   []string        `json:"removed"`
+	Modified  []string        `json:"modified"`
+}
+
+type ghPushHook struct {
+	Ref        string          `json:"ref"`
+	Before     string          `json:"before"`
+	After      string          `json:"after"`
+	Compare    string          `json:"compare"`
+	Created    bool            `json:"created"`
+	Deleted    bool            `json:"deleted"`
+	Forced     bool            `json:"forced"`
+	HeadCommit *ghPushCommit   `json:"head_commit"`
+	Commits    []*ghPushCommit `json:"commits"`
+	Repository *ghRepository   `json:"repository"`
+	Pusher     ghPushSignature `json:"pusher"`
+	Sender     *ghUser         `json:"sender"`
+}
+
+type ghPullRequestHook struct {
+	Action      string         `json:"action"`
+	Number      int            `json:"number"`
+	PullRequest *ghPullRequest `json:"pull_request"`
+	Label       *ghLabel       `json:"label,omitempty"`
+	Repository  *ghRepository  `json:"repository"`
+	Sender      *ghUser        `json:"sender"`
+}
+
+type ghIssueHook struct {
+	Action     string        `json:"action"`
+	Issue      *ghIssue      `json:"issue"`
+	Repository *ghRepository `json:"repository"`
+	Sender     *ghUser       `json:"sender"`
+}
+
+type ghIssueCommentHook struct {
+	Action     string        `json:"action"`
+	Issue      *ghIssue      `json:"issue"`
+	Comment    *ghComment    `json:"comment"`
+	Repository *ghRepository `json:"repository"`
+	Sender     *ghUser       `json:"sender"`
+}
+
+type ghCreateDeleteHook struct {
+	Ref        string        `json:"ref"`
+	RefType    string        `json:"ref_type"`
+	Repository *ghRepository `json:"repository"`
+	Sender     *ghUser       `json:"sender"`
+}
+
+type ghRelease struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	TagName     string    `json:"tag_name"`
+	Commitish   string    `json:"target_commitish"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type ghReleaseHook struct {
+	Action     string        `json:"action"`
+	Release    *ghRelease    `json:"release"`
+	Repository *ghRepository `json:"repository"`
+	Sender     *ghUser       `json:"sender"`
+}
+
+type ghRepositoryHook struct {
+	Action     string        `json:"action"`
+	Repository *ghRepository `json:"repository"`
+	Sender     *ghUser       `json:"sender"`
+}
+
+type ghStatusHook struct {
+	Context     string        `json:"context"`
+	Description string        `json:"description"`
+	Repository  *ghRepository `json:"repository"`
+	Sender      *ghUser       `json:"sender"`
+}
+
+// toGitHubWebhook returns the GitHub event and payload of the
+// webhook, an empty event if GitHub has none.
+func toGitHubWebhook(from scm.Webhook) (string, interface{}) {
+	switch h := from.(type) {
+	case *scm.PushHook:
+		return "push", toPushHook(h)
+	case *scm.PullRequestHook:
+		to := &ghPullRequestHook{
+			Action:      toAction(h.Action),
+			Number:      h.PullRequest.Number,
+			PullRequest: toPullRequest(&h.PullRequest),
+			Repository:  toRepository(&h.Repo),
+			Sender:      toUser(&h.Sender),
+		}
+		if h.Label.Name != "" {
+			to.Label = toLabel(&h.Label)
+		}
+		return "pull_request", to
+	case *scm.IssueHook:
+		return "issues", &ghIssueHook{
+			Action:     toAction(h.Action),
+			Issue:      toIssue(&h.Issue),
+			Repository: toRepository(&h.Repo),
+			Sender:     toUser(&h.Sender),
+		}
+	case *scm.IssueCommentHook:
+		return "issue_comment", &ghIssueCommentHook{
+			Action:     toAction(h.Action),
+			Issue:      toIssue(&h.Issue),
+			Comment:    toComment(&h.Comment),
+			Repository: toRepository(&h.Repo),
+			Sender:     toUser(&h.Sender),
+		}
+	case *scm.PullRequestCommentHook:
+		// GitHub sends the comments of the pull requests as
+		// comments of their issue.
+		issue := toIssue(&scm.Issue{
+			Number:  h.PullRequest.Number,
+			Title:   h.PullRequest.Title,
+			Body:    h.PullRequest.Body,
+			State:   h.PullRequest.State,
+			Closed:  h.PullRequest.Closed,
+			Link:    h.PullRequest.Link,
+			Author:  h.PullRequest.Author,
+			Created: h.PullRequest.Created,
+			Updated: h.PullRequest.Updated,
+		})
+		issue.PullRequest = &ghIssuePullRequest{
+			HTMLURL: h.PullRequest.Link,
+			DiffURL: h.PullRequest.DiffLink,
+		}
+		return "issue_comment", &ghIssueCommentHook{
+			Action:     toAction(h.Action),
+			Issue:      issue,
+			Comment:    toComment(&h.Comment),
+			Repository: toRepository(&h.Repo),
+			Sender:     toUser(&h.Sender),
+		}
+	case *scm.BranchHook:
+		return toRefEvent(h.Action), &ghCreateDeleteHook{
+			Ref:        h.Ref.Name,
+			RefType:    "branch",
+			Repository: toRepository(&h.Repo),
+			Sender:     toUser(&h.Sender),
+		}
+	case *scm.TagHook:
+		return toRefEvent(h.Action), &ghCreateDeleteHook{
+			Ref:        h.Ref.Name,
+			RefType:    "tag",
+			Repository: toRepository(&h.Repo),
+			Sender:     toUser(&h.Sender),
+		}
+	case *scm.ReleaseHook:
+		return "release", &ghReleaseHook{
+			Action:     toAction(h.Action),
+			Release:    toRelease(&h.Release),
+			Repository: toRepository(&h.Repo),
+			Sender:     toUser(&h.Sender),
+		}
+	case *scm.RepositoryHook:
+		return "repository", &ghRepositoryHook{
+			Action:     toAction(h.Action),
+			Repository: toRepository(&h.Repo),
+			Sender:     toUser(&h.Sender),
+		}
+	case *scm.StatusHook:
+		return "status", &ghStatusHook{
+			Context:     h.Label.Name,
+			Description: h.Label.Description,
+			Repository:  toRepository(&h.Repo),
+			Sender:      toUser(&h.Sender),
+		}
+	default:
+		return "", nil
+	}
+}
+
+func toPushHook(from *scm.PushHook) *ghPushHook {
+	to := &ghPushHook{
+		Ref:        from.Ref,
+		Before:     from.Before,
+		After:      from.After,
+		Compare:    from.Compare,
+		Created:    from.Created,
+		Deleted:    from.Deleted,
+		Forced:     from.Forced,
+		Commits:    []*ghPushCommit{},
+		Repository: toRepository(&from.Repo),
+		Pusher:     ghPushSignature{Name: from.Sender.Login, Email: from.Sender.Email},
+		Sender:     toUser(&from.Sender),
+	}
+	for i := range from.Commits {
+		commit := &ghPushCommit{
+			ID:       from.Commits[i].ID,
+			Distinct: true,
+			Message:  from.Commits[i].Message,
+			Added:    from.Commits[i].Added,
+			Removed:  from.Commits[i].Removed,
+			Modified: from.Commits[i].Modified,
+		}
+		if commit.ID == from.Commit.Sha {
+			commit.TreeID = from.Commit.Tree.Sha
+			commit.Timestamp = from.Commit.Committer.Date
+			commit.URL = from.Commit.Link
+			commit.Author = toPushSignature(from.Commit.Author)
+			commit.Committer = toPushSignature(from.Commit.Committer)
+			to.HeadCommit = commit
+		}
+		to.Commits = append(to.Commits, commit)
+	}
+	return to
+}
+
+func toPushSignature(from scm.Signature) ghPushSignature {
+	return ghPushSignature{Name: from.Name, Email: from.Email, Username: from.Login}
+}
+
+func toRelease(from *scm.Release) *ghRelease {
+	return &ghRelease{
+		ID:          from.ID,
+		Name:        from.Title,
+		Body:        from.Description,
+		HTMLURL:     from.Link,
+		TagName:     from.Tag,
+		Commitish:   from.Commitish,
+		Draft:       from.Draft,
+		Prerelease:  from.Prerelease,
+		CreatedAt:   from.Created,
+		PublishedAt: from.Published,
+	}
+}
+
+// toAction returns the GitHub action of the webhook action.
+func toAction(from scm.Action) string {
+	switch from {
+	case scm.ActionUpdate:
+		return "edited"
+	case scm.ActionSync:
+		return "synchronize"
+	case scm.ActionMerge:
+		return "closed"
+	default:
+		return from.String()
+	}
+}
+
+// toRefEvent returns the GitHub event of the creation or
+// deletion of a reference.
+func toRefEvent(action scm.Action) string {
+	if action == scm.ActionDelete {
+		return "delete"
+	}
+	return "create"
+}
@@ -0,0 +1,206 @@
+package fake_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSubscribe(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	var hooks []scm.Webhook
+	data.Subscribe(func(hook scm.Webhook) {
+		hooks = append(hooks, hook)
+	})
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "myrepo"})
+	require.NoError(t, err)
+	_, err = client.Contents.Create(ctx, repo, "README.md", &scm.ContentParams{Data: []byte("hello\n")})
+	require.NoError(t, err)
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "Add docs", Head: "docs", Base: "master"})
+	require.NoError(t, err)
+	_, _, err = client.PullRequests.CreateComment(ctx, repo, pr.Number, &scm.CommentInput{Body: "/lgtm"})
+	require.NoError(t, err)
+	issue, _, err := client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+	_, _, err = client.Issues.CreateComment(ctx, repo, issue.Number, &scm.CommentInput{Body: "on it"})
+	require.NoError(t, err)
+	_, _, err = client.Repositories.CreateStatus(ctx, repo, "abc", &scm.StatusInput{State: scm.StateSuccess, Label: "ci"})
+	require.NoError(t, err)
+	_, err = client.PullRequests.Merge(ctx, repo, pr.Number, &scm.PullRequestMergeOptions{})
+	require.NoError(t, err)
+
+	var kinds []scm.WebhookKind
+	for _, hook := range hooks {
+		kinds = append(kinds, hook.Kind())
+		assert.Equal(t, repo, hook.Repository().FullName)
+	}
+	assert.Equal(t, []scm.WebhookKind{
+		scm.WebhookKindRepository,
+		scm.WebhookKindPush,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPullRequestComment,
+		scm.WebhookKindIssue,
+		scm.WebhookKindIssueComment,
+		scm.WebhookKindStatus,
+		scm.WebhookKindPullRequest,
+	}, kinds)
+
+	push := hooks[1].(*scm.PushHook)
+	assert.Equal(t, "refs/heads/master", push.Ref)
+	assert.Equal(t, []string{"README.md"}, push.Commits[0].Added)
+	assert.Equal(t, push.After, push.Commit.Sha)
+	opened := hooks[2].(*scm.PullRequestHook)
+	assert.Equal(t, scm.ActionOpen, opened.Action)
+	assert.Equal(t, "fakeuser", opened.Sender.Login)
+	assert.Equal(t, "/lgtm", hooks[3].(*scm.PullRequestCommentHook).Comment.Body)
+	assert.Equal(t, issue.Number, hooks[5].(*scm.IssueCommentHook).Issue.Number)
+	merged := hooks[7].(*scm.PullRequestHook)
+	assert.Equal(t, scm.ActionClose, merged.Action)
+	assert.True(t, merged.PullRequest.Merged)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+	const secret = "s3cr3t"
+
+	var received []scm.Webhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hook, err := client.Webhooks.Parse(r, func(scm.Webhook) (string, error) {
+			return secret, nil
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, hook)
+	}))
+	defer server.Close()
+
+	_, _, err := client.Repositories.CreateHook(ctx, repo, &scm.HookInput{
+		Target: server.URL,
+		Secret: secret,
+		Events: scm.HookEvents{PullRequest: true, IssueComment: true},
+	})
+	require.NoError(t, err)
+	_, _, err = client.Repositories.CreateHook(ctx, repo, &scm.HookInput{
+		Target:       server.URL,
+		Secret:       "wrong",
+		NativeEvents: []string{"issues"},
+	})
+	require.NoError(t, err)
+
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "Add docs", Head: "docs", Base: "master"})
+	require.NoError(t, err)
+	_, err = client.PullRequests.AddLabel(ctx, repo, pr.Number, "lgtm")
+	require.NoError(t, err)
+	_, _, err = client.Repositories.CreateStatus(ctx, repo, "abc", &scm.StatusInput{State: scm.StatePending, Label: "ci"})
+	require.NoError(t, err)
+	_, _, err = client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+
+	require.Len(t, received, 2, "Want the pull request webhooks, without the status nor the badly signed issue")
+	labeled := received[1].(*scm.PullRequestHook)
+	assert.Equal(t, scm.ActionLabel, labeled.Action)
+	assert.Equal(t, "lgtm", labeled.Label.Name)
+	assert.Equal(t, "Add docs", labeled.PullRequest.Title)
+	assert.NotEmpty(t, labeled.GUID)
+
+	require.Len(t, data.Deliveries, 3)
+	assert.Equal(t, http.StatusOK, data.Deliveries[0].Status)
+	assert.Equal(t, scm.WebhookKindIssue, data.Deliveries[2].Kind)
+	assert.Equal(t, http.StatusBadRequest, data.Deliveries[2].Status)
+}
+
+func TestWebhookDeliveryGitHub(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+	const secret = "s3cr3t"
+
+	var received []scm.Webhook
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get("X-Hub-Signature-256"))
+		hook, err := github.NewWebHookService().Parse(r, func(scm.Webhook) (string, error) {
+			return secret, nil
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, hook)
+	}))
+	defer server.Close()
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "myrepo"})
+	require.NoError(t, err)
+	_, err = client.Contents.Create(ctx, repo, "README.md", &scm.ContentParams{Data: []byte("hello\n")})
+	require.NoError(t, err)
+	_, _, err = client.Repositories.CreateHook(ctx, repo, &scm.HookInput{Target: server.URL, Secret: secret})
+	require.NoError(t, err)
+	_, err = client.Contents.Update(ctx, repo, "README.md", &scm.ContentParams{Data: []byte("hello world\n")})
+	require.NoError(t, err)
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "Add docs", Head: "docs", Base: "master"})
+	require.NoError(t, err)
+	_, _, err = client.PullRequests.CreateComment(ctx, repo, pr.Number, &scm.CommentInput{Body: "/lgtm"})
+	require.NoError(t, err)
+
+	require.Len(t, received, 3)
+	for _, signature := range signatures {
+		assert.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+	}
+	push := received[0].(*scm.PushHook)
+	assert.Equal(t, "refs/heads/master", push.Ref)
+	assert.Equal(t, repo, push.Repo.FullName)
+	assert.Equal(t, []string{"README.md"}, push.Commits[0].Modified)
+	opened := received[1].(*scm.PullRequestHook)
+	assert.Equal(t, scm.ActionOpen, opened.Action)
+	assert.Equal(t, pr.Number, opened.PullRequest.Number)
+	assert.Equal(t, "fakeuser", opened.Sender.Login)
+	assert.NotEmpty(t, opened.GUID)
+	comment := received[2].(*scm.IssueCommentHook)
+	assert.Equal(t, scm.ActionCreate, comment.Action)
+	assert.Equal(t, "/lgtm", comment.Comment.Body)
+	assert.Equal(t, pr.Number, comment.Issue.Number)
+	assert.NotNil(t, comment.Issue.PullRequest, "Want the comment of a pull request sent as a comment of its issue")
+	for _, delivery := range data.Deliveries {
+		assert.Equal(t, http.StatusOK, delivery.Status)
+	}
+}
+
+func TestWebhookDeliveryTimeout(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	hung := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hung
+	}))
+	defer server.Close()
+	defer close(hung)
+	data.HookClient = &http.Client{Timeout: 10 * time.Millisecond}
+
+	_, _, err := client.Repositories.CreateHook(ctx, repo, &scm.HookInput{Target: server.URL})
+	require.NoError(t, err)
+	_, _, err = client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+
+	require.Len(t, data.Deliveries, 1)
+	assert.Zero(t, data.Deliveries[0].Status)
+	assert.Contains(t, data.Deliveries[0].Error, "Client.Timeout")
+}
@@ -0,0 +1,47 @@
+package gitea
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// Gitea driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapContentDelete,
+		scm.CapGitCompareCommits,
+		scm.CapGitCreateRef,
+		scm.CapGitFindTag,
+		scm.CapGitListChanges,
+		scm.CapOrganizationAcceptOrganizationInvitation,
+		scm.CapOrganizationListMemberships,
+		scm.CapOrganizationListPendingInvitations,
+		scm.CapIssueListEvents,
+		scm.CapIssueLock,
+		scm.CapIssueSearch,
+		scm.CapIssueUnlock,
+		scm.CapPullRequestDeletePullRequest,
+		scm.CapPullRequestListCommits,
+		scm.CapPullRequestListEvents,
+		scm.CapRepositoryUpdateHook,
+		scm.CapReviewDismiss,
+		scm.CapUserAcceptInvitation,
+		scm.CapUserListInvitations,
+	},
+	Webhooks: []scm.WebhookKind{
+		scm.WebhookKindBranch,
+		scm.WebhookKindIssue,
+		scm.WebhookKindIssueComment,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPullRequestComment,
+		scm.WebhookKindPush,
+		scm.WebhookKindRelease,
+		scm.WebhookKindReview,
+		scm.WebhookKindTag,
+	},
+	MergeMethods: []string{
+		scm.MergeMethodMerge,
+		scm.MergeMethodSquash,
+		scm.MergeMethodRebase,
+		scm.MergeMethodRebaseMerge,
+	},
+	Labels: scm.LabelsNative,
+}
@@ -24,6 +24,7 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 	ref = strings.TrimPrefix(ref, "refs/tags/")
 
 	out, resp, err := s.client.GiteaClient.GetContents(namespace, name, ref, path)
+	err = toSCMError(err, resp)
 	if err != nil {
 		return nil, toSCMResponse(resp), err
 	}
@@ -45,6 +46,7 @@ func (s *contentService) List(ctx context.Context, repo, path, ref string, opts
 	path = strings.TrimPrefix(path, "/")
 
 	c, resp, err := s.client.GiteaClient.ListContents(namespace, name, ref, path)
+	err = toSCMError(err, resp)
 	if err != nil {
 		return nil, toSCMResponse(resp), err
 	}
@@ -68,6 +70,7 @@ func (s *contentService) Create(ctx context.Context, repo, path string, params *
 	}
 
 	_, resp, err := s.client.GiteaClient.CreateFile(namespace, name, path, o)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -88,6 +91,7 @@ func (s *contentService) Update(ctx context.Context, repo, path string, params *
 	}
 
 	_, resp, err := s.client.GiteaClient.UpdateFile(namespace, name, path, o)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
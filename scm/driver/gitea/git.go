@@ -24,6 +24,7 @@ func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *sc
 	namespace, name := scm.Split(repo)
 
 	out, giteaResp, err := s.client.GiteaClient.GetRepoRefs(namespace, name, ref)
+	err = toSCMError(err, giteaResp)
 	resp := toSCMResponse(giteaResp)
 	if err != nil {
 		return "", resp, err
@@ -44,6 +45,7 @@ func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Resp
 	namespace, name := scm.Split(repo)
 	ref = strings.TrimPrefix(ref, "heads/")
 	out, giteaResp, err := s.client.GiteaClient.DeleteRepoBranch(namespace, name, ref)
+	err = toSCMError(err, giteaResp)
 	resp := toSCMResponse(giteaResp)
 	if !out {
 		return resp, errors.New("failed to delete branch")
@@ -54,12 +56,14 @@ func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Resp
 func (s *gitService) FindBranch(ctx context.Context, repo, branchName string) (*scm.Reference, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetRepoBranch(namespace, name, branchName)
+	err = toSCMError(err, resp)
 	return convertBranch(out), toSCMResponse(resp), err
 }
 
 func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetSingleCommit(namespace, name, ref)
+	err = toSCMError(err, resp)
 	return convertCommit(out), toSCMResponse(resp), err
 }
 
@@ -70,6 +74,7 @@ func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Refer
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts *scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.ListRepoBranches(namespace, name, gitea.ListRepoBranchesOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertBranchList(out), toSCMResponse(resp), err
 }
 
@@ -84,6 +89,7 @@ func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.Comm
 		SHA: opts.Sha,
 	}
 	out, resp, err := s.client.GiteaClient.ListRepoCommits(namespace, name, listOpts)
+	err = toSCMError(err, resp)
 	return convertCommitList(out), toSCMResponse(resp), err
 }
 
@@ -91,6 +97,7 @@ func (s *gitService) ListTags(ctx context.Context, repo string, opts *scm.ListOp
 	namespace, name := scm.Split(repo)
 
 	out, resp, err := s.client.GiteaClient.ListRepoTags(namespace, name, gitea.ListRepoTagsOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertTagList(out), toSCMResponse(resp), err
 }
 
@@ -9,7 +9,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -31,6 +30,18 @@ func New(uri string) (*scm.Client, error) {
 
 // NewWithToken returns a new Gitea API client with the token set.
 func NewWithToken(uri, token string) (*scm.Client, error) {
+	return newClient(uri, gitea.SetToken(token))
+}
+
+// NewWithBasicAuth returns a new Gitea API client with the basic auth set.
+func NewWithBasicAuth(uri, user, password string) (*scm.Client, error) {
+	return newClient(uri, gitea.SetBasicAuth(user, password))
+}
+
+// newClient returns a new Gitea API client whose SDK sends
+// its requests through the Client, so that the retry policy,
+// throttler, middleware and transport of the Client apply.
+func newClient(uri string, auth gitea.ClientOption) (*scm.Client, error) {
 	base, err := url.Parse(uri)
 	if err != nil {
 		return nil, err
@@ -39,14 +50,16 @@ func NewWithToken(uri, token string) (*scm.Client, error) {
 		base.Path += "/"
 	}
 	client := &wrapper{Client: new(scm.Client)}
-	client.GiteaClient, err = gitea.NewClient(base.String(), gitea.SetToken(token))
-
+	client.BaseURL = base
+	client.GiteaClient, err = gitea.NewClient(base.String(), auth, gitea.SetHTTPClient(&http.Client{
+		Transport: &sdkTransport{client: client.Client},
+	}))
 	if err != nil {
 		return nil, err
 	}
-	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGitea
+	client.SetCapabilities(capabilities)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -61,35 +74,41 @@ func NewWithToken(uri, token string) (*scm.Client, error) {
 	return client.Client, nil
 }
 
-// NewWithBasicAuth returns a new Gitea API client with the basic auth set.
-func NewWithBasicAuth(uri, user, password string) (*scm.Client, error) {
-	base, err := url.Parse(uri)
-	if err != nil {
-		return nil, err
+// sdkTransport sends the requests of the Gitea SDK through
+// the Client.
+type sdkTransport struct {
+	client *scm.Client
+}
+
+func (t *sdkTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	in := &scm.Request{
+		Method: req.Method,
+		Path:   req.URL.String(),
+		Header: req.Header.Clone(),
 	}
-	if !strings.HasSuffix(base.Path, "/") {
-		base.Path += "/"
+	if req.Body != nil {
+		// buffer the body so that its length is sent.
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		in.Body = bytes.NewReader(body)
 	}
-	client := &wrapper{Client: new(scm.Client)}
-	client.GiteaClient, err = gitea.NewClient(base.String(), gitea.SetBasicAuth(user, password))
-
+	res, err := t.client.Do(req.Context(), in)
 	if err != nil {
 		return nil, err
 	}
-	client.BaseURL = base
-	// initialize services
-	client.Driver = scm.DriverGitea
-	client.Contents = &contentService{client}
-	client.Git = &gitService{client}
-	client.Issues = &issueService{client}
-	client.Milestones = &milestoneService{client}
-	client.Organizations = &organizationService{client}
-	client.PullRequests = &pullService{&issueService{client}}
-	client.Repositories = &repositoryService{client}
-	client.Reviews = &reviewService{client}
-	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
-	return client.Client, nil
+	return &http.Response{
+		Status:     http.StatusText(res.Status),
+		StatusCode: res.Status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     res.Header,
+		Body:       res.Body,
+		Request:    req,
+	}, nil
 }
 
 // wraper wraps the Client to provide high level helper functions
@@ -130,9 +149,13 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	// if an error is encountered, unmarshal and return the
 	// error response.
 	if res.Status > 300 {
-		return res, errors.New(
-			http.StatusText(res.Status),
-		)
+		apiErr := scm.NewError(scm.DriverGitea, res)
+		out := new(errorResponse)
+		if json.NewDecoder(res.Body).Decode(out) == nil {
+			apiErr.Message = out.Message
+			apiErr.DocumentationURL = out.URL
+		}
+		return res, apiErr
 	}
 
 	if out == nil {
@@ -166,12 +189,32 @@ func toSCMResponse(r *gitea.Response) *scm.Response {
 		Body:   r.Body,
 	}
 	res.PopulatePageValues()
+	res.PopulateRate()
 	return res
 }
 
+// toSCMError returns an Error for the error of a Gitea SDK
+// call if the API responded with an error status, otherwise
+// the error unchanged.
+func toSCMError(err error, r *gitea.Response) error {
+	if err == nil || r == nil || r.StatusCode < 300 {
+		return err
+	}
+	apiErr := scm.NewError(scm.DriverGitea, toSCMResponse(r))
+	apiErr.Message = err.Error()
+	return apiErr
+}
+
 func toGiteaListOptions(in *scm.ListOptions) gitea.ListOptions {
 	return gitea.ListOptions{
 		Page:     in.Page,
 		PageSize: in.Size,
 	}
 }
+
+// errorResponse is the error response body returned by
+// the API.
+type errorResponse struct {
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
@@ -6,9 +6,12 @@
 package gitea
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
 	"gopkg.in/h2non/gock.v1"
 )
 
@@ -82,3 +85,84 @@ func mockServerVersion() {
 		Type("application/json").
 		File("testdata/version.json")
 }
+
+func TestClient_Capabilities(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, err := New("https://demo.gitea.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	captest.Verify(t, client)
+}
+
+func TestClient_SDKRequests(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/user").
+		Reply(200).
+		Type("application/json").
+		SetHeader("X-RateLimit-Limit", "60").
+		SetHeader("X-RateLimit-Remaining", "59").
+		SetHeader("X-RateLimit-Reset", "1512076018").
+		File("testdata/user.json")
+
+	client, err := New("https://demo.gitea.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	client.Use(scm.Middleware{
+		BeforeRequest: func(_ context.Context, event *scm.RequestEvent) {
+			paths = append(paths, event.Request.Path)
+		},
+	})
+	if _, _, err := client.Users.Find(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 1, len(paths); got != want {
+		t.Fatalf("Want %d requests sent through the client, got %d", want, got)
+	}
+	if want, got := 59, client.Rate().Remaining; got != want {
+		t.Errorf("Want rate remaining %d, got %d", want, got)
+	}
+}
+
+func TestClient_SDKError(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/unknown").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"repository does not exist"}`)
+
+	client, err := New("https://demo.gitea.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, res, err := client.Repositories.Find(context.Background(), "go-gitea/unknown")
+	var apiErr *scm.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Want an *scm.Error, got %T", err)
+	}
+	if want, got := 404, apiErr.Status; got != want {
+		t.Errorf("Want error status %d, got %d", want, got)
+	}
+	if want, got := "Not Found: repository does not exist", err.Error(); got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+	if !errors.Is(err, scm.ErrNotFound) {
+		t.Errorf("Want the error to match scm.ErrNotFound")
+	}
+	if want, got := 404, res.Status; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+}
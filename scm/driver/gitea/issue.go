@@ -42,6 +42,7 @@ func (s *issueService) AssignIssue(ctx context.Context, repo string, number int,
 		Assignees: assignees.List(),
 	}
 	_, giteaResp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	err = toSCMError(err, giteaResp)
 	return toSCMResponse(giteaResp), err
 }
 
@@ -65,6 +66,7 @@ func (s *issueService) UnassignIssue(ctx context.Context, repo string, number in
 		Assignees: assignees.List(),
 	}
 	_, giteaResp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	err = toSCMError(err, giteaResp)
 	return toSCMResponse(giteaResp), err
 }
 
@@ -75,6 +77,7 @@ func (s *issueService) ListEvents(context.Context, string, int, *scm.ListOptions
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetIssueLabels(namespace, name, int64(number), gitea.ListLabelsOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertLabels(out), toSCMResponse(resp), err
 }
 
@@ -121,6 +124,7 @@ func (s *issueService) AddLabel(ctx context.Context, repo string, number int, lb
 			Name:        lbl,
 		}
 		newLabel, giteaResp, err := s.client.GiteaClient.CreateLabel(namespace, name, lblInput)
+		err = toSCMError(err, giteaResp)
 		if err != nil {
 			return toSCMResponse(giteaResp), errors.Wrapf(err, "failed to create label %s in repository %s", lbl, repo)
 		}
@@ -129,6 +133,7 @@ func (s *issueService) AddLabel(ctx context.Context, repo string, number int, lb
 
 	in := gitea.IssueLabelsOption{Labels: []int64{labelID}}
 	_, giteaResp, err := s.client.GiteaClient.AddIssueLabels(namespace, name, int64(number), in)
+	err = toSCMError(err, giteaResp)
 	return toSCMResponse(giteaResp), err
 }
 
@@ -143,12 +148,14 @@ func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int,
 
 	namespace, name := scm.Split(repo)
 	giteaResp, err := s.client.GiteaClient.DeleteIssueLabel(namespace, name, int64(number), labelID)
+	err = toSCMError(err, giteaResp)
 	return toSCMResponse(giteaResp), err
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetIssue(namespace, name, int64(number))
+	err = toSCMError(err, resp)
 	return convertIssue(out), toSCMResponse(resp), err
 }
 
@@ -193,12 +200,14 @@ func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueList
 		in.State = gitea.StateClosed
 	}
 	out, resp, err := s.client.GiteaClient.ListRepoIssues(namespace, name, in)
+	err = toSCMError(err, resp)
 	return convertIssueList(out), toSCMResponse(resp), err
 }
 
 func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts *scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.ListIssueComments(namespace, name, int64(index), gitea.ListIssueCommentOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertIssueCommentList(out), toSCMResponse(resp), err
 }
 
@@ -210,6 +219,7 @@ func (s *issueService) Create(ctx context.Context, repo string, input *scm.Issue
 		Body:  input.Body,
 	}
 	out, resp, err := s.client.GiteaClient.CreateIssue(namespace, name, in)
+	err = toSCMError(err, resp)
 	return convertIssue(out), toSCMResponse(resp), err
 }
 
@@ -217,12 +227,14 @@ func (s *issueService) CreateComment(ctx context.Context, repo string, index int
 	namespace, name := scm.Split(repo)
 	in := gitea.CreateIssueCommentOption{Body: input.Body}
 	out, resp, err := s.client.GiteaClient.CreateIssueComment(namespace, name, int64(index), in)
+	err = toSCMError(err, resp)
 	return convertIssueComment(out), toSCMResponse(resp), err
 }
 
 func (s *issueService) DeleteComment(ctx context.Context, repo string, index, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	resp, err := s.client.GiteaClient.DeleteIssueComment(namespace, name, int64(id))
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -230,6 +242,7 @@ func (s *issueService) EditComment(ctx context.Context, repo string, number, id
 	namespace, name := scm.Split(repo)
 	in := gitea.EditIssueCommentOption{Body: input.Body}
 	out, resp, err := s.client.GiteaClient.EditIssueComment(namespace, name, int64(id), in)
+	err = toSCMError(err, resp)
 	return convertIssueComment(out), toSCMResponse(resp), err
 }
 
@@ -240,6 +253,7 @@ func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm
 		State: &closed,
 	}
 	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -250,6 +264,7 @@ func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*sc
 		State: &reopen,
 	}
 	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -268,6 +283,7 @@ func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID, n
 		Milestone: &num64,
 	}
 	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(issueID), in)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -275,6 +291,7 @@ func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int)
 	namespace, name := scm.Split(repo)
 	in := gitea.EditIssueOption{}
 	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(id), in)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -14,6 +14,7 @@ type milestoneService struct {
 func (s *milestoneService) Find(ctx context.Context, repo string, id int) (*scm.Milestone, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetMilestone(namespace, name, int64(id))
+	err = toSCMError(err, resp)
 	return convertMilestone(out), toSCMResponse(resp), err
 }
 
@@ -33,6 +34,7 @@ func (s *milestoneService) List(ctx context.Context, repo string, opts scm.Miles
 		in.State = gitea.StateOpen
 	}
 	out, resp, err := s.client.GiteaClient.ListRepoMilestones(namespace, name, in)
+	err = toSCMError(err, resp)
 	return convertMilestoneList(out), toSCMResponse(resp), err
 }
 
@@ -48,12 +50,14 @@ func (s *milestoneService) Create(ctx context.Context, repo string, input *scm.M
 		in.State = gitea.StateClosed
 	}
 	out, resp, err := s.client.GiteaClient.CreateMilestone(namespace, name, in)
+	err = toSCMError(err, resp)
 	return convertMilestone(out), toSCMResponse(resp), err
 }
 
 func (s *milestoneService) Delete(ctx context.Context, repo string, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	resp, err := s.client.GiteaClient.DeleteMilestone(namespace, name, int64(id))
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -78,6 +82,7 @@ func (s *milestoneService) Update(ctx context.Context, repo string, id int, inpu
 		in.Deadline = input.DueDate
 	}
 	out, resp, err := s.client.GiteaClient.EditMilestone(namespace, name, int64(id), in)
+	err = toSCMError(err, resp)
 	return convertMilestone(out), toSCMResponse(resp), err
 }
 
@@ -27,16 +27,19 @@ func (s *organizationService) Create(_ context.Context, org *scm.OrganizationInp
 		Website:     org.Homepage,
 		Visibility:  visibility,
 	})
+	err = toSCMError(err, resp)
 	return convertOrg(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) Delete(_ context.Context, org string) (*scm.Response, error) {
 	resp, err := s.client.GiteaClient.DeleteOrg(org)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org, user string) (bool, *scm.Response, error) {
 	isMember, resp, err := s.client.GiteaClient.CheckOrgMembership(org, user)
+	err = toSCMError(err, resp)
 	return isMember, toSCMResponse(resp), err
 }
 
@@ -68,6 +71,7 @@ func (s *organizationService) IsAdmin(ctx context.Context, org, user string) (bo
 
 func (s *organizationService) ListTeams(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.Team, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.ListOrgTeams(org, gitea.ListTeamsOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertTeamList(out), toSCMResponse(resp), err
 }
 
@@ -75,21 +79,25 @@ func (s *organizationService) ListTeamMembers(ctx context.Context, id int, role
 	out, resp, err := s.client.GiteaClient.ListTeamMembers(int64(id), gitea.ListTeamMembersOptions{
 		ListOptions: toGiteaListOptions(opts),
 	})
+	err = toSCMError(err, resp)
 	return convertMemberList(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) ListOrgMembers(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.ListOrgMembership(org, gitea.ListOrgMembershipOption{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertMemberList(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organization, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.GetOrg(name)
+	err = toSCMError(err, resp)
 	return convertOrg(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) List(ctx context.Context, opts *scm.ListOptions) ([]*scm.Organization, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.ListMyOrgs(gitea.ListOrgsOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertOrgList(out), toSCMResponse(resp), err
 }
 
@@ -21,6 +21,7 @@ type pullService struct {
 func (s *pullService) Find(ctx context.Context, repo string, index int) (*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetPullRequest(namespace, name, int64(index))
+	err = toSCMError(err, resp)
 	return convertPullRequest(out), toSCMResponse(resp), err
 }
 
@@ -38,6 +39,7 @@ func (s *pullService) List(ctx context.Context, repo string, opts *scm.PullReque
 		in.State = gitea.StateClosed
 	}
 	out, resp, err := s.client.GiteaClient.ListRepoPullRequests(namespace, name, in)
+	err = toSCMError(err, resp)
 	return convertPullRequests(out), toSCMResponse(resp), err
 }
 
@@ -90,6 +92,7 @@ func (s *pullService) Merge(ctx context.Context, repo string, index int, options
 	}
 
 	_, resp, err := s.client.GiteaClient.MergePullRequest(namespace, name, int64(index), in)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -101,6 +104,7 @@ func (s *pullService) Update(ctx context.Context, repo string, number int, input
 		Base:  input.Base,
 	}
 	out, resp, err := s.client.GiteaClient.EditPullRequest(namespace, name, int64(number), in)
+	err = toSCMError(err, resp)
 	return convertPullRequest(out), toSCMResponse(resp), err
 }
 
@@ -111,6 +115,7 @@ func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.
 		State: &closed,
 	}
 	_, resp, err := s.client.GiteaClient.EditPullRequest(namespace, name, int64(number), in)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -121,6 +126,7 @@ func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm
 		State: &reopen,
 	}
 	_, resp, err := s.client.GiteaClient.EditPullRequest(namespace, name, int64(number), in)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -133,6 +139,7 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 		Body:  input.Body,
 	}
 	out, resp, err := s.client.GiteaClient.CreatePullRequest(namespace, name, in)
+	err = toSCMError(err, resp)
 	return convertPullRequest(out), toSCMResponse(resp), err
 }
 
@@ -17,6 +17,7 @@ type releaseService struct {
 func (s *releaseService) Find(ctx context.Context, repo string, id int) (*scm.Release, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetRelease(namespace, name, int64(id))
+	err = toSCMError(err, resp)
 	return convertRelease(out), toSCMResponse(resp), err
 }
 
@@ -27,6 +28,7 @@ func (s *releaseService) FindByTag(ctx context.Context, repo, tag string) (*scm.
 	// newer versions of gitea have a GetReleaseByTag that doesn't 500 error
 	if err := s.client.GiteaClient.CheckServerVersionConstraint(">= 1.13.2"); err == nil {
 		out, resp, err := s.client.GiteaClient.GetReleaseByTag(namespace, name, tag)
+		err = toSCMError(err, resp)
 		if err == nil {
 			// There is a bug where a release that is a tag is returned - filter these out based on contents of the fields
 			// https://github.com/go-gitea/gitea/pull/14397
@@ -39,8 +41,6 @@ func (s *releaseService) FindByTag(ctx context.Context, repo, tag string) (*scm.
 
 	// older gitea version a broken `GetReleaseByTag`, so use `ListReleases` and iterate over each page
 	// https://github.com/go-gitea/gitea/commit/5ee09d3c8161280c72be8b02cd7ea354c1f55331
-	var releases []*gitea.Release
-	var err error
 	opts := scm.ReleaseListOptions{
 		Page: 1,
 		Size: 100,
@@ -48,9 +48,10 @@ func (s *releaseService) FindByTag(ctx context.Context, repo, tag string) (*scm.
 
 	scanPages := 1000
 	for opts.Page <= scanPages {
-		releases, _, err = s.client.GiteaClient.ListReleases(namespace, name, gitea.ListReleasesOptions{ListOptions: releaseListOptionsToGiteaListOptions(opts)})
+		releases, resp, err := s.client.GiteaClient.ListReleases(namespace, name, gitea.ListReleasesOptions{ListOptions: releaseListOptionsToGiteaListOptions(opts)})
+		err = toSCMError(err, resp)
 		if err != nil {
-			return nil, nil, err
+			return nil, toSCMResponse(resp), err
 		}
 		if len(releases) == 0 {
 			// no more pages to scan, release was not found
@@ -69,6 +70,7 @@ func (s *releaseService) FindByTag(ctx context.Context, repo, tag string) (*scm.
 func (s *releaseService) List(ctx context.Context, repo string, opts scm.ReleaseListOptions) ([]*scm.Release, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.ListReleases(namespace, name, gitea.ListReleasesOptions{ListOptions: releaseListOptionsToGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertReleaseList(out), toSCMResponse(resp), err
 }
 
@@ -82,12 +84,14 @@ func (s *releaseService) Create(ctx context.Context, repo string, input *scm.Rel
 		IsDraft:      input.Draft,
 		IsPrerelease: input.Prerelease,
 	})
+	err = toSCMError(err, resp)
 	return convertRelease(out), toSCMResponse(resp), err
 }
 
 func (s *releaseService) Delete(ctx context.Context, repo string, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	resp, err := s.client.GiteaClient.DeleteRelease(namespace, name, int64(id))
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
@@ -109,6 +113,7 @@ func (s *releaseService) Update(ctx context.Context, repo string, id int, input
 		IsDraft:      &input.Draft,
 		IsPrerelease: &input.Prerelease,
 	})
+	err = toSCMError(err, resp)
 	return convertRelease(out), toSCMResponse(resp), err
 }
 
@@ -32,6 +32,7 @@ func (s *repositoryService) Create(_ context.Context, input *scm.RepositoryInput
 	} else {
 		out, resp, err = s.client.GiteaClient.CreateOrgRepo(input.Namespace, in)
 	}
+	err = toSCMError(err, resp)
 	return convertRepository(out), toSCMResponse(resp), err
 }
 
@@ -39,12 +40,14 @@ func (s *repositoryService) Fork(ctx context.Context, input *scm.RepositoryInput
 	namespace, name := scm.Split(origRepo)
 	opts := gitea.CreateForkOption{Organization: &input.Namespace}
 	out, resp, err := s.client.GiteaClient.CreateFork(namespace, name, opts)
+	err = toSCMError(err, resp)
 	return convertRepository(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) FindCombinedStatus(_ context.Context, repo, ref string) (*scm.CombinedStatus, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetCombinedStatus(namespace, name, ref)
+	err = toSCMError(err, resp)
 	if err != nil {
 		return nil, toSCMResponse(resp), err
 	}
@@ -95,6 +98,7 @@ func (s *repositoryService) AddCollaborator(_ context.Context, repo, user, permi
 	giteaPerm := gitea.AccessMode(permission)
 	opt := gitea.AddCollaboratorOption{Permission: &giteaPerm}
 	resp, err := s.client.GiteaClient.AddCollaborator(namespace, name, user, opt)
+	err = toSCMError(err, resp)
 	if err != nil {
 		return false, false, toSCMResponse(resp), err
 	}
@@ -104,24 +108,28 @@ func (s *repositoryService) AddCollaborator(_ context.Context, repo, user, permi
 func (s *repositoryService) IsCollaborator(_ context.Context, repo, user string) (bool, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	isCollab, resp, err := s.client.GiteaClient.IsCollaborator(namespace, name, user)
+	err = toSCMError(err, resp)
 	return isCollab, toSCMResponse(resp), err
 }
 
 func (s *repositoryService) ListCollaborators(_ context.Context, repo string, opts *scm.ListOptions) ([]scm.User, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.ListCollaborators(namespace, name, gitea.ListCollaboratorsOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertUsers(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) ListLabels(_ context.Context, repo string, opts *scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.ListRepoLabels(namespace, name, gitea.ListLabelsOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertLabels(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) Find(_ context.Context, repo string) (*scm.Repository, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.GetRepo(namespace, name)
+	err = toSCMError(err, resp)
 	return convertRepository(out), toSCMResponse(resp), err
 }
 
@@ -132,6 +140,7 @@ func (s *repositoryService) FindHook(_ context.Context, repo, id string) (*scm.H
 		return nil, nil, err
 	}
 	out, resp, err := s.client.GiteaClient.GetRepoHook(namespace, name, idInt)
+	err = toSCMError(err, resp)
 	return convertHook(out), toSCMResponse(resp), err
 }
 
@@ -145,28 +154,33 @@ func (s *repositoryService) FindPerms(ctx context.Context, repo string) (*scm.Pe
 
 func (s *repositoryService) List(_ context.Context, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.ListMyRepos(gitea.ListReposOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertRepositoryList(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) ListOrganisation(_ context.Context, org string, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.ListOrgRepos(org, gitea.ListOrgReposOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertRepositoryList(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) ListUser(_ context.Context, username string, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.ListUserRepos(username, gitea.ListReposOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertRepositoryList(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) ListHooks(_ context.Context, repo string, opts *scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.ListRepoHooks(namespace, name, gitea.ListHooksOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertHookList(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) ListStatus(_ context.Context, repo, ref string, opts *scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	out, resp, err := s.client.GiteaClient.ListStatuses(namespace, name, ref, gitea.ListStatusesOption{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 	return convertStatusList(out), toSCMResponse(resp), err
 }
 
@@ -194,6 +208,7 @@ func (s *repositoryService) CreateHook(_ context.Context, repo string, input *sc
 		Active: true,
 	}
 	out, resp, err := s.client.GiteaClient.CreateRepoHook(namespace, name, in)
+	err = toSCMError(err, resp)
 	return convertHook(out), toSCMResponse(resp), err
 }
 
@@ -210,6 +225,7 @@ func (s *repositoryService) CreateStatus(_ context.Context, repo, ref string, in
 		Context:     input.Label,
 	}
 	out, resp, err := s.client.GiteaClient.CreateStatus(namespace, name, ref, in)
+	err = toSCMError(err, resp)
 	return convertStatus(out), toSCMResponse(resp), err
 }
 
@@ -220,12 +236,14 @@ func (s *repositoryService) DeleteHook(_ context.Context, repo, id string) (*scm
 		return nil, err
 	}
 	resp, err := s.client.GiteaClient.DeleteRepoHook(namespace, name, idInt)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
 func (s *repositoryService) Delete(_ context.Context, repo string) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	resp, err := s.client.GiteaClient.DeleteRepo(namespace, name)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
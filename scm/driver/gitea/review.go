@@ -19,12 +19,14 @@ type reviewService struct {
 func (s *reviewService) Find(ctx context.Context, repo string, number, id int) (*scm.Review, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	review, resp, err := s.client.GiteaClient.GetPullReview(namespace, name, int64(number), int64(id))
+	err = toSCMError(err, resp)
 	return convertReview(review), toSCMResponse(resp), err
 }
 
 func (s *reviewService) List(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	reviews, resp, err := s.client.GiteaClient.ListPullReviews(namespace, name, int64(number), gitea.ListPullReviewsOptions{ListOptions: toGiteaListOptions(opts)})
+	err = toSCMError(err, resp)
 
 	return convertReviewList(reviews), toSCMResponse(resp), err
 }
@@ -39,18 +41,21 @@ func (s *reviewService) Create(ctx context.Context, repo string, number int, inp
 		Comments: toCreatePullRequestComments(input.Comments),
 	}
 	review, resp, err := s.client.GiteaClient.CreatePullReview(namespace, name, int64(number), in)
+	err = toSCMError(err, resp)
 	return convertReview(review), toSCMResponse(resp), err
 }
 
 func (s *reviewService) Delete(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	resp, err := s.client.GiteaClient.DeletePullReview(namespace, name, int64(number), int64(id))
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID, reviewID int, options *scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	comments, resp, err := s.client.GiteaClient.ListPullReviewComments(namespace, name, int64(prID), int64(reviewID))
+	err = toSCMError(err, resp)
 	return convertReviewCommentList(comments), toSCMResponse(resp), err
 }
 
@@ -60,6 +65,7 @@ func (s *reviewService) Update(ctx context.Context, repo string, prID, reviewID
 		Body: body,
 	}
 	review, resp, err := s.client.GiteaClient.SubmitPullReview(namespace, name, int64(prID), int64(reviewID), in)
+	err = toSCMError(err, resp)
 	return convertReview(review), toSCMResponse(resp), err
 }
 
@@ -70,6 +76,7 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID, reviewID
 		Body:  input.Body,
 	}
 	review, resp, err := s.client.GiteaClient.SubmitPullReview(namespace, name, int64(prID), int64(reviewID), in)
+	err = toSCMError(err, resp)
 	return convertReview(review), toSCMResponse(resp), err
 }
 
@@ -19,6 +19,7 @@ func (s *userService) CreateToken(_ context.Context, user, name string) (*scm.Us
 	out, resp, err := s.client.GiteaClient.CreateAccessToken(gitea.CreateAccessTokenOption{
 		Name: name,
 	})
+	err = toSCMError(err, resp)
 	if out == nil {
 		return nil, toSCMResponse(resp), err
 	}
@@ -31,16 +32,19 @@ func (s *userService) CreateToken(_ context.Context, user, name string) (*scm.Us
 
 func (s *userService) DeleteToken(_ context.Context, id int64) (*scm.Response, error) {
 	resp, err := s.client.GiteaClient.DeleteAccessToken(id)
+	err = toSCMError(err, resp)
 	return toSCMResponse(resp), err
 }
 
 func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.GetMyUserInfo()
+	err = toSCMError(err, resp)
 	return convertUser(out), toSCMResponse(resp), err
 }
 
 func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *scm.Response, error) {
 	out, resp, err := s.client.GiteaClient.GetUserInfo(login)
+	err = toSCMError(err, resp)
 	return convertUser(out), toSCMResponse(resp), err
 }
 
@@ -0,0 +1,47 @@
+package github
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// GitHub driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapContentDelete,
+		scm.CapGitFindTag,
+		scm.CapOrganizationCreate,
+		scm.CapOrganizationDelete,
+		scm.CapPullRequestDeletePullRequest,
+		scm.CapPullRequestListCommits,
+		scm.CapUserCreateToken,
+		scm.CapUserDeleteToken,
+	},
+	Webhooks: []scm.WebhookKind{
+		scm.WebhookKindBranch,
+		scm.WebhookKindCheckRun,
+		scm.WebhookKindCheckSuite,
+		scm.WebhookKindDeploy,
+		scm.WebhookKindDeploymentStatus,
+		scm.WebhookKindFork,
+		scm.WebhookKindInstallation,
+		scm.WebhookKindInstallationRepository,
+		scm.WebhookKindIssue,
+		scm.WebhookKindIssueComment,
+		scm.WebhookKindLabel,
+		scm.WebhookKindPing,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPush,
+		scm.WebhookKindRelease,
+		scm.WebhookKindRepository,
+		scm.WebhookKindReview,
+		scm.WebhookKindReviewCommentHook,
+		scm.WebhookKindStatus,
+		scm.WebhookKindTag,
+		scm.WebhookKindWatch,
+	},
+	MergeMethods: []string{
+		scm.MergeMethodMerge,
+		scm.MergeMethodSquash,
+		scm.MergeMethodRebase,
+	},
+	Labels: scm.LabelsNative,
+}
@@ -9,12 +9,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
@@ -47,6 +45,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGithub
+	client.SetCapabilities(capabilities)
 	client.Contents = &contentService{client}
 	client.Deployments = &deploymentService{client}
 	client.Git = &gitService{client}
@@ -147,25 +146,17 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 	// parse the github request id.
 	res.ID = res.Header.Get("X-GitHub-Request-Id")
 
-	// parse the github rate limit details.
-	res.Rate.Limit, _ = strconv.Atoi(
-		res.Header.Get("X-RateLimit-Limit"),
-	)
-	res.Rate.Remaining, _ = strconv.Atoi(
-		res.Header.Get("X-RateLimit-Remaining"),
-	)
-	res.Rate.Reset, _ = strconv.ParseInt(
-		res.Header.Get("X-RateLimit-Reset"), 10, 64,
-	)
-
-	// snapshot the request rate limit
-	c.SetRate(res.Rate)
-
 	// if an error is encountered, unmarshal and return the
 	// error response.
 	if res.Status > 300 {
+		apiErr := scm.NewError(scm.DriverGithub, res)
 		if res.Status == 404 {
-			return res, scm.ErrNotFound
+			// the missing resources are expected, so they
+			// are not logged.
+			if b, err := io.ReadAll(res.Body); err == nil {
+				parseError(apiErr, b)
+			}
+			return res, apiErr
 		}
 		if res.Body != nil {
 			if b, err := io.ReadAll(res.Body); err == nil {
@@ -177,11 +168,10 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 					"rate":           res.Rate,
 					"requestID":      res.ID,
 				}).Warn("GitHub responded with error")
+				parseError(apiErr, b)
 			}
 		}
-		return res, errors.New(
-			http.StatusText(res.Status),
-		)
+		return res, apiErr
 	}
 
 	if out == nil {
@@ -195,9 +185,35 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 
 // Error represents a Github error.
 type Error struct {
-	Message string `json:"message"`
+	Message          string `json:"message"`
+	DocumentationURL string `json:"documentation_url"`
+	Errors           []struct {
+		Resource string `json:"resource"`
+		Field    string `json:"field"`
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+	} `json:"errors"`
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
+
+// parseError populates the scm.Error with the message and
+// validation errors of the GitHub error response body.
+func parseError(err *scm.Error, body []byte) {
+	out := new(Error)
+	if json.Unmarshal(body, out) != nil {
+		return
+	}
+	err.Message = out.Message
+	err.DocumentationURL = out.DocumentationURL
+	for _, e := range out.Errors {
+		err.Errors = append(err.Errors, scm.FieldError{
+			Resource: e.Resource,
+			Field:    e.Field,
+			Code:     e.Code,
+			Message:  e.Message,
+		})
+	}
+}
@@ -5,9 +5,14 @@
 package github
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
+	"gopkg.in/h2non/gock.v1"
 )
 
 var mockHeaders = map[string]string{
@@ -116,3 +121,72 @@ func testRequest(res *scm.Response) func(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_ErrorResponse(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/issues").
+		Reply(422).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/error_validation.json")
+
+	client := NewDefault()
+	_, _, err := client.Issues.Create(context.Background(), "octocat/hello-world", &scm.IssueInput{})
+	if err == nil {
+		t.Fatalf("Expect validation error")
+	}
+	if !scm.IsValidation(err) {
+		t.Errorf("Want validation error, got %v", err)
+	}
+	want := &scm.Error{
+		Driver:           scm.DriverGithub,
+		Status:           422,
+		Message:          "Validation Failed",
+		DocumentationURL: "https://docs.github.com/rest/issues/issues#create-an-issue",
+		Errors: []scm.FieldError{
+			{Resource: "Issue", Field: "title", Code: "missing_field"},
+		},
+		RequestID: "DD0E:6011:12F21A8:1926790:5A2064E2",
+	}
+	if diff := cmp.Diff(want, err); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/unknown").
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message":"Not Found","documentation_url":"https://docs.github.com/rest"}`)
+
+	client := NewDefault()
+	_, _, err := client.Repositories.Find(context.Background(), "octocat/unknown")
+	if !errors.Is(err, scm.ErrNotFound) {
+		t.Errorf("Want the error to match scm.ErrNotFound, got %v", err)
+	}
+	if want, got := "Not Found", err.Error(); got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+	want := &scm.Error{
+		Driver:           scm.DriverGithub,
+		Status:           404,
+		Message:          "Not Found",
+		DocumentationURL: "https://docs.github.com/rest",
+		RequestID:        "DD0E:6011:12F21A8:1926790:5A2064E2",
+	}
+	if diff := cmp.Diff(want, err); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestClient_Capabilities(t *testing.T) {
+	captest.Verify(t, NewDefault())
+}
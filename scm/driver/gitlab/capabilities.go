@@ -0,0 +1,50 @@
+package gitlab
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// GitLab driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapContentDelete,
+		scm.CapGitDeleteRef,
+		scm.CapOrganizationAcceptOrganizationInvitation,
+		scm.CapOrganizationCreate,
+		scm.CapOrganizationDelete,
+		scm.CapOrganizationListMemberships,
+		scm.CapOrganizationListPendingInvitations,
+		scm.CapPullRequestDeletePullRequest,
+		scm.CapPullRequestListCommits,
+		scm.CapReleaseDelete,
+		scm.CapReleaseFind,
+		scm.CapReleaseUpdate,
+		scm.CapRepositoryListOrganisation,
+		scm.CapRepositoryListUser,
+		scm.CapReviewCreate,
+		scm.CapReviewDelete,
+		scm.CapReviewDismiss,
+		scm.CapReviewFind,
+		scm.CapReviewList,
+		scm.CapReviewListComments,
+		scm.CapReviewSubmit,
+		scm.CapReviewUpdate,
+		scm.CapUserAcceptInvitation,
+		scm.CapUserCreateToken,
+		scm.CapUserDeleteToken,
+		scm.CapUserListInvitations,
+	},
+	Webhooks: []scm.WebhookKind{
+		scm.WebhookKindBranch,
+		scm.WebhookKindIssueComment,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPullRequestComment,
+		scm.WebhookKindPush,
+		scm.WebhookKindRelease,
+		scm.WebhookKindTag,
+	},
+	MergeMethods: []string{
+		scm.MergeMethodMerge,
+		scm.MergeMethodSquash,
+	},
+	Labels: scm.LabelsNative,
+}
@@ -9,10 +9,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -39,6 +40,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGitlab
+	client.SetCapabilities(capabilities)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -158,26 +160,12 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	// parse the gitlab request id.
 	res.ID = res.Header.Get("X-Request-Id")
 
-	// parse the gitlab rate limit details.
-	res.Rate.Limit, _ = strconv.Atoi(
-		res.Header.Get("RateLimit-Limit"),
-	)
-	res.Rate.Remaining, _ = strconv.Atoi(
-		res.Header.Get("RateLimit-Remaining"),
-	)
-	res.Rate.Reset, _ = strconv.ParseInt(
-		res.Header.Get("RateLimit-Reset"), 10, 64,
-	)
-
-	// snapshot the request rate limit
-	c.SetRate(res.Rate)
-
 	// if an error is encountered, unmarshal and return the
 	// error response.
 	if res.Status > 300 {
-		return res, errors.New(
-			http.StatusText(res.Status),
-		)
+		apiErr := scm.NewError(scm.DriverGitlab, res)
+		parseError(apiErr, res.Body)
+		return res, apiErr
 	}
 
 	if out == nil {
@@ -198,6 +186,51 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
+// errorResponse is the GitLab error response body. The
+// message is either a string or, for validation errors,
+// an object mapping field names to their error messages.
+type errorResponse struct {
+	Message json.RawMessage `json:"message"`
+	Error   string          `json:"error"`
+}
+
+// parseError populates the scm.Error with the message and
+// validation errors of the GitLab error response body.
+func parseError(err *scm.Error, body io.Reader) {
+	out := new(errorResponse)
+	if json.NewDecoder(body).Decode(out) != nil {
+		return
+	}
+	err.Message = out.Error
+	var message string
+	if json.Unmarshal(out.Message, &message) == nil {
+		// the messages may start with the status code, such
+		// as 404 Project Not Found.
+		err.Message = strings.TrimPrefix(message, strconv.Itoa(err.Status)+" ")
+		return
+	}
+	var fields map[string][]string
+	if json.Unmarshal(out.Message, &fields) != nil {
+		return
+	}
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	var messages []string
+	for _, field := range names {
+		for _, e := range fields[field] {
+			err.Errors = append(err.Errors, scm.FieldError{
+				Field:   field,
+				Message: e,
+			})
+			messages = append(messages, field+" "+e)
+		}
+	}
+	err.Message = strings.Join(messages, ", ")
+}
+
 type updateNoteOptions struct {
 	Body string `json:"body"`
 }
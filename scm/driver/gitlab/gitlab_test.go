@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
 )
 
 var mockHeaders = map[string]string{
@@ -198,3 +199,7 @@ func testRequest(res *scm.Response) func(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_Capabilities(t *testing.T) {
+	captest.Verify(t, NewDefault())
+}
@@ -27,8 +27,9 @@ type releaseInput struct {
 }
 
 func (s *releaseService) Find(ctx context.Context, repo string, id int) (*scm.Release, *scm.Response, error) {
-	// this could be implemented by List and filter but would be to expensive
-	panic("gitlab only allows to find a release by tag")
+	// gitlab only allows to find a release by tag. this could
+	// be implemented by List and filter but would be to expensive
+	return nil, nil, scm.ErrNotSupported
 }
 
 func (s *releaseService) FindByTag(ctx context.Context, repo, tag string) (*scm.Release, *scm.Response, error) {
@@ -58,8 +59,9 @@ func (s *releaseService) Create(ctx context.Context, repo string, input *scm.Rel
 }
 
 func (s *releaseService) Delete(ctx context.Context, repo string, id int) (*scm.Response, error) {
-	// this could be implemented by List and filter but would be to expensive
-	panic("gitlab only allows to delete a release by tag")
+	// gitlab only allows to delete a release by tag. this could
+	// be implemented by List and filter but would be to expensive
+	return nil, scm.ErrNotSupported
 }
 
 func (s *releaseService) DeleteByTag(ctx context.Context, repo, tag string) (*scm.Response, error) {
@@ -68,8 +70,9 @@ func (s *releaseService) DeleteByTag(ctx context.Context, repo, tag string) (*sc
 }
 
 func (s *releaseService) Update(ctx context.Context, repo string, id int, input *scm.ReleaseInput) (*scm.Release, *scm.Response, error) {
-	// this could be implemented by List and filter but would be to expensive
-	panic("gitlab only allows to update a release by tag")
+	// gitlab only allows to update a release by tag. this could
+	// be implemented by List and filter but would be to expensive
+	return nil, nil, scm.ErrNotSupported
 }
 
 func (s *releaseService) UpdateByTag(ctx context.Context, repo, tag string, input *scm.ReleaseInput) (*scm.Release, *scm.Response, error) {
@@ -240,6 +240,9 @@ func TestRepositoryNotFound(t *testing.T) {
 	if got, want := err.Error(), "Not Found"; got != want {
 		t.Errorf("Want error %q, got %q", want, got)
 	}
+	if got, want := err.(*scm.Error).Message, "Project Not Found"; got != want {
+		t.Errorf("Want error message %q, got %q", want, got)
+	}
 }
 
 func TestRepositoryList(t *testing.T) {
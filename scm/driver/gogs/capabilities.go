@@ -0,0 +1,112 @@
+package gogs
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// Gogs driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapContentCreate,
+		scm.CapContentDelete,
+		scm.CapContentList,
+		scm.CapContentUpdate,
+		scm.CapGitCompareCommits,
+		scm.CapGitCreateRef,
+		scm.CapGitDeleteRef,
+		scm.CapGitFindRef,
+		scm.CapGitFindTag,
+		scm.CapGitListChanges,
+		scm.CapGitListCommits,
+		scm.CapGitListTags,
+		scm.CapOrganizationAcceptOrganizationInvitation,
+		scm.CapOrganizationCreate,
+		scm.CapOrganizationDelete,
+		scm.CapOrganizationIsAdmin,
+		scm.CapOrganizationIsMember,
+		scm.CapOrganizationListMemberships,
+		scm.CapOrganizationListOrgMembers,
+		scm.CapOrganizationListPendingInvitations,
+		scm.CapOrganizationListTeamMembers,
+		scm.CapOrganizationListTeams,
+		scm.CapIssueAddLabel,
+		scm.CapIssueAssignIssue,
+		scm.CapIssueClearMilestone,
+		scm.CapIssueClose,
+		scm.CapIssueDeleteLabel,
+		scm.CapIssueEditComment,
+		scm.CapIssueFindComment,
+		scm.CapIssueListEvents,
+		scm.CapIssueListLabels,
+		scm.CapIssueLock,
+		scm.CapIssueReopen,
+		scm.CapIssueSearch,
+		scm.CapIssueSetMilestone,
+		scm.CapIssueUnassignIssue,
+		scm.CapIssueUnlock,
+		scm.CapMilestoneCreate,
+		scm.CapMilestoneDelete,
+		scm.CapMilestoneFind,
+		scm.CapMilestoneList,
+		scm.CapMilestoneUpdate,
+		scm.CapPullRequestAddLabel,
+		scm.CapPullRequestAssignIssue,
+		scm.CapPullRequestClearMilestone,
+		scm.CapPullRequestClose,
+		scm.CapPullRequestCreate,
+		scm.CapPullRequestCreateComment,
+		scm.CapPullRequestDeleteComment,
+		scm.CapPullRequestDeleteLabel,
+		scm.CapPullRequestDeletePullRequest,
+		scm.CapPullRequestEditComment,
+		scm.CapPullRequestFind,
+		scm.CapPullRequestFindComment,
+		scm.CapPullRequestList,
+		scm.CapPullRequestListChanges,
+		scm.CapPullRequestListComments,
+		scm.CapPullRequestListCommits,
+		scm.CapPullRequestListEvents,
+		scm.CapPullRequestListLabels,
+		scm.CapPullRequestMerge,
+		scm.CapPullRequestReopen,
+		scm.CapPullRequestRequestReview,
+		scm.CapPullRequestSetMilestone,
+		scm.CapPullRequestUnassignIssue,
+		scm.CapPullRequestUnrequestReview,
+		scm.CapPullRequestUpdate,
+		scm.CapRepositoryAddCollaborator,
+		scm.CapRepositoryCreate,
+		scm.CapRepositoryCreateStatus,
+		scm.CapRepositoryDelete,
+		scm.CapRepositoryFindCombinedStatus,
+		scm.CapRepositoryFindUserPermission,
+		scm.CapRepositoryFork,
+		scm.CapRepositoryIsCollaborator,
+		scm.CapRepositoryListCollaborators,
+		scm.CapRepositoryListLabels,
+		scm.CapRepositoryListStatus,
+		scm.CapRepositoryUpdateHook,
+		scm.CapReviewCreate,
+		scm.CapReviewDelete,
+		scm.CapReviewDismiss,
+		scm.CapReviewFind,
+		scm.CapReviewList,
+		scm.CapReviewListComments,
+		scm.CapReviewSubmit,
+		scm.CapReviewUpdate,
+		scm.CapUserAcceptInvitation,
+		scm.CapUserCreateToken,
+		scm.CapUserDeleteToken,
+		scm.CapUserListInvitations,
+	},
+	Webhooks: []scm.WebhookKind{
+		scm.WebhookKindBranch,
+		scm.WebhookKindIssue,
+		scm.WebhookKindIssueComment,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPullRequestComment,
+		scm.WebhookKindPush,
+		scm.WebhookKindRelease,
+		scm.WebhookKindTag,
+	},
+	Labels: scm.LabelsNotSupported,
+}
@@ -9,9 +9,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
-	"net/http"
 	"net/url"
 	"strings"
 
@@ -36,6 +34,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGogs
+	client.SetCapabilities(capabilities)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -86,9 +85,13 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	// if an error is encountered, unmarshal and return the
 	// error response.
 	if res.Status > 300 {
-		return res, errors.New(
-			http.StatusText(res.Status),
-		)
+		apiErr := scm.NewError(scm.DriverGogs, res)
+		out := new(errorResponse)
+		if json.NewDecoder(res.Body).Decode(out) == nil {
+			apiErr.Message = out.Message
+			apiErr.DocumentationURL = out.URL
+		}
+		return res, apiErr
 	}
 
 	if out == nil {
@@ -106,3 +109,10 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	// the json response.
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
+
+// errorResponse is the error response body returned by
+// the API.
+type errorResponse struct {
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
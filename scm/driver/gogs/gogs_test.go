@@ -5,7 +5,11 @@
 // Package gogs implements a Gogs client.
 package gogs
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
+)
 
 func TestClient(t *testing.T) {
 	client, err := New("https://try.gogs.io")
@@ -33,3 +37,11 @@ func TestClient_Error(t *testing.T) {
 		t.Errorf("Expect error when invalid URL")
 	}
 }
+
+func TestClient_Capabilities(t *testing.T) {
+	client, err := New("https://try.gogs.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	captest.Verify(t, client)
+}
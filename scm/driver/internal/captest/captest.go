@@ -0,0 +1,263 @@
+// Package captest verifies that the capabilities declared
+// by a driver are in sync with its implementation.
+package captest
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// Verify checks that the operations declared unsupported
+// by the client capabilities are exactly the methods of
+// the driver, in the package in the current directory,
+// that do nothing but validate their arguments and return
+// scm.ErrNotSupported, and that no method does nothing but
+// panic.
+func Verify(t *testing.T, client *scm.Client) {
+	t.Helper()
+	unsupported, panics, err := parseMethods(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caps := client.Capabilities()
+	if caps == nil {
+		t.Fatalf("Want capabilities declared for driver %s", client.Driver)
+	}
+	for _, op := range scm.Operations() {
+		if panics[op] {
+			t.Errorf("Want %s implemented or unsupported, got a method that panics", op)
+			continue
+		}
+		if !scm.Supports(client, op) && caps.Supports(op) {
+			// the service is not implemented.
+			continue
+		}
+		if got, want := caps.Supports(op), !unsupported[op]; got != want {
+			t.Errorf("Want %s supported %v, got %v", op, want, got)
+		}
+	}
+}
+
+// body is the kind of the body of a method.
+type body int
+
+const (
+	bodyImplemented body = iota
+	bodyNotSupported
+	bodyPanics
+)
+
+// parseMethods parses the Go files in the directory and
+// returns the operations implemented by methods that only
+// return scm.ErrNotSupported, and by methods that only
+// panic, such as the stubs left to implement.
+func parseMethods(dir string) (unsupported, panics map[scm.Capability]bool, err error) {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(match)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, err := parser.ParseFile(fset, match, src, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, file)
+	}
+
+	// map the service types to the client services they
+	// are assigned to, e.g. client.PullRequests = &pullService{},
+	// directly or through a variable, e.g. us := &userService{}
+	// then client.Users = us.
+	services := map[string]string{}
+	for _, file := range files {
+		vars := map[string]string{}
+		ast.Inspect(file, func(n ast.Node) bool {
+			assign, ok := n.(*ast.AssignStmt)
+			if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+				return true
+			}
+			typ := compositeType(assign.Rhs[0])
+			if ident, ok := assign.Rhs[0].(*ast.Ident); ok {
+				typ = vars[ident.Name]
+			}
+			switch lhs := assign.Lhs[0].(type) {
+			case *ast.Ident:
+				if typ != "" {
+					vars[lhs.Name] = typ
+				}
+			case *ast.SelectorExpr:
+				if typ != "" {
+					services[typ] = lhs.Sel.Name
+				}
+			}
+			return true
+		})
+	}
+
+	// collect the methods and embedded types of every type
+	// since service types embed other services, e.g. the
+	// pull request service embedding the issue service.
+	methods := map[string]map[string]body{}
+	embeds := map[string][]string{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil || decl.Body == nil || !decl.Name.IsExported() {
+					continue
+				}
+				typ := receiverType(decl.Recv.List[0].Type)
+				if methods[typ] == nil {
+					methods[typ] = map[string]body{}
+				}
+				methods[typ][decl.Name.Name] = bodyKind(decl.Body)
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					spec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					st, ok := spec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range st.Fields.List {
+						if len(field.Names) == 0 {
+							embeds[spec.Name.Name] = append(embeds[spec.Name.Name], receiverType(field.Type))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	unsupported = map[scm.Capability]bool{}
+	panics = map[scm.Capability]bool{}
+	for typ, service := range services {
+		for _, op := range scm.Operations() {
+			if op.Service() != service {
+				continue
+			}
+			switch resolve(methods, embeds, typ, op.Method()) {
+			case bodyNotSupported:
+				unsupported[op] = true
+			case bodyPanics:
+				panics[op] = true
+			}
+		}
+	}
+	return unsupported, panics, nil
+}
+
+// resolve returns the kind of the body of the method of the
+// type, or of the method promoted from an embedded type.
+func resolve(methods map[string]map[string]body, embeds map[string][]string, typ, method string) body {
+	if kind, ok := methods[typ][method]; ok {
+		return kind
+	}
+	for _, embedded := range embeds[typ] {
+		if kind := resolve(methods, embeds, embedded, method); kind != bodyImplemented {
+			return kind
+		}
+	}
+	return bodyImplemented
+}
+
+// compositeType returns the type name of a &T{} expression.
+func compositeType(expr ast.Expr) string {
+	unary, ok := expr.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return ""
+	}
+	lit, ok := unary.X.(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+	ident, ok := lit.Type.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return ident.Name
+}
+
+// receiverType returns the type name of a method receiver.
+func receiverType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// bodyKind returns whether the function body consists of a
+// return statement whose last result is scm.ErrNotSupported,
+// or of a call to panic, optionally preceded by statements
+// validating the arguments.
+func bodyKind(block *ast.BlockStmt) body {
+	if len(block.List) == 0 {
+		return bodyImplemented
+	}
+	last := len(block.List) - 1
+	for _, stmt := range block.List[:last] {
+		if !isValidation(stmt) {
+			return bodyImplemented
+		}
+	}
+	switch stmt := block.List[last].(type) {
+	case *ast.ExprStmt:
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			return bodyImplemented
+		}
+		if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "panic" {
+			return bodyPanics
+		}
+	case *ast.ReturnStmt:
+		if len(stmt.Results) == 0 {
+			return bodyImplemented
+		}
+		sel, ok := stmt.Results[len(stmt.Results)-1].(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "ErrNotSupported" {
+			return bodyImplemented
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "scm" {
+			return bodyNotSupported
+		}
+	}
+	return bodyImplemented
+}
+
+// isValidation returns whether the statement validates the
+// arguments, such as parsing them and returning the error
+// if they are invalid.
+func isValidation(stmt ast.Stmt) bool {
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		return true
+	case *ast.IfStmt:
+		if stmt.Else != nil || len(stmt.Body.List) == 0 {
+			return false
+		}
+		_, ok := stmt.Body.List[len(stmt.Body.List)-1].(*ast.ReturnStmt)
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package stash
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// capabilities describes the features supported by the
+// Bitbucket Server driver.
+var capabilities = &scm.Capabilities{
+	Unsupported: []scm.Capability{
+		scm.CapContentDelete,
+		scm.CapGitListCommits,
+		scm.CapOrganizationAcceptOrganizationInvitation,
+		scm.CapOrganizationCreate,
+		scm.CapOrganizationDelete,
+		scm.CapOrganizationFind,
+		scm.CapOrganizationListMemberships,
+		scm.CapOrganizationListPendingInvitations,
+		scm.CapOrganizationListTeamMembers,
+		scm.CapOrganizationListTeams,
+		scm.CapIssueAssignIssue,
+		scm.CapIssueClearMilestone,
+		scm.CapIssueClose,
+		scm.CapIssueCreate,
+		scm.CapIssueDeleteComment,
+		scm.CapIssueEditComment,
+		scm.CapIssueFind,
+		scm.CapIssueFindComment,
+		scm.CapIssueList,
+		scm.CapIssueListComments,
+		scm.CapIssueListEvents,
+		scm.CapIssueListLabels,
+		scm.CapIssueLock,
+		scm.CapIssueReopen,
+		scm.CapIssueSearch,
+		scm.CapIssueSetMilestone,
+		scm.CapIssueUnassignIssue,
+		scm.CapIssueUnlock,
+		scm.CapMilestoneCreate,
+		scm.CapMilestoneDelete,
+		scm.CapMilestoneFind,
+		scm.CapMilestoneList,
+		scm.CapMilestoneUpdate,
+		scm.CapPullRequestClearMilestone,
+		scm.CapPullRequestListCommits,
+		scm.CapPullRequestListEvents,
+		scm.CapPullRequestSetMilestone,
+		scm.CapRepositoryDelete,
+		scm.CapRepositoryListUser,
+		scm.CapRepositoryUpdateHook,
+		scm.CapReviewCreate,
+		scm.CapReviewDelete,
+		scm.CapReviewDismiss,
+		scm.CapReviewFind,
+		scm.CapReviewList,
+		scm.CapReviewListComments,
+		scm.CapReviewSubmit,
+		scm.CapReviewUpdate,
+		scm.CapUserCreateToken,
+		scm.CapUserDeleteToken,
+	},
+	Webhooks: []scm.WebhookKind{
+		scm.WebhookKindBranch,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPullRequestComment,
+		scm.WebhookKindPush,
+		scm.WebhookKindReview,
+		scm.WebhookKindTag,
+	},
+	MergeMethods: []string{
+		scm.MergeMethodMerge,
+	},
+	Labels: scm.LabelsComments,
+}
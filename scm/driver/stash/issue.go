@@ -34,13 +34,9 @@ func (s *issueService) ListEvents(context.Context, string, int, *scm.ListOptions
 }
 
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	// Get all comments, parse out labels (removing and added based off time)
-	cs, res, err := s.ListComments(ctx, repo, number, opts)
-	if err == nil {
-		l, err := labels.ConvertLabelComments(cs)
-		return l, res, err
-	}
-	return nil, res, err
+	// the labels are parsed out of the comments, which
+	// cannot be listed.
+	return nil, nil, scm.ErrNotSupported
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
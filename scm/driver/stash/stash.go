@@ -40,6 +40,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverStash
+	client.SetCapabilities(capabilities)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -112,13 +113,27 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	}
 	defer res.Body.Close()
 
+	// parse the bitbucket server request id.
+	res.ID = res.Header.Get("X-Arequestid")
+
 	// if an error is encountered, unmarshal and return the
 	// error response.
 	if res.Status > 300 {
-		err := new(Error)
+		apiErr := scm.NewError(scm.DriverStash, res)
+		out := new(Error)
 		// nolint
-		json.NewDecoder(res.Body).Decode(err) // #nosec
-		return res, err
+		json.NewDecoder(res.Body).Decode(out) // #nosec
+		apiErr.Message = out.Error()
+		apiErr.Err = out
+		for _, e := range out.Errors {
+			if e.Context != "" {
+				apiErr.Errors = append(apiErr.Errors, scm.FieldError{
+					Field:   e.Context,
+					Message: e.Message,
+				})
+			}
+		}
+		return res, apiErr
 	}
 
 	if out == nil {
@@ -151,6 +166,7 @@ type pagination struct {
 type Error struct {
 	Errors []struct {
 		Message         string `json:"message"`
+		Context         string `json:"context"`
 		ExceptionName   string `json:"exceptionName"`
 		CurrentVersion  int    `json:"currentVersion"`
 		ExpectedVersion int    `json:"expectedVersion"`
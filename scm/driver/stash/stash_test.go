@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/captest"
 )
 
 func TestClient(t *testing.T) {
@@ -48,3 +49,7 @@ func testRequest(res *scm.Response) func(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_Capabilities(t *testing.T) {
+	captest.Verify(t, NewDefault())
+}
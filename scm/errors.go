@@ -1,7 +1,9 @@
 package scm
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -60,3 +62,142 @@ type MissingHeader struct {
 func (e MissingHeader) Error() string {
 	return fmt.Sprintf("400 Bad Request: Missing Header: %s", e.Header)
 }
+
+// Error represents an error response returned by the git
+// provider API.
+type Error struct {
+	// Driver identifies the provider that returned the error.
+	Driver Driver
+
+	// Status is the HTTP status code of the response.
+	Status int
+
+	// Message is the error message returned by the provider.
+	Message string
+
+	// DocumentationURL optionally links to the provider
+	// documentation describing the error.
+	DocumentationURL string
+
+	// Errors holds the validation errors returned by the
+	// provider, if any.
+	Errors []FieldError
+
+	// RequestID is the provider request ID of the response.
+	RequestID string
+
+	// Err optionally holds the driver specific error the
+	// Error was created from.
+	Err error
+}
+
+// FieldError represents a validation error for a single
+// field of a request.
+type FieldError struct {
+	Resource string
+	Field    string
+	Code     string
+	Message  string
+}
+
+// NewError returns a new Error for the given failed
+// response.
+func NewError(driver Driver, res *Response) *Error {
+	return &Error{
+		Driver:    driver,
+		Status:    res.Status,
+		RequestID: res.ID,
+	}
+}
+
+// Error returns the driver specific error message if
+// available, otherwise the HTTP status text followed by the
+// message of the provider, unless the message only restates
+// the status. Without a message, the error string matches
+// ErrNotFound for missing resources.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	text := http.StatusText(e.Status)
+	switch {
+	case text == "":
+		return e.Message
+	case e.Message == "" || strings.Contains(e.Message, text):
+		return text
+	}
+	return text + ": " + e.Message
+}
+
+// Unwrap returns the driver specific error, if any.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrNotFound and the
+// error was caused by a missing resource.
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.Status == http.StatusNotFound
+}
+
+// Error implements error
+var _ error = (*Error)(nil)
+
+// ErrorStatus returns the HTTP status of the error if it
+// is, or wraps, an Error. Otherwise it returns zero.
+func ErrorStatus(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Status
+	}
+	return 0
+}
+
+// IsConflict returns true if the request conflicted with
+// the current state of the resource.
+func IsConflict(err error) bool {
+	return ErrorStatus(err) == http.StatusConflict
+}
+
+// IsForbidden returns true if the request was forbidden.
+func IsForbidden(err error) bool {
+	return ErrorStatus(err) == http.StatusForbidden
+}
+
+// IsUnauthorized returns true if the request was not
+// authenticated.
+func IsUnauthorized(err error) bool {
+	return ErrorStatus(err) == http.StatusUnauthorized
+}
+
+// IsRateLimited returns true if the request was rejected
+// because the rate limit was exceeded.
+func IsRateLimited(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	switch e.Status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return strings.Contains(strings.ToLower(e.Message), "rate limit")
+	}
+	return false
+}
+
+// IsValidation returns true if the request was rejected
+// because it failed validation.
+func IsValidation(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	switch e.Status {
+	case http.StatusUnprocessableEntity:
+		return true
+	case http.StatusBadRequest:
+		return len(e.Errors) > 0
+	}
+	return false
+}
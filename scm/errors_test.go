@@ -0,0 +1,74 @@
+package scm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	err := &Error{Status: 404}
+	if got, want := err.Error(), "Not Found"; got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+	err = &Error{Status: 404, Message: "Repository dev/null not found"}
+	if got, want := err.Error(), "Not Found: Repository dev/null not found"; got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+	err = &Error{Status: 404, Message: "Project Not Found"}
+	if got, want := err.Error(), "Not Found"; got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Want error to match ErrNotFound")
+	}
+	if !IsScmNotFound(fmt.Errorf("wrapped: %w", err)) {
+		t.Errorf("Want wrapped error to be not found")
+	}
+
+	cause := errors.New("Project dev does not exist.")
+	err = &Error{Status: 409, Err: cause}
+	if got, want := err.Error(), cause.Error(); got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Want error to wrap the driver error")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("Want conflict error not to match ErrNotFound")
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		err        error
+		conflict   bool
+		forbidden  bool
+		rateLimit  bool
+		validation bool
+	}{
+		{err: &Error{Status: 409}, conflict: true},
+		{err: &Error{Status: 403, Message: "Resource not accessible by integration"}, forbidden: true},
+		{err: &Error{Status: 403, Message: "API rate limit exceeded for user ID 1."}, forbidden: true, rateLimit: true},
+		{err: &Error{Status: 429}, rateLimit: true},
+		{err: &Error{Status: 422}, validation: true},
+		{err: &Error{Status: 400, Errors: []FieldError{{Field: "title"}}}, validation: true},
+		{err: &Error{Status: 400}},
+		{err: errors.New("Conflict")},
+		{err: nil},
+	}
+	for i, test := range tests {
+		if got, want := IsConflict(test.err), test.conflict; got != want {
+			t.Errorf("%d: Want IsConflict %v, got %v", i, want, got)
+		}
+		if got, want := IsForbidden(test.err), test.forbidden; got != want {
+			t.Errorf("%d: Want IsForbidden %v, got %v", i, want, got)
+		}
+		if got, want := IsRateLimited(test.err), test.rateLimit; got != want {
+			t.Errorf("%d: Want IsRateLimited %v, got %v", i, want, got)
+		}
+		if got, want := IsValidation(test.err), test.validation; got != want {
+			t.Errorf("%d: Want IsValidation %v, got %v", i, want, got)
+		}
+	}
+}
@@ -747,3 +747,26 @@ func ExampleWebhook_lookupSecret() {
 		log.Fatal(err)
 	}
 }
+
+func ExampleIterate() {
+	client, err := github.New("https://api.github.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	list := func(ctx context.Context, opts *scm.ListOptions) ([]*scm.Issue, *scm.Response, error) {
+		return client.Issues.List(ctx, "octocat/hello-world", scm.IssueListOptions{
+			Page: opts.Page,
+			Size: opts.Size,
+			Open: true,
+		})
+	}
+
+	opts := &scm.PageOptions{ListOptions: scm.ListOptions{Size: 100}}
+	for issue, err := range scm.Iterate(ctx, list, opts) {
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(issue.Number, issue.Title)
+	}
+}
@@ -0,0 +1,417 @@
+package factory
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/transport"
+)
+
+// Auth methods of the ServerConfig.
+const (
+	// AuthMethodToken authenticates using an OAuth token or
+	// personal access token. It is the default if a token is
+	// configured.
+	AuthMethodToken = "token"
+
+	// AuthMethodBasic authenticates using a username and
+	// password.
+	AuthMethodBasic = "basic"
+
+	// AuthMethodOAuth2 authenticates using OAuth2 client
+	// credentials.
+	AuthMethodOAuth2 = "oauth2"
+
+	// AuthMethodGitHubApp authenticates as a GitHub App or
+	// GitHub App installation.
+	AuthMethodGitHubApp = "github-app"
+
+	// AuthMethodNone sends unauthenticated requests.
+	AuthMethodNone = "none"
+)
+
+type (
+	// Config lists the git servers used by a ClientPool.
+	Config struct {
+		Servers []*ServerConfig `json:"servers" yaml:"servers"`
+	}
+
+	// ServerConfig configures the client of a git server.
+	ServerConfig struct {
+		// Name identifies the server. Defaults to the host
+		// of the URL.
+		Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+		// Driver is the name of a registered driver. If
+		// empty, the driver is detected, see DetectDriver.
+		Driver string `json:"driver,omitempty" yaml:"driver,omitempty"`
+
+		// URL is the URL of the server.
+		URL string `json:"url" yaml:"url"`
+
+		// Hosts lists additional hostnames of repository
+		// URLs served by the server, such as a separate SSH
+		// host.
+		Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+
+		// Auth configures the authentication.
+		Auth AuthConfig `json:"auth,omitempty" yaml:"auth,omitempty"`
+
+		// Proxy is the URL of the HTTP or SOCKS5 proxy. If
+		// empty, the proxy environment variables are used.
+		Proxy string `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+
+		// CABundle is the path of a PEM encoded bundle of
+		// certificate authorities trusted in addition to the
+		// system ones.
+		CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+
+		// InsecureSkipVerify disables the verification of
+		// the server certificate.
+		InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty"`
+
+		// Retry configures retries of failed requests.
+		Retry *RetryConfig `json:"retry,omitempty" yaml:"retry,omitempty"`
+	}
+
+	// AuthConfig configures the authentication of a client.
+	// Secrets can be configured as literals, environment
+	// variable references or files; files take precedence
+	// over environment variables, which take precedence
+	// over literals.
+	AuthConfig struct {
+		// Method is one of token, basic, oauth2, github-app
+		// or none. Defaults to token if a token is configured
+		// and none otherwise.
+		Method string `json:"method,omitempty" yaml:"method,omitempty"`
+
+		Username     string `json:"username,omitempty" yaml:"username,omitempty"`
+		UsernameEnv  string `json:"usernameEnv,omitempty" yaml:"usernameEnv,omitempty"`
+		Token        string `json:"token,omitempty" yaml:"token,omitempty"`
+		TokenEnv     string `json:"tokenEnv,omitempty" yaml:"tokenEnv,omitempty"`
+		TokenFile    string `json:"tokenFile,omitempty" yaml:"tokenFile,omitempty"`
+		Password     string `json:"password,omitempty" yaml:"password,omitempty"`
+		PasswordEnv  string `json:"passwordEnv,omitempty" yaml:"passwordEnv,omitempty"`
+		PasswordFile string `json:"passwordFile,omitempty" yaml:"passwordFile,omitempty"`
+
+		// OAuth2 client credentials. The token URL defaults
+		// to the one of Bitbucket Cloud.
+		ClientID        string `json:"clientID,omitempty" yaml:"clientID,omitempty"`
+		ClientSecret    string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+		ClientSecretEnv string `json:"clientSecretEnv,omitempty" yaml:"clientSecretEnv,omitempty"`
+		TokenURL        string `json:"tokenURL,omitempty" yaml:"tokenURL,omitempty"`
+
+		// GitHub App credentials. The installation ID is
+		// optional.
+		AppID          int64  `json:"appID,omitempty" yaml:"appID,omitempty"`
+		InstallationID int64  `json:"installationID,omitempty" yaml:"installationID,omitempty"`
+		PrivateKeyFile string `json:"privateKeyFile,omitempty" yaml:"privateKeyFile,omitempty"`
+	}
+
+	// RetryConfig configures the scm.RetryPolicy of a
+	// client. Durations are strings such as 500ms or 1m.
+	RetryConfig struct {
+		MaxAttempts        int           `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+		MinBackoff         time.Duration `json:"minBackoff,omitempty" yaml:"minBackoff,omitempty"`
+		MaxBackoff         time.Duration `json:"maxBackoff,omitempty" yaml:"maxBackoff,omitempty"`
+		MaxRetryAfter      time.Duration `json:"maxRetryAfter,omitempty" yaml:"maxRetryAfter,omitempty"`
+		RetryNonIdempotent bool          `json:"retryNonIdempotent,omitempty" yaml:"retryNonIdempotent,omitempty"`
+	}
+)
+
+// LoadConfig reads the YAML or JSON configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	// resolve relative paths against the directory of the
+	// configuration file.
+	dir := filepath.Dir(path)
+	for _, server := range config.Servers {
+		server.CABundle = resolvePath(dir, server.CABundle)
+		server.Auth.TokenFile = resolvePath(dir, server.Auth.TokenFile)
+		server.Auth.PasswordFile = resolvePath(dir, server.Auth.PasswordFile)
+		server.Auth.PrivateKeyFile = resolvePath(dir, server.Auth.PrivateKeyFile)
+	}
+	return config, nil
+}
+
+// ParseConfig parses the YAML or JSON configuration.
+func ParseConfig(data []byte) (*Config, error) {
+	config := new(Config)
+	// JSON is a subset of YAML so both are parsed as YAML.
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks the configuration.
+func (c *Config) Validate() error {
+	names := map[string]bool{}
+	for i, server := range c.Servers {
+		if server == nil {
+			return fmt.Errorf("server %d is empty", i)
+		}
+		if server.URL == "" {
+			return fmt.Errorf("server %d has no url", i)
+		}
+		u, err := url.Parse(server.URL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("server %d has an invalid url: %s", i, server.URL)
+		}
+		name := server.name()
+		if names[name] {
+			return fmt.Errorf("duplicate server name: %s", name)
+		}
+		names[name] = true
+		switch server.Auth.Method {
+		case "", AuthMethodToken, AuthMethodBasic, AuthMethodOAuth2, AuthMethodGitHubApp, AuthMethodNone:
+		default:
+			return fmt.Errorf("server %s has an unsupported auth method: %s", name, server.Auth.Method)
+		}
+	}
+	return nil
+}
+
+// name returns the name of the server, defaulting to the
+// host of its URL.
+func (s *ServerConfig) name() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if u, err := url.Parse(s.URL); err == nil {
+		return u.Host
+	}
+	return s.URL
+}
+
+// NewClient creates a client for the server.
+func (s *ServerConfig) NewClient(opts ...ClientOptionFunc) (*scm.Client, error) {
+	base, err := s.baseTransport()
+	if err != nil {
+		return nil, err
+	}
+	if s.Retry != nil {
+		opts = append([]ClientOptionFunc{SetRetryPolicy(s.Retry.policy())}, opts...)
+	}
+
+	driver := s.Driver
+	if driver == "" {
+		driver, err = detectDriver(s.URL, base)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	auth := &s.Auth
+	username, err := secret(auth.Username, auth.UsernameEnv, "")
+	if err != nil {
+		return nil, err
+	}
+	token, err := secret(auth.Token, auth.TokenEnv, auth.TokenFile)
+	if err != nil {
+		return nil, err
+	}
+	method := auth.Method
+	if method == "" {
+		method = AuthMethodNone
+		if token != "" {
+			method = AuthMethodToken
+		}
+	}
+
+	if method == AuthMethodGitHubApp {
+		key, err := os.ReadFile(auth.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the GitHub App private key of server %s: %w", s.name(), err)
+		}
+		return newGitHubAppClient(s.URL, auth.AppID, auth.InstallationID, key, base, opts...)
+	}
+	if method == AuthMethodBasic && driver == "gitea" {
+		// the gitea SDK needs the credentials too.
+		password, err := secret(auth.Password, auth.PasswordEnv, auth.PasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		client, err := NewClientWithBasicAuth(driver, s.URL, username, password, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if base != nil {
+			setBaseTransport(client, base)
+		}
+		return client, nil
+	}
+
+	authOptions := &AuthOptions{username: username}
+	if method == AuthMethodToken {
+		if token == "" {
+			return nil, fmt.Errorf("no token configured for server %s", s.name())
+		}
+		authOptions.oauthToken = token
+	}
+	// the options are applied once the client is
+	// authenticated, so they can override the HTTP client.
+	client, err := newClient(driver, s.URL, authOptions)
+	if err != nil {
+		return nil, err
+	}
+	switch method {
+	case AuthMethodBasic:
+		password, err := secret(auth.Password, auth.PasswordEnv, auth.PasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		client.Client = &http.Client{
+			Transport: &transport.BasicAuth{
+				Username: username,
+				Password: password,
+			},
+		}
+	case AuthMethodOAuth2:
+		clientSecret, err := secret(auth.ClientSecret, auth.ClientSecretEnv, "")
+		if err != nil {
+			return nil, err
+		}
+		if auth.ClientID == "" || clientSecret == "" {
+			return nil, fmt.Errorf("no OAuth2 client credentials configured for server %s", s.name())
+		}
+		config := clientcredentials.Config{
+			ClientID:     auth.ClientID,
+			ClientSecret: clientSecret,
+			TokenURL:     auth.TokenURL,
+		}
+		if config.TokenURL == "" {
+			config.TokenURL = "https://bitbucket.org/site/oauth2/access_token"
+		}
+		ctx := context.Background()
+		if base != nil {
+			// request the tokens using the base transport.
+			ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: base})
+		}
+		client.Client = config.Client(ctx)
+	}
+	if base != nil {
+		setBaseTransport(client, base)
+	}
+	for _, o := range opts {
+		o(client)
+	}
+	return client, nil
+}
+
+// baseTransport returns the transport configured with the
+// proxy and certificate authorities of the server, or nil
+// if the default transport can be used.
+func (s *ServerConfig) baseTransport() (http.RoundTripper, error) {
+	if s.Proxy == "" && s.CABundle == "" && !s.InsecureSkipVerify {
+		return nil, nil
+	}
+	base := newTransport()
+	if s.Proxy != "" {
+		proxy, err := url.Parse(s.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy of server %s: %w", s.name(), err)
+		}
+		base.Proxy = http.ProxyURL(proxy)
+	}
+	if s.CABundle != "" || s.InsecureSkipVerify {
+		base.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: s.InsecureSkipVerify, // nolint
+		}
+	}
+	if s.CABundle != "" {
+		pem, err := os.ReadFile(s.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA bundle of server %s: %w", s.name(), err)
+		}
+		pool, err := certPool(pem)
+		if err != nil {
+			return nil, fmt.Errorf("%w of server %s", err, s.name())
+		}
+		base.TLSClientConfig.RootCAs = pool
+	}
+	return base, nil
+}
+
+// policy returns the retry policy.
+func (r *RetryConfig) policy() *scm.RetryPolicy {
+	return &scm.RetryPolicy{
+		MaxAttempts:        r.MaxAttempts,
+		MinBackoff:         r.MinBackoff,
+		MaxBackoff:         r.MaxBackoff,
+		MaxRetryAfter:      r.MaxRetryAfter,
+		RetryNonIdempotent: r.RetryNonIdempotent,
+	}
+}
+
+// detectDriver detects the driver of the server using the
+// base transport.
+func detectDriver(serverURL string, base http.RoundTripper) (string, error) {
+	if base == nil {
+		return detect(DefaultDetector, serverURL)
+	}
+	return detect(&DriverDetector{Client: &http.Client{Transport: base, Timeout: 10 * time.Second}}, serverURL)
+}
+
+// setBaseTransport sets the base transport of the client.
+// If the client already has a transport, such as the one
+// adding the credentials, the base is set as the Base
+// field of the innermost transport lacking one.
+func setBaseTransport(client *scm.Client, base http.RoundTripper) {
+	replaceBaseTransport(client, func(rt http.RoundTripper) http.RoundTripper {
+		if rt == nil {
+			return base
+		}
+		return rt
+	})
+}
+
+// secret returns the value of the file, the environment
+// variable or the literal, in that order of precedence.
+func secret(literal, env, file string) (string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if env != "" {
+		if value := os.Getenv(env); value != "" {
+			return value, nil
+		}
+	}
+	return literal, nil
+}
+
+// resolvePath resolves the relative path against the
+// directory.
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,189 @@
+package factory
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/transport"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig("testdata/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, config.Servers, 3) {
+		assert.Equal(t, "github.com", config.Servers[0].name())
+		assert.Equal(t, &RetryConfig{MaxAttempts: 3, MinBackoff: 500 * time.Millisecond, MaxBackoff: time.Minute}, config.Servers[0].Retry)
+		assert.Equal(t, "testdata/gitlab-token", config.Servers[1].Auth.TokenFile)
+	}
+
+	config, err = LoadConfig("testdata/config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, config.Servers, 1) {
+		assert.Equal(t, AuthMethodBasic, config.Servers[0].Auth.Method)
+		assert.Equal(t, time.Second, config.Servers[0].Retry.MinBackoff)
+	}
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"servers: [{driver: github}]", "server 0 has no url"},
+		{"servers: [{url: github.com}]", "server 0 has an invalid url: github.com"},
+		{"servers: [{url: 'https://a.com'}, {url: 'https://a.com'}]", "duplicate server name: a.com"},
+		{"servers: [{url: 'https://a.com', auth: {method: ntlm}}]", "server a.com has an unsupported auth method: ntlm"},
+	}
+	for _, test := range tests {
+		_, err := ParseConfig([]byte(test.config))
+		assert.EqualError(t, err, test.want)
+	}
+}
+
+func TestClientPool(t *testing.T) {
+	t.Setenv("TEST_GITHUB_TOKEN", "s3cr3t")
+
+	pool, err := NewClientPoolFromFile("testdata/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := pool.ForRepoURL("https://github.com/jenkins-x/go-scm.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverGithub, client.Driver)
+	assert.Equal(t, 3, client.Retry.MaxAttempts)
+	token, err := client.Client.Transport.(*oauth2.Transport).Source.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "s3cr3t", token.AccessToken)
+
+	again, err := pool.ForHost("github.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Same(t, client, again, "want clients reused")
+
+	// the longest matching path wins.
+	for _, repoURL := range []string{
+		"https://git.example.com/gitlab/org/repo.git",
+		"git@ssh.git.example.com:org/repo.git",
+		"ssh://git@ssh.git.example.com:2222/org/repo.git",
+	} {
+		client, err = pool.ForRepoURL(repoURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, scm.DriverGitlab, client.Driver, repoURL)
+	}
+	auth := client.Client.Transport.(*transport.PrivateToken)
+	assert.Equal(t, "abc123", auth.Token)
+	if base, ok := auth.Base.(*http.Transport); assert.True(t, ok, "want proxy transport") {
+		proxy, _ := base.Proxy(&http.Request{})
+		assert.Equal(t, "http://proxy.example.com:3128", proxy.String())
+	}
+
+	client, err = pool.ForRepoURL("https://git.example.com/org/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverGogs, client.Driver)
+
+	client, err = pool.Client("gogs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverGogs, client.Driver)
+
+	_, err = pool.ForRepoURL("https://unknown.example.com/org/repo")
+	if !errors.Is(err, ErrServerNotConfigured) {
+		t.Errorf("Want ErrServerNotConfigured, got %v", err)
+	}
+}
+
+func TestClientPool_SlowDetection(t *testing.T) {
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	var hits int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&hits, 1)
+		once.Do(func() { close(probing) })
+		<-release
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"message":"401 Unauthorized"}`)
+	}))
+	defer slow.Close()
+
+	pool, err := NewClientPool(&Config{Servers: []*ServerConfig{
+		{Name: "slow", URL: slow.URL},
+		{Name: "github", Driver: "github", URL: "https://github.com"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clients := make([]*scm.Client, 2)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clients[i], _ = pool.Client("slow")
+		}()
+	}
+	<-probing
+	client, err := pool.Client("github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverGithub, client.Driver, "want other servers not blocked by the detection")
+
+	close(release)
+	wg.Wait()
+	if assert.NotNil(t, clients[0]) {
+		assert.Equal(t, scm.DriverGitlab, clients[0].Driver)
+	}
+	assert.Same(t, clients[0], clients[1], "want the client created once")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestServerConfig_BasicAuth(t *testing.T) {
+	server := &ServerConfig{
+		Driver: "github",
+		URL:    "https://github.example.com",
+		Auth: AuthConfig{
+			Method:      AuthMethodBasic,
+			Username:    "jcitizen",
+			PasswordEnv: "TEST_GITHUB_PASSWORD",
+		},
+	}
+	t.Setenv("TEST_GITHUB_PASSWORD", "passw0rd")
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := client.Client.Transport.(*transport.BasicAuth)
+	assert.Equal(t, "jcitizen", auth.Username)
+	assert.Equal(t, "passw0rd", auth.Password)
+	assert.Equal(t, "jcitizen", client.Username)
+}
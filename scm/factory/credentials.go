@@ -0,0 +1,400 @@
+package factory
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Credentials holds the credentials of a git server.
+type Credentials struct {
+	Username string
+	Token    string
+}
+
+// CredentialProvider resolves the credentials of a git
+// server. It returns nil credentials and no error if it
+// has no credentials for the server.
+type CredentialProvider interface {
+	Credentials(ctx context.Context, serverURL string) (*Credentials, error)
+}
+
+// DefaultCredentialProvider is the credential provider used
+// by FromRepoURL and NewClientFromEnvironment when no token
+// is supplied.
+var DefaultCredentialProvider CredentialProvider = CredentialChain{
+	&GitCredentialHelper{},
+	&Netrc{},
+	&GitCredentialStore{},
+	&EnvCredentials{},
+}
+
+// CredentialChain is a CredentialProvider returning the
+// credentials of the first provider having credentials for
+// the server. Errors of the providers are ignored, unless
+// no provider has credentials.
+type CredentialChain []CredentialProvider
+
+// Credentials returns the credentials of the first provider
+// having credentials for the server.
+func (c CredentialChain) Credentials(ctx context.Context, serverURL string) (*Credentials, error) {
+	var errs []error
+	for _, provider := range c {
+		credentials, err := provider.Credentials(ctx, serverURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if credentials != nil && credentials.Token != "" {
+			return credentials, nil
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// GitCredentialHelper resolves credentials using git
+// credential fill, which queries the credential helpers
+// configured for git. Git never prompts for credentials.
+type GitCredentialHelper struct {
+	// Command is the git executable. Defaults to git.
+	Command string
+
+	// Timeout is the time given to git to return the
+	// credentials. Defaults to ten seconds.
+	Timeout time.Duration
+}
+
+// Credentials returns the credentials of the configured
+// git credential helpers.
+func (g *GitCredentialHelper) Credentials(ctx context.Context, serverURL string) (*Credentials, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Host == "" {
+		return nil, err
+	}
+	command := g.Command
+	if command == "" {
+		command = "git"
+	}
+	if _, err := exec.LookPath(command); err != nil {
+		// git is not installed.
+		return nil, nil
+	}
+	var input bytes.Buffer
+	input.WriteString("protocol=" + u.Scheme + "\n")
+	input.WriteString("host=" + u.Host + "\n")
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		input.WriteString("path=" + path + "\n")
+	}
+	input.WriteString("\n")
+
+	timeout := g.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, command, "credential", "fill")
+	cmd.WaitDelay = time.Second
+	cmd.Stdin = &input
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.Output()
+	if err != nil {
+		// git exits with an error when no helper has
+		// credentials since it cannot prompt for them.
+		return nil, nil
+	}
+	credentials := new(Credentials)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+		switch key {
+		case "username":
+			credentials.Username = value
+		case "password":
+			credentials.Token = value
+		}
+	}
+	if credentials.Token == "" {
+		return nil, nil
+	}
+	return credentials, nil
+}
+
+// Netrc resolves credentials from a .netrc file.
+type Netrc struct {
+	// Path is the path of the file. Defaults to $NETRC or
+	// ~/.netrc.
+	Path string
+}
+
+// Credentials returns the login and password of the
+// machine of the server, or of the default entry.
+func (n *Netrc) Credentials(_ context.Context, serverURL string) (*Credentials, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Host == "" {
+		return nil, err
+	}
+	path := n.Path
+	if path == "" {
+		path = os.Getenv("NETRC")
+	}
+	if path == "" {
+		path = homePath(".netrc")
+	}
+	data, err := readOptionalFile(path)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var (
+		found    *Credentials
+		fallback *Credentials
+		current  *Credentials
+		tokens   []string
+		macro    bool
+	)
+	// tokens may span lines, except for macro definitions
+	// which run up to the next empty line.
+	for _, line := range strings.Split(string(data), "\n") {
+		if macro {
+			macro = strings.TrimSpace(line) != ""
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			if fields[i] == "macdef" {
+				macro = true
+				fields = fields[:i]
+				break
+			}
+		}
+		tokens = append(tokens, fields...)
+		if macro {
+			tokens = append(tokens, "macdef")
+		}
+	}
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "machine":
+			current = nil
+			if i+1 < len(tokens) {
+				i++
+				if strings.EqualFold(tokens[i], u.Hostname()) || strings.EqualFold(tokens[i], u.Host) {
+					current = new(Credentials)
+					if found == nil {
+						found = current
+					}
+				}
+			}
+		case "default":
+			current = new(Credentials)
+			if fallback == nil {
+				fallback = current
+			}
+		case "macdef":
+			current = nil
+		case "login", "password", "account":
+			if i+1 >= len(tokens) {
+				break
+			}
+			i++
+			if current == nil {
+				continue
+			}
+			switch tokens[i-1] {
+			case "login":
+				current.Username = tokens[i]
+			case "password":
+				current.Token = tokens[i]
+			}
+		}
+	}
+	return pick(found, fallback), nil
+}
+
+// GitCredentialStore resolves credentials from the file
+// written by the git credential store helper.
+type GitCredentialStore struct {
+	// Path is the path of the file. Defaults to
+	// ~/.git-credentials, or else
+	// $XDG_CONFIG_HOME/git/credentials.
+	Path string
+}
+
+// Credentials returns the credentials stored for the
+// server. Credentials stored for a path take precedence.
+func (g *GitCredentialStore) Credentials(_ context.Context, serverURL string) (*Credentials, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Host == "" {
+		return nil, err
+	}
+	paths := []string{g.Path}
+	if g.Path == "" {
+		config := os.Getenv("XDG_CONFIG_HOME")
+		if config == "" {
+			config = homePath(".config")
+		}
+		paths = []string{homePath(".git-credentials"), filepath.Join(config, "git", "credentials")}
+	}
+	for _, path := range paths {
+		data, err := readOptionalFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var found *Credentials
+		longest := -1
+		for _, line := range strings.Split(string(data), "\n") {
+			stored, err := url.Parse(strings.TrimSpace(line))
+			if err != nil || stored.User == nil || !strings.EqualFold(stored.Host, u.Host) ||
+				(stored.Scheme != "" && u.Scheme != "" && stored.Scheme != u.Scheme) {
+				continue
+			}
+			prefix := strings.Trim(stored.Path, "/")
+			path := strings.Trim(u.Path, "/")
+			if prefix != "" && path != prefix && !strings.HasPrefix(path, prefix+"/") {
+				continue
+			}
+			if len(prefix) > longest {
+				password, _ := stored.User.Password()
+				found = &Credentials{Username: stored.User.Username(), Token: password}
+				longest = len(prefix)
+			}
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, nil
+}
+
+// EnvCredentials resolves credentials from environment
+// variables.
+type EnvCredentials struct {
+	// Hosts maps hostnames to the environment variables
+	// holding their token, in order of precedence. Defaults
+	// to GITHUB_TOKEN and GH_TOKEN for github.com, and
+	// GITLAB_TOKEN for gitlab.com.
+	Hosts map[string][]string
+}
+
+// Credentials returns the token of the environment
+// variables of the host, or else of $GIT_TOKEN if the host
+// is the one of $GIT_SERVER, with the username of $GIT_USER
+// or $GIT_USERNAME. Without $GIT_SERVER, the host is the one
+// of the public server of $GIT_KIND, defaulting to GitHub.
+func (e *EnvCredentials) Credentials(_ context.Context, serverURL string) (*Credentials, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	hosts := e.Hosts
+	if hosts == nil {
+		hosts = map[string][]string{
+			"github.com":     {"GITHUB_TOKEN", "GH_TOKEN"},
+			"api.github.com": {"GITHUB_TOKEN", "GH_TOKEN"},
+			"gitlab.com":     {"GITLAB_TOKEN"},
+		}
+	}
+	host := strings.ToLower(u.Hostname())
+	vars := slices.Clone(hosts[host])
+	// $GIT_TOKEN is only sent to its server, not to the
+	// host of any repository URL.
+	gitServer := os.Getenv("GIT_SERVER")
+	if gitServer == "" {
+		gitServer = defaultServerURL(os.Getenv("GIT_KIND"))
+	}
+	if server, err := url.Parse(gitServer); err == nil && host != "" && strings.EqualFold(server.Hostname(), host) {
+		vars = append(vars, "GIT_TOKEN")
+	}
+	for _, name := range vars {
+		if token := os.Getenv(name); token != "" {
+			username := os.Getenv("GIT_USER")
+			if username == "" {
+				username = os.Getenv("GIT_USERNAME")
+			}
+			return &Credentials{Username: username, Token: token}, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveCredentials returns the credentials of the server
+// resolved by the DefaultCredentialProvider, or nil if it
+// has none.
+func resolveCredentials(serverURL string) *Credentials {
+	if DefaultCredentialProvider == nil {
+		return nil
+	}
+	credentials, err := DefaultCredentialProvider.Credentials(context.Background(), serverURL)
+	if err != nil {
+		return nil
+	}
+	return credentials
+}
+
+// defaultServerURL returns the URL of the public server of
+// the driver, if any.
+func defaultServerURL(driver string) string {
+	switch driver {
+	case "", "github":
+		return "https://github.com"
+	case "gitlab":
+		return "https://gitlab.com"
+	case "bitbucket", "bitbucketcloud":
+		return "https://bitbucket.org"
+	case "azure":
+		return "https://dev.azure.com"
+	}
+	return ""
+}
+
+// pick returns the first non-nil credentials having a
+// token.
+func pick(credentials ...*Credentials) *Credentials {
+	for _, c := range credentials {
+		if c != nil && c.Token != "" {
+			return c
+		}
+	}
+	return nil
+}
+
+// homePath returns the path relative to the home directory
+// of the user.
+func homePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, path)
+}
+
+// readOptionalFile reads the file, returning no data and
+// no error if the file does not exist.
+func readOptionalFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// CredentialProvider implementations
+var (
+	_ CredentialProvider = CredentialChain(nil)
+	_ CredentialProvider = (*GitCredentialHelper)(nil)
+	_ CredentialProvider = (*Netrc)(nil)
+	_ CredentialProvider = (*GitCredentialStore)(nil)
+	_ CredentialProvider = (*EnvCredentials)(nil)
+)
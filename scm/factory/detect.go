@@ -0,0 +1,211 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDriverNotDetected is returned when the driver of a
+// server cannot be detected.
+var ErrDriverNotDetected = errors.New("unable to detect driver")
+
+// DefaultDetector is the driver detector used by
+// DetectDriver.
+var DefaultDetector = &DriverDetector{}
+
+// detectTimeout is the time given to the detection of the
+// driver of a server when the factory falls back to it.
+const detectTimeout = 30 * time.Second
+
+// DetectDriver probes the server using the DefaultDetector
+// and returns the name of its driver.
+func DetectDriver(ctx context.Context, serverURL string) (string, error) {
+	return DefaultDetector.Detect(ctx, serverURL)
+}
+
+// detect probes the server using the detector, giving up
+// after the detectTimeout.
+func detect(detector *DriverDetector, serverURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), detectTimeout)
+	defer cancel()
+	return detector.Detect(ctx, serverURL)
+}
+
+// probe identifies a driver by requesting a well-known
+// unauthenticated endpoint of the server.
+type probe struct {
+	driver string
+	path   string
+	match  func(res *http.Response, body []byte) bool
+}
+
+// probes lists the probes in order of precedence, which
+// matters when several probes match the same server.
+var probes = []probe{
+	{
+		driver: "github",
+		path:   "/api/v3/meta",
+		match: func(res *http.Response, body []byte) bool {
+			if res.Header.Get("X-GitHub-Enterprise-Version") != "" {
+				return true
+			}
+			return res.StatusCode == http.StatusOK && hasJSONField(body, "verifiable_password_authentication")
+		},
+	},
+	{
+		driver: "gitlab",
+		path:   "/api/v4/version",
+		match: func(res *http.Response, body []byte) bool {
+			if res.Header.Get("X-Gitlab-Meta") != "" {
+				return true
+			}
+			switch res.StatusCode {
+			case http.StatusOK:
+				return hasJSONField(body, "version") && hasJSONField(body, "revision")
+			case http.StatusUnauthorized:
+				// the version requires authentication
+				return strings.Contains(string(body), `"401 Unauthorized"`)
+			}
+			return false
+		},
+	},
+	{
+		driver: "stash",
+		path:   "/rest/api/1.0/application-properties",
+		match: func(res *http.Response, body []byte) bool {
+			return res.StatusCode == http.StatusOK && hasJSONField(body, "buildNumber")
+		},
+	},
+	{
+		driver: "azure",
+		path:   "/_apis/connectionData",
+		match: func(res *http.Response, body []byte) bool {
+			return res.Header.Get("X-TFS-ProcessId") != "" || res.Header.Get("X-VSS-E2EID") != ""
+		},
+	},
+	{
+		// matches Forgejo too, which is compatible with Gitea.
+		driver: "gitea",
+		path:   "/api/v1/version",
+		match: func(res *http.Response, body []byte) bool {
+			return res.StatusCode == http.StatusOK && hasJSONField(body, "version")
+		},
+	},
+	{
+		driver: "gogs",
+		path:   "/",
+		match: func(res *http.Response, body []byte) bool {
+			for _, cookie := range res.Cookies() {
+				if cookie.Name == "i_like_gogs" {
+					return true
+				}
+			}
+			return false
+		},
+	},
+}
+
+// DriverDetector detects the driver of a server by probing
+// well-known unauthenticated endpoints of GitHub Enterprise,
+// GitLab, Bitbucket Server, Azure DevOps Server, Gitea,
+// Forgejo and Gogs. Detected drivers are cached per server.
+//
+// DriverDetector is safe for concurrent use by multiple
+// goroutines.
+type DriverDetector struct {
+	// Client is the HTTP client used to probe the servers.
+	// If nil, a client with a ten second timeout is used.
+	Client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// Detect returns the name of the driver of the server.
+func (d *DriverDetector) Detect(ctx context.Context, serverURL string) (string, error) {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+	if serverURL == "" {
+		return "", ErrMissingGitServerURL
+	}
+	d.mu.Lock()
+	driver, ok := d.cache[serverURL]
+	d.mu.Unlock()
+	if ok {
+		return driver, nil
+	}
+
+	// probe concurrently and pick the first matching probe
+	// in order of precedence.
+	matches := make([]bool, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matches[i] = d.probe(ctx, serverURL, p)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	for i, match := range matches {
+		if !match {
+			continue
+		}
+		driver = probes[i].driver
+		d.mu.Lock()
+		if d.cache == nil {
+			d.cache = map[string]string{}
+		}
+		d.cache[serverURL] = driver
+		d.mu.Unlock()
+		return driver, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrDriverNotDetected, serverURL)
+}
+
+// probe reports whether the probe matches the server.
+func (d *DriverDetector) probe(ctx context.Context, serverURL string, p probe) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+p.path, http.NoBody)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := d.client().Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return false
+	}
+	return p.match(res, body)
+}
+
+// client returns the HTTP client used to probe servers.
+func (d *DriverDetector) client() *http.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+// hasJSONField reports whether the body is a JSON object
+// holding the field.
+func hasJSONField(body []byte, field string) bool {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return false
+	}
+	_, ok := fields[field]
+	return ok
+}
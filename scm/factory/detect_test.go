@@ -0,0 +1,124 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDriver(t *testing.T) {
+	tests := []struct {
+		driver string
+		path   string
+		status int
+		header http.Header
+		body   string
+	}{
+		{"github", "/api/v3/meta", 200, http.Header{"X-Github-Enterprise-Version": {"3.10.0"}}, `{}`},
+		{"github", "/api/v3/meta", 200, nil, `{"verifiable_password_authentication":true}`},
+		{"gitlab", "/api/v4/version", 401, nil, `{"message":"401 Unauthorized"}`},
+		{"gitlab", "/api/v4/version", 200, nil, `{"version":"16.0.0","revision":"abc"}`},
+		{"stash", "/rest/api/1.0/application-properties", 200, nil, `{"version":"8.9.0","buildNumber":"8009000","displayName":"Bitbucket"}`},
+		{"azure", "/_apis/connectionData", 401, http.Header{"X-Tfs-Processid": {"abc"}}, ``},
+		{"gitea", "/api/v1/version", 200, nil, `{"version":"1.21.0"}`},
+		{"gogs", "/", 200, http.Header{"Set-Cookie": {"i_like_gogs=abc; Path=/"}}, `<html></html>`},
+	}
+	for _, test := range tests {
+		t.Run(test.driver, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != test.path {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				for k, v := range test.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(test.status)
+				_, _ = io.WriteString(w, test.body)
+			}))
+			defer server.Close()
+
+			detector := &DriverDetector{}
+			driver, err := detector.Detect(context.Background(), server.URL+"/")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.driver, driver)
+		})
+	}
+}
+
+func TestDetectDriver_Cache(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path != "/api/v1/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, `{"version":"1.21.0"}`)
+	}))
+	defer server.Close()
+
+	detector := &DriverDetector{}
+	for i := 0; i < 2; i++ {
+		driver, err := detector.Detect(context.Background(), server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "gitea", driver)
+	}
+	assert.Equal(t, int32(len(probes)), atomic.LoadInt32(&hits))
+}
+
+func TestDetectDriver_Unknown(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := (&DriverDetector{}).Detect(context.Background(), server.URL)
+	if !errors.Is(err, ErrDriverNotDetected) {
+		t.Errorf("Want ErrDriverNotDetected, got %v", err)
+	}
+}
+
+func TestFromRepoURL_DetectDriver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"message":"401 Unauthorized"}`)
+	}))
+	defer server.Close()
+
+	client, err := FromRepoURL(server.URL + "/myorg/myrepo.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverGitlab, client.Driver)
+	assert.Equal(t, server.URL+"/", client.BaseURL.String())
+}
+
+func TestNewClientFromEnvironment_DetectDriverFallback(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	t.Setenv("GIT_REPO_URL", "")
+	t.Setenv("GIT_KIND", "")
+	t.Setenv("GIT_SERVER", server.URL)
+	t.Setenv("GIT_TOKEN", "abc123")
+
+	client, err := NewClientFromEnvironment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverGithub, client.Driver, "want the github driver when the detection fails")
+	assert.Equal(t, server.URL+"/api/v3/", client.BaseURL.String())
+}
@@ -10,6 +10,8 @@ import (
 type HostDriverIdentifier map[string]string
 
 // Identify looks up the provided hostname, and returns the driver mapping.
+// Hostnames without a mapping are looked up in the hosts registered with
+// RegisterHost.
 //
 // If no mapping exists, then it returns an error.
 func (u HostDriverIdentifier) Identify(host string) (string, error) {
@@ -17,6 +19,9 @@ func (u HostDriverIdentifier) Identify(host string) (string, error) {
 	if ok {
 		return d, nil
 	}
+	if d, ok := lookupHost(host); ok {
+		return d, nil
+	}
 	return "", unknownDriverError{hostname: host}
 }
 
@@ -31,12 +36,10 @@ func Mapping(host, driver string) MappingFunc {
 	}
 }
 
-// NewDriverIdentifier creates and returns a new HostDriverIdentifier.
+// NewDriverIdentifier creates and returns a new HostDriverIdentifier
+// holding the hosts registered with RegisterHost.
 func NewDriverIdentifier(extras ...MappingFunc) HostDriverIdentifier {
-	u := HostDriverIdentifier{
-		"github.com": "github",
-		"gitlab.com": "gitlab",
-	}
+	u := HostDriverIdentifier(registeredHosts())
 	for _, e := range extras {
 		e(u)
 	}
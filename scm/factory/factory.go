@@ -1,43 +1,55 @@
 package factory
 
 import (
-	"context"
-	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 
-	"github.com/pkg/errors"
-
 	"github.com/jenkins-x/go-scm/scm"
-	"github.com/jenkins-x/go-scm/scm/driver/azure"
-	"github.com/jenkins-x/go-scm/scm/driver/bitbucket"
-	"github.com/jenkins-x/go-scm/scm/driver/fake"
 	"github.com/jenkins-x/go-scm/scm/driver/gitea"
 	"github.com/jenkins-x/go-scm/scm/driver/github"
-	"github.com/jenkins-x/go-scm/scm/driver/gitlab"
-	"github.com/jenkins-x/go-scm/scm/driver/gogs"
-	"github.com/jenkins-x/go-scm/scm/driver/stash"
 	"github.com/jenkins-x/go-scm/scm/transport"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/clientcredentials"
 )
 
 // ErrMissingGitServerURL the error returned if you use a git driver that needs a git server URL
 var ErrMissingGitServerURL = fmt.Errorf("no git serverURL was specified")
 
 // DefaultIdentifier is the default driver identifier used by FromRepoURL.
-var DefaultIdentifier = NewDriverIdentifier()
+// It is built once the drivers of the package are registered.
+var DefaultIdentifier HostDriverIdentifier
 
 // ClientOptionFunc is a function taking a client as its argument
 type ClientOptionFunc func(*scm.Client)
 
+// AuthOptions holds the credentials used to authenticate
+// the client.
 type AuthOptions struct {
 	oauthToken   string
 	clientID     string
 	clientSecret string
+	username     string
+}
+
+// Token returns the OAuth token or personal access token.
+func (a *AuthOptions) Token() string {
+	return a.oauthToken
+}
+
+// ClientID returns the OAuth client ID, if any.
+func (a *AuthOptions) ClientID() string {
+	return a.clientID
+}
+
+// ClientSecret returns the OAuth client secret, if any.
+func (a *AuthOptions) ClientSecret() string {
+	return a.clientSecret
+}
+
+// Username returns the username, if any.
+func (a *AuthOptions) Username() string {
+	return a.username
 }
 
 // SetUsername allows the username to be set
@@ -49,6 +61,29 @@ func SetUsername(username string) ClientOptionFunc {
 	}
 }
 
+// SetRetryPolicy configures the client to retry failed requests
+// using the given policy
+func SetRetryPolicy(policy *scm.RetryPolicy) ClientOptionFunc {
+	return func(client *scm.Client) {
+		client.Retry = policy
+	}
+}
+
+// SetThrottler configures the client to pause requests when the
+// remaining rate limit drops below the threshold of the throttler
+func SetThrottler(throttler *scm.Throttler) ClientOptionFunc {
+	return func(client *scm.Client) {
+		client.Throttle = throttler
+	}
+}
+
+// UseMiddleware registers middleware invoked around every request sent by the client
+func UseMiddleware(middleware ...scm.Middleware) ClientOptionFunc {
+	return func(client *scm.Client) {
+		client.Use(middleware...)
+	}
+}
+
 // NewClientWithBasicAuth creates a new client for a given driver, serverURL and basic auth
 func NewClientWithBasicAuth(driver, serverURL, user, password string, opts ...ClientOptionFunc) (*scm.Client, error) {
 	if driver == "" {
@@ -83,126 +118,128 @@ func NewClient(driver, serverURL, oauthToken string, opts ...ClientOptionFunc) (
 	return newClient(driver, serverURL, authOptions, opts...)
 }
 
+// NewClientWithUsername creates a new client for a given driver, serverURL,
+// username and OAuth token. The username is required by the drivers
+// authenticating with it, such as bitbucketcloud.
+func NewClientWithUsername(driver, serverURL, username, oauthToken string, opts ...ClientOptionFunc) (*scm.Client, error) {
+	authOptions := &AuthOptions{
+		oauthToken: oauthToken,
+		username:   username,
+	}
+	return newClient(driver, serverURL, authOptions, opts...)
+}
+
 func newClient(driver, serverURL string, authOptions *AuthOptions, opts ...ClientOptionFunc) (*scm.Client, error) {
-	oauthToken := authOptions.oauthToken
 	if driver == "" {
 		driver = "github"
 	}
-	var client *scm.Client
-	var err error
-
-	switch driver {
-	case "azure":
-		if serverURL != "" {
-			client, err = azure.New(serverURL)
-		} else {
-			client = azure.NewDefault()
-		}
-	case "bitbucket", "bitbucketcloud":
-		if serverURL != "" {
-			client, err = bitbucket.New(ensureBBCEndpoint(serverURL))
-		} else {
-			client = bitbucket.NewDefault()
-		}
-	case "fake", "fakegit":
-		client, _ = fake.NewDefault()
-	case "gitea":
-		if serverURL == "" {
-			return nil, ErrMissingGitServerURL
-		}
-		client, err = gitea.NewWithToken(serverURL, oauthToken)
-	case "github":
-		if serverURL != "" {
-			client, err = github.New(ensureGHEEndpoint(serverURL))
-		} else {
-			client = github.NewDefault()
-		}
-	case "gitlab":
-		if serverURL != "" {
-			client, err = gitlab.New(serverURL)
-		} else {
-			client = gitlab.NewDefault()
-		}
-	case "gogs":
-		if serverURL == "" {
-			return nil, ErrMissingGitServerURL
-		}
-		client, err = gogs.New(serverURL)
-	case "stash", "bitbucketserver":
-		if serverURL == "" {
-			return nil, ErrMissingGitServerURL
-		}
-		client, err = stash.New(serverURL)
-	default:
-		return nil, fmt.Errorf("unsupported $GIT_KIND value: %s", driver)
+	d, err := lookupDriver(driver)
+	if err != nil {
+		return nil, err
 	}
+	client, err := d.constructor(serverURL, authOptions)
 	if err != nil {
 		return client, err
 	}
-	if oauthToken != "" {
-		switch driver {
-		case "azure":
-			client.Client = &http.Client{
-				Transport: &transport.Custom{
-					Before: func(r *http.Request) {
-						encoded := base64.StdEncoding.EncodeToString([]byte(":" + oauthToken))
-						r.Header.Set("Authorization", fmt.Sprintf("Basic %s", encoded))
-					},
-				},
-			}
-		case "gitea":
-			client.Client = &http.Client{
-				Transport: &transport.Authorization{
-					Scheme:      "token",
-					Credentials: oauthToken,
-				},
-			}
-		case "gitlab":
-			client.Client = &http.Client{
-				Transport: &transport.PrivateToken{
-					Token: oauthToken,
-				},
-			}
-		case "bitbucketcloud":
-			// lets process any options now so that we can populate the username
-			for _, o := range opts {
-				o(client)
-			}
-			if client.Username == "" {
-				return nil, errors.Errorf("no username supplied")
-			}
-			if authOptions.clientID != "" && authOptions.clientSecret != "" {
-				config := clientcredentials.Config{
-					ClientID:     authOptions.clientID,
-					ClientSecret: authOptions.clientSecret,
-					TokenURL:     "https://bitbucket.org/site/oauth2/access_token",
-				}
-				client.Client = config.Client(context.Background())
-				return client, nil
-			}
-			// BB App Password / PAT
-			client.Client = &http.Client{
-				Transport: &transport.BasicAuth{
-					Username: client.Username,
-					Password: oauthToken,
-				},
-			}
-			return client, nil
-		default:
-			ts := oauth2.StaticTokenSource(
-				&oauth2.Token{AccessToken: oauthToken},
-			)
-			client.Client = oauth2.NewClient(context.Background(), ts)
+	if authOptions.username == "" {
+		// the username may be set by the options, such as
+		// SetUsername, which are applied after the auth.
+		authOptions.username = optionsUsername(client, opts)
+	}
+	if authOptions.username != "" {
+		client.Username = authOptions.username
+	}
+	if authOptions.oauthToken != "" {
+		if err := d.auth(client, authOptions); err != nil {
+			return nil, err
 		}
 	}
 	for _, o := range opts {
 		o(client)
 	}
-	return client, err
+	return client, nil
+}
+
+// optionsUsername returns the username the options set on
+// the client, applying them to a new client.
+func optionsUsername(client *scm.Client, opts []ClientOptionFunc) string {
+	if len(opts) == 0 {
+		return ""
+	}
+	probe := scm.Client{Username: client.Username}
+	for _, o := range opts {
+		o(&probe)
+	}
+	if probe.Username == client.Username {
+		return ""
+	}
+	return probe.Username
+}
+
+// NewGitHubAppClient creates a new GitHub client for the given serverURL authenticated as a GitHub App
+// using the PEM encoded private key of the App. If an installationID is given the client authenticates
+// as that installation using installation access tokens which are created and renewed automatically,
+// otherwise the client authenticates as the App itself
+func NewGitHubAppClient(serverURL string, appID, installationID int64, privateKey []byte, opts ...ClientOptionFunc) (*scm.Client, error) {
+	return newGitHubAppClient(serverURL, appID, installationID, privateKey, nil, opts...)
+}
+
+// newGitHubAppClient creates a new GitHub App client sending the requests using the base transport
+func newGitHubAppClient(serverURL string, appID, installationID int64, privateKey []byte, base http.RoundTripper, opts ...ClientOptionFunc) (*scm.Client, error) {
+	key, err := transport.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	appClient, err := newGitHubClient(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	appClient.Client = &http.Client{
+		Transport: &transport.GitHubApp{
+			Base:  base,
+			AppID: appID,
+			Key:   key,
+		},
+	}
+	// the app client mints the installation tokens, so it
+	// is configured too, such as with the CA or the proxy.
+	for _, o := range opts {
+		o(appClient)
+	}
+	if installationID == 0 {
+		return appClient, nil
+	}
+	client, err := newGitHubClient(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	client.Client = &http.Client{
+		Transport: &transport.GitHubAppInstallation{
+			Base:           base,
+			InstallationID: installationID,
+			Tokens: &transport.InstallationTokens{
+				Apps: appClient.Apps,
+			},
+		},
+	}
+	for _, o := range opts {
+		o(client)
+	}
+	return client, nil
+}
+
+// newGitHubClient creates a new GitHub client for the serverURL defaulting to github.com
+func newGitHubClient(serverURL string) (*scm.Client, error) {
+	if serverURL == "" {
+		return github.NewDefault(), nil
+	}
+	return github.New(ensureGHEEndpoint(serverURL))
 }
 
 // NewClientFromEnvironment creates a new client using environment variables $GIT_KIND, $GIT_SERVER, $GIT_TOKEN
-// defaulting to github if no $GIT_KIND or $GIT_SERVER
+// detecting the driver of the $GIT_SERVER if no $GIT_KIND and defaulting to github if no $GIT_SERVER
+// or if the detection fails.
+// If no $GIT_TOKEN is set, the token is resolved by the DefaultCredentialProvider
 func NewClientFromEnvironment() (*scm.Client, error) {
 	if repoURL := os.Getenv("GIT_REPO_URL"); repoURL != "" {
 		return FromRepoURL(repoURL)
@@ -215,12 +252,26 @@ func NewClientFromEnvironment() (*scm.Client, error) {
 		username = os.Getenv("GIT_USERNAME")
 	}
 
+	if oauthToken == "" {
+		// fall back to the credentials configured for git
+		credentialsURL := serverURL
+		if credentialsURL == "" {
+			credentialsURL = defaultServerURL(driver)
+		}
+		if credentials := resolveCredentials(credentialsURL); credentials != nil {
+			oauthToken = credentials.Token
+			if username == "" {
+				username = credentials.Username
+			}
+		}
+	}
 	if oauthToken == "" {
 		return nil, fmt.Errorf("no Git OAuth token specified for $GIT_TOKEN")
 	}
 
 	authOptions := &AuthOptions{
 		oauthToken: oauthToken,
+		username:   username,
 	}
 
 	clientID := os.Getenv("BB_OAUTH_CLIENT_ID")
@@ -228,16 +279,33 @@ func NewClientFromEnvironment() (*scm.Client, error) {
 	authOptions.clientID = clientID
 	authOptions.clientSecret = clientSecret
 
-	client, err := newClient(driver, serverURL, authOptions, SetUsername(username))
+	if driver == "" && serverURL != "" {
+		// fall back to probing the server, and then to github
+		detected, err := detect(DefaultDetector, serverURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to detect the driver of $GIT_SERVER, using github: %v\n", err)
+			detected = "github"
+		}
+		driver = detected
+	}
+
+	client, err := newClient(driver, serverURL, authOptions)
+	if err != nil {
+		return nil, err
+	}
 	if driver == "" {
 		driver = client.Driver.String()
 	}
-	fmt.Printf("using driver: %s and serverURL: %s\n", driver, serverURL)
-	return client, err
+	// stdout is left to the output of the callers, such as
+	// JSON.
+	fmt.Fprintf(os.Stderr, "using driver: %s and serverURL: %s\n", driver, serverURL)
+	return client, nil
 }
 
 // FromRepoURL parses a URL of the form https://:authtoken@host/ and attempts to
 // determine the driver and creates a client to authenticate to the endpoint.
+// If the host has no driver mapping, the driver is detected by probing the server.
+// If the URL has no token, the token is resolved by the DefaultCredentialProvider.
 func FromRepoURL(repoURL string) (*scm.Client, error) {
 	u, err := url.Parse(repoURL)
 	if err != nil {
@@ -247,14 +315,27 @@ func FromRepoURL(repoURL string) (*scm.Client, error) {
 	if password, ok := u.User.Password(); ok {
 		auth = password
 	}
+	username := ""
 
+	u.Path = "/"
+	u.User = nil
 	driver, err := DefaultIdentifier.Identify(u.Host)
 	if err != nil {
-		return nil, err
+		// fall back to probing the server
+		detected, detectErr := detect(DefaultDetector, u.String())
+		if detectErr != nil {
+			return nil, fmt.Errorf("%w: %v", err, detectErr)
+		}
+		driver = detected
 	}
-	u.Path = "/"
-	u.User = nil
-	return NewClient(driver, u.String(), auth)
+	if auth == "" {
+		// fall back to the credentials configured for git
+		if credentials := resolveCredentials(u.String()); credentials != nil {
+			auth = credentials.Token
+			username = credentials.Username
+		}
+	}
+	return NewClientWithUsername(driver, u.String(), username, auth)
 }
 
 // ensureGHEEndpoint lets ensure we have the /api/v3 suffix on the URL
@@ -284,30 +365,18 @@ func Client(httpClient *http.Client) ClientOptionFunc {
 	}
 }
 
-// NewWebHookService creates a new instance of the webhook service without the rest of the client
+// NewWebHookService creates a new instance of the webhook service without the rest of the client.
+// It returns an error wrapping scm.ErrNotSupported if the driver does not support webhooks.
 func NewWebHookService(driver string) (scm.WebhookService, error) {
 	if driver == "" {
 		driver = "github"
 	}
-	var service scm.WebhookService
-	switch driver {
-	case "bitbucket", "bitbucketcloud":
-		service = bitbucket.NewWebHookService()
-	case "fake", "fakegit":
-		// TODO: support fake
-	case "gitea":
-		service = gitea.NewWebHookService()
-	case "github":
-		service = github.NewWebHookService()
-	case "gitlab":
-		service = gitlab.NewWebHookService()
-	case "gogs":
-		service = gogs.NewWebHookService()
-	case "stash", "bitbucketserver":
-		service = stash.NewWebHookService()
-	default:
+	d, err := lookupDriver(driver)
+	if err != nil {
 		return nil, fmt.Errorf("unsupported GIT_KIND value: %s", driver)
 	}
-
-	return service, nil
+	if d.webhook == nil {
+		return nil, fmt.Errorf("driver %s does not support webhooks: %w", driver, scm.ErrNotSupported)
+	}
+	return d.webhook(), nil
 }
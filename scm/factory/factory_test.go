@@ -1,10 +1,18 @@
 package factory
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
 	"github.com/jenkins-x/go-scm/scm/transport"
 	"github.com/stretchr/testify/assert"
 )
@@ -50,3 +58,177 @@ func TestFromRepoURL(t *testing.T) {
 		t.Fatalf("got %q, want %q", p, "abc123")
 	}
 }
+
+func TestNewClientWithRetryPolicy(t *testing.T) {
+	policy := &scm.RetryPolicy{MaxAttempts: 3}
+	client, err := NewClient("github", "", "", SetRetryPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, policy, client.Retry)
+}
+
+func TestNewGitHubAppClient(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	client, err := NewGitHubAppClient("https://github.example.com", 42, 0, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "https://github.example.com/api/v3/", client.BaseURL.String())
+	app, ok := client.Client.Transport.(*transport.GitHubApp)
+	if assert.True(t, ok, "want app transport") {
+		assert.Equal(t, int64(42), app.AppID)
+	}
+
+	client, err = NewGitHubAppClient("", 42, 7, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "https://api.github.com/", client.BaseURL.String())
+	installation, ok := client.Client.Transport.(*transport.GitHubAppInstallation)
+	if assert.True(t, ok, "want installation transport") {
+		assert.Equal(t, int64(7), installation.InstallationID)
+	}
+
+	_, err = NewGitHubAppClient("", 42, 7, []byte("invalid"))
+	assert.Equal(t, transport.ErrInvalidPrivateKey, err)
+}
+
+func TestNewGitHubAppClientOptions(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/app/installations/7/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"token": "ghs_1"}`))
+		case "/api/v3/user":
+			assert.Equal(t, "token ghs_1", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// the token is minted with the options of the client,
+	// trusting the certificate of the server.
+	client, err := NewGitHubAppClient(server.URL, 42, 7, data, SetInsecureSkipVerify())
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _, err := client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "octocat", user.Login)
+}
+
+func TestRegisterDriver(t *testing.T) {
+	var gotAuth *AuthOptions
+	RegisterDriver("custom", func(serverURL string, auth *AuthOptions) (*scm.Client, error) {
+		client, err := NewClient("fake", "", "")
+		if err != nil {
+			return nil, err
+		}
+		client.BaseURL, err = url.Parse(serverURL)
+		return client, err
+	}, func() scm.WebhookService {
+		return github.NewWebHookService()
+	}, func(client *scm.Client, auth *AuthOptions) error {
+		gotAuth = auth
+		client.Client = &http.Client{Transport: &transport.BearerToken{Token: auth.Token()}}
+		return nil
+	})
+	RegisterHost("scm.example.com", "custom")
+	t.Cleanup(func() {
+		registry.Lock()
+		delete(registry.drivers, "custom")
+		delete(registry.hosts, "scm.example.com")
+		registry.Unlock()
+	})
+
+	assert.Contains(t, Drivers(), "custom")
+
+	client, err := FromRepoURL("https://:abc123@scm.example.com/myorg/myrepo.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "https://scm.example.com/", client.BaseURL.String())
+	if assert.NotNil(t, gotAuth) {
+		assert.Equal(t, "abc123", gotAuth.Token())
+	}
+	assert.Equal(t, "abc123", client.Client.Transport.(*transport.BearerToken).Token)
+
+	service, err := NewWebHookService("custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, service)
+
+	driver, err := NewDriverIdentifier().Identify("scm.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "custom", driver)
+}
+
+func TestNewWebHookServiceNotSupported(t *testing.T) {
+	service, err := NewWebHookService("azure")
+	assert.Nil(t, service)
+	assert.EqualError(t, err, "driver azure does not support webhooks: not supported")
+	assert.ErrorIs(t, err, scm.ErrNotSupported)
+}
+
+func TestNewClientUnknownDriver(t *testing.T) {
+	_, err := NewClient("unknown", "", "")
+	assert.EqualError(t, err, "unsupported $GIT_KIND value: unknown")
+}
+
+func TestNewClientBitbucketCloudUsername(t *testing.T) {
+	_, err := NewClient("bitbucketcloud", "", "abc123")
+	assert.EqualError(t, err, "no username supplied")
+
+	client, err := NewClient("bitbucketcloud", "", "abc123", SetUsername("jcitizen"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := client.Client.Transport.(*transport.BasicAuth)
+	assert.Equal(t, "jcitizen", auth.Username)
+	assert.Equal(t, "abc123", auth.Password)
+
+	client, err = NewClientWithUsername("bitbucketcloud", "", "jcitizen", "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth = client.Client.Transport.(*transport.BasicAuth)
+	assert.Equal(t, "jcitizen", auth.Username)
+	assert.Equal(t, "abc123", auth.Password)
+	assert.Equal(t, "jcitizen", client.Username)
+}
+
+func TestNewClientOptionsAppliedOnce(t *testing.T) {
+	calls := 0
+	count := func(*scm.Client) {
+		calls++
+	}
+	_, err := NewClientWithUsername("bitbucketcloud", "", "jcitizen", "abc123", count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestDefaultIdentifier(t *testing.T) {
+	assert.Equal(t, "github", DefaultIdentifier["github.com"])
+	assert.Equal(t, "gitlab", DefaultIdentifier["gitlab.com"])
+}
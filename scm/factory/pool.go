@@ -0,0 +1,204 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// ErrServerNotConfigured is returned by the ClientPool when
+// no server is configured for a repository or host.
+var ErrServerNotConfigured = errors.New("no server configured")
+
+// scpURL matches scp-like git URLs such as
+// git@github.com:org/repo.git.
+var scpURL = regexp.MustCompile(`^(?:[\w.-]+@)?([\w.-]+):(.*)$`)
+
+// ClientPool returns the client of the server of a
+// repository, for platforms talking to several git servers
+// at once. Clients are created on first use and reused.
+//
+// ClientPool is safe for concurrent use by multiple
+// goroutines.
+type ClientPool struct {
+	config *Config
+	opts   []ClientOptionFunc
+
+	mu      sync.Mutex
+	clients map[string]*scm.Client
+	calls   map[string]*poolCall
+}
+
+// poolCall is the creation of the client of a server, which
+// the concurrent lookups of the server wait for.
+type poolCall struct {
+	done   chan struct{}
+	client *scm.Client
+	err    error
+}
+
+// NewClientPool returns a new ClientPool for the servers of
+// the configuration. The options are applied to every
+// client.
+func NewClientPool(config *Config, opts ...ClientOptionFunc) (*ClientPool, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &ClientPool{
+		config:  config,
+		opts:    opts,
+		clients: map[string]*scm.Client{},
+		calls:   map[string]*poolCall{},
+	}, nil
+}
+
+// NewClientPoolFromFile returns a new ClientPool for the
+// servers of the YAML or JSON configuration file.
+func NewClientPoolFromFile(path string, opts ...ClientOptionFunc) (*ClientPool, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientPool(config, opts...)
+}
+
+// Client returns the client of the named server.
+func (p *ClientPool) Client(name string) (*scm.Client, error) {
+	for _, server := range p.config.Servers {
+		if server.name() == name {
+			return p.client(server)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrServerNotConfigured, name)
+}
+
+// ForHost returns the client of the server of the host.
+func (p *ClientPool) ForHost(host string) (*scm.Client, error) {
+	server := p.match(host, "")
+	if server == nil {
+		return nil, fmt.Errorf("%w: %s", ErrServerNotConfigured, host)
+	}
+	return p.client(server)
+}
+
+// ForRepoURL returns the client of the server of the
+// repository. Both HTTP(S) and SSH URLs are supported.
+func (p *ClientPool) ForRepoURL(repoURL string) (*scm.Client, error) {
+	host, path, err := parseRepoURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	server := p.match(host, path)
+	if server == nil {
+		return nil, fmt.Errorf("%w: %s", ErrServerNotConfigured, repoURL)
+	}
+	return p.client(server)
+}
+
+// client returns the client of the server, creating it if
+// needed. The client is created outside of the lock, as
+// detecting the driver of the server may take a while, and
+// only once for the concurrent lookups of the server.
+func (p *ClientPool) client(server *ServerConfig) (*scm.Client, error) {
+	name := server.name()
+	p.mu.Lock()
+	if client, ok := p.clients[name]; ok {
+		p.mu.Unlock()
+		return client, nil
+	}
+	if call, ok := p.calls[name]; ok {
+		p.mu.Unlock()
+		<-call.done
+		return call.client, call.err
+	}
+	call := &poolCall{done: make(chan struct{})}
+	p.calls[name] = call
+	p.mu.Unlock()
+
+	call.client, call.err = server.NewClient(p.opts...)
+	if call.err != nil {
+		call.client = nil
+		call.err = fmt.Errorf("failed to create client for server %s: %w", name, call.err)
+	}
+
+	p.mu.Lock()
+	delete(p.calls, name)
+	if call.err == nil {
+		p.clients[name] = call.client
+	}
+	p.mu.Unlock()
+	close(call.done)
+	return call.client, call.err
+}
+
+// match returns the server of the host and path. If
+// several servers share a host, the one with the longest
+// URL path prefixing the path wins.
+func (p *ClientPool) match(host, path string) *ServerConfig {
+	var (
+		found  *ServerConfig
+		length = -1
+	)
+	for _, server := range p.config.Servers {
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			continue
+		}
+		prefix := strings.Trim(u.Path, "/")
+		switch {
+		case strings.EqualFold(u.Host, host), strings.EqualFold(u.Hostname(), host):
+		case containsFold(server.Hosts, host):
+			// additional hosts serve the repositories at the
+			// root.
+			prefix = ""
+		default:
+			continue
+		}
+		if prefix != "" && path != "" && path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		if len(prefix) > length {
+			found = server
+			length = len(prefix)
+		}
+	}
+	return found
+}
+
+// parseRepoURL returns the host and path of the repository
+// URL, without the leading slash and the .git suffix.
+func parseRepoURL(repoURL string) (host, path string, err error) {
+	if !strings.Contains(repoURL, "://") {
+		if m := scpURL.FindStringSubmatch(repoURL); m != nil {
+			return m[1], strings.TrimSuffix(strings.Trim(m[2], "/"), ".git"), nil
+		}
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Host == "" {
+		return "", "", fmt.Errorf("invalid repository URL: %s", repoURL)
+	}
+	host = u.Host
+	if u.Scheme == "ssh" {
+		host = u.Hostname()
+	}
+	return host, strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), nil
+}
+
+// containsFold reports whether the list contains the value,
+// ignoring case.
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
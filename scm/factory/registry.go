@@ -0,0 +1,276 @@
+package factory
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/azure"
+	"github.com/jenkins-x/go-scm/scm/driver/bitbucket"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/driver/gitea"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/jenkins-x/go-scm/scm/driver/gitlab"
+	"github.com/jenkins-x/go-scm/scm/driver/gogs"
+	"github.com/jenkins-x/go-scm/scm/driver/stash"
+	"github.com/jenkins-x/go-scm/scm/transport"
+)
+
+type (
+	// Constructor creates a client for the server URL. The
+	// server URL is empty if none was specified, in which
+	// case the constructor should use the default server of
+	// the provider or return ErrMissingGitServerURL.
+	Constructor func(serverURL string, auth *AuthOptions) (*scm.Client, error)
+
+	// WebhookConstructor creates a webhook service without
+	// the rest of the client.
+	WebhookConstructor func() scm.WebhookService
+
+	// AuthConfigurer configures the client to authenticate
+	// using the credentials. It is only called if a token
+	// is specified.
+	AuthConfigurer func(client *scm.Client, auth *AuthOptions) error
+)
+
+// registration holds the functions of a registered driver.
+type registration struct {
+	constructor Constructor
+	webhook     WebhookConstructor
+	auth        AuthConfigurer
+}
+
+// registry holds the registered drivers and the hosts
+// mapped to them.
+var registry = struct {
+	sync.RWMutex
+	drivers map[string]*registration
+	hosts   map[string]string
+}{
+	drivers: map[string]*registration{},
+	hosts:   map[string]string{},
+}
+
+// RegisterDriver registers a driver by name, replacing any
+// driver previously registered with the name. The webhook
+// constructor is optional; the auth configurer defaults to
+// OAuth2 bearer token authentication. RegisterDriver panics
+// if the name is empty or the constructor is nil.
+func RegisterDriver(name string, constructor Constructor, webhook WebhookConstructor, auth AuthConfigurer) {
+	if name == "" {
+		panic("factory: RegisterDriver name is empty")
+	}
+	if constructor == nil {
+		panic("factory: RegisterDriver constructor is nil for driver " + name)
+	}
+	if auth == nil {
+		auth = bearerAuth
+	}
+	registry.Lock()
+	registry.drivers[name] = &registration{
+		constructor: constructor,
+		webhook:     webhook,
+		auth:        auth,
+	}
+	registry.Unlock()
+}
+
+// RegisterHost maps the hostname to the registered driver,
+// so that FromRepoURL and HostDriverIdentifier can identify
+// the driver of repositories hosted there.
+func RegisterHost(host, driver string) {
+	registry.Lock()
+	registry.hosts[host] = driver
+	registry.Unlock()
+}
+
+// Drivers returns the sorted names of the registered
+// drivers.
+func Drivers() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	var names []string
+	for name := range registry.drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupDriver returns the registered driver.
+func lookupDriver(name string) (*registration, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	d, ok := registry.drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported $GIT_KIND value: %s", name)
+	}
+	return d, nil
+}
+
+// lookupHost returns the driver mapped to the hostname.
+func lookupHost(host string) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	driver, ok := registry.hosts[host]
+	return driver, ok
+}
+
+// registeredHosts returns a copy of the hosts mapped to
+// drivers.
+func registeredHosts() map[string]string {
+	registry.RLock()
+	defer registry.RUnlock()
+	hosts := make(map[string]string, len(registry.hosts))
+	for host, driver := range registry.hosts {
+		hosts[host] = driver
+	}
+	return hosts
+}
+
+func init() {
+	RegisterDriver("azure", newAzureClient, nil, azureAuth)
+	RegisterDriver("bitbucket", newBitbucketClient, bitbucket.NewWebHookService, nil)
+	RegisterDriver("bitbucketcloud", newBitbucketClient, bitbucket.NewWebHookService, bitbucketCloudAuth)
+	RegisterDriver("fake", newFakeClient, fake.NewWebHookService, nil)
+	RegisterDriver("fakegit", newFakeClient, fake.NewWebHookService, nil)
+	RegisterDriver("gitea", newGiteaClient, gitea.NewWebHookService, giteaAuth)
+	RegisterDriver("github", func(serverURL string, _ *AuthOptions) (*scm.Client, error) {
+		return newGitHubClient(serverURL)
+	}, github.NewWebHookService, nil)
+	RegisterDriver("gitlab", newGitLabClient, gitlab.NewWebHookService, gitlabAuth)
+	RegisterDriver("gogs", newGogsClient, gogs.NewWebHookService, nil)
+	RegisterDriver("stash", newStashClient, stash.NewWebHookService, nil)
+	RegisterDriver("bitbucketserver", newStashClient, stash.NewWebHookService, nil)
+
+	RegisterHost("github.com", "github")
+	RegisterHost("gitlab.com", "gitlab")
+
+	DefaultIdentifier = NewDriverIdentifier()
+}
+
+func newAzureClient(serverURL string, _ *AuthOptions) (*scm.Client, error) {
+	if serverURL == "" {
+		return azure.NewDefault(), nil
+	}
+	return azure.New(serverURL)
+}
+
+func newBitbucketClient(serverURL string, _ *AuthOptions) (*scm.Client, error) {
+	if serverURL == "" {
+		return bitbucket.NewDefault(), nil
+	}
+	return bitbucket.New(ensureBBCEndpoint(serverURL))
+}
+
+func newFakeClient(string, *AuthOptions) (*scm.Client, error) {
+	client, _ := fake.NewDefault()
+	return client, nil
+}
+
+func newGiteaClient(serverURL string, auth *AuthOptions) (*scm.Client, error) {
+	if serverURL == "" {
+		return nil, ErrMissingGitServerURL
+	}
+	return gitea.NewWithToken(serverURL, auth.Token())
+}
+
+func newGitLabClient(serverURL string, _ *AuthOptions) (*scm.Client, error) {
+	if serverURL == "" {
+		return gitlab.NewDefault(), nil
+	}
+	return gitlab.New(serverURL)
+}
+
+func newGogsClient(serverURL string, _ *AuthOptions) (*scm.Client, error) {
+	if serverURL == "" {
+		return nil, ErrMissingGitServerURL
+	}
+	return gogs.New(serverURL)
+}
+
+func newStashClient(serverURL string, _ *AuthOptions) (*scm.Client, error) {
+	if serverURL == "" {
+		return nil, ErrMissingGitServerURL
+	}
+	return stash.New(serverURL)
+}
+
+// bearerAuth authenticates using the token as an OAuth2
+// bearer token.
+func bearerAuth(client *scm.Client, auth *AuthOptions) error {
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: auth.Token()},
+	)
+	client.Client = oauth2.NewClient(context.Background(), ts)
+	return nil
+}
+
+// azureAuth authenticates using the token as a personal
+// access token.
+func azureAuth(client *scm.Client, auth *AuthOptions) error {
+	token := auth.Token()
+	client.Client = &http.Client{
+		Transport: &transport.Custom{
+			Before: func(r *http.Request) {
+				encoded := base64.StdEncoding.EncodeToString([]byte(":" + token))
+				r.Header.Set("Authorization", fmt.Sprintf("Basic %s", encoded))
+			},
+		},
+	}
+	return nil
+}
+
+func giteaAuth(client *scm.Client, auth *AuthOptions) error {
+	client.Client = &http.Client{
+		Transport: &transport.Authorization{
+			Scheme:      "token",
+			Credentials: auth.Token(),
+		},
+	}
+	return nil
+}
+
+func gitlabAuth(client *scm.Client, auth *AuthOptions) error {
+	client.Client = &http.Client{
+		Transport: &transport.PrivateToken{
+			Token: auth.Token(),
+		},
+	}
+	return nil
+}
+
+// bitbucketCloudAuth authenticates using the OAuth client
+// credentials if configured, or else using the token as an
+// app password of the user.
+func bitbucketCloudAuth(client *scm.Client, auth *AuthOptions) error {
+	username := auth.Username()
+	if username == "" {
+		return errors.Errorf("no username supplied")
+	}
+	if auth.ClientID() != "" && auth.ClientSecret() != "" {
+		config := clientcredentials.Config{
+			ClientID:     auth.ClientID(),
+			ClientSecret: auth.ClientSecret(),
+			TokenURL:     "https://bitbucket.org/site/oauth2/access_token",
+		}
+		client.Client = config.Client(context.Background())
+		return nil
+	}
+	// BB App Password / PAT
+	client.Client = &http.Client{
+		Transport: &transport.BasicAuth{
+			Username: username,
+			Password: auth.Token(),
+		},
+	}
+	return nil
+}
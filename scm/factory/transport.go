@@ -0,0 +1,289 @@
+package factory
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/transport"
+	scmoauth2 "github.com/jenkins-x/go-scm/scm/transport/oauth2"
+)
+
+// ConfigureTransport configures the *http.Transport sending
+// the requests of the client. The transport is the Base of
+// the transports wrapping it, such as the one adding the
+// credentials, so the auth of the driver is kept. The
+// transport is cloned before it is configured, since it may
+// be shared, such as the http.DefaultTransport.
+//
+// The option must be applied after any Client option. It
+// has no effect on transports wrapped by transports other
+// than those of the scm/transport and oauth2 packages and
+// the TransportWrapper implementations, or on requests sent
+// outside the client, such as the token requests of OAuth2
+// client credentials.
+func ConfigureTransport(configure func(*http.Transport)) ClientOptionFunc {
+	return func(client *scm.Client) {
+		replaceBaseTransport(client, func(rt http.RoundTripper) http.RoundTripper {
+			var t *http.Transport
+			switch base := rt.(type) {
+			case nil:
+				t = newTransport()
+			case *http.Transport:
+				t = base.Clone()
+			default:
+				// the transport cannot be configured.
+				return rt
+			}
+			configure(t)
+			return t
+		})
+	}
+}
+
+// SetRootCAs configures the client to verify the
+// certificates of the server using the certificate
+// authorities of the pool.
+func SetRootCAs(pool *x509.CertPool) ClientOptionFunc {
+	return configureTLS(func(config *tls.Config) {
+		config.RootCAs = pool
+	})
+}
+
+// SetCABundle configures the client to verify the
+// certificates of the server using the system certificate
+// authorities and those of the PEM encoded bundle. If the
+// bundle holds no certificate, the requests of the client
+// fail.
+func SetCABundle(pem []byte) ClientOptionFunc {
+	pool, err := certPool(pem)
+	if err != nil {
+		return func(client *scm.Client) {
+			client.Client = &http.Client{Transport: errorTransport{err: err}}
+		}
+	}
+	return SetRootCAs(pool)
+}
+
+// SetClientCertificate configures the client to present
+// the certificate to servers requiring mutual TLS.
+func SetClientCertificate(cert tls.Certificate) ClientOptionFunc {
+	return configureTLS(func(config *tls.Config) {
+		config.Certificates = append(config.Certificates, cert)
+	})
+}
+
+// SetInsecureSkipVerify configures the client to skip the
+// verification of the certificates of the server. It makes
+// the client vulnerable to man-in-the-middle attacks, so it
+// should only be used for testing.
+func SetInsecureSkipVerify() ClientOptionFunc {
+	return configureTLS(func(config *tls.Config) {
+		config.InsecureSkipVerify = true // nolint
+	})
+}
+
+// SetProxy configures the client to send the requests
+// through the proxy. The http, https and socks5 schemes are
+// supported. A nil URL disables the proxy, including the
+// one configured by the environment.
+func SetProxy(proxy *url.URL) ClientOptionFunc {
+	return ConfigureTransport(func(t *http.Transport) {
+		if proxy == nil {
+			t.Proxy = nil
+			return
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	})
+}
+
+// SetTimeout configures the time limit of the requests of
+// the client, including connecting, redirects and reading
+// the response body. A zero timeout means no timeout.
+func SetTimeout(timeout time.Duration) ClientOptionFunc {
+	return func(client *scm.Client) {
+		httpClient := new(http.Client)
+		if client.Client != nil {
+			// copy the client since it may be shared.
+			*httpClient = *client.Client
+		}
+		httpClient.Timeout = timeout
+		client.Client = httpClient
+	}
+}
+
+// configureTLS configures the TLS config of the transport
+// of the client.
+func configureTLS(configure func(*tls.Config)) ClientOptionFunc {
+	return ConfigureTransport(func(t *http.Transport) {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		configure(t.TLSClientConfig)
+	})
+}
+
+// TransportWrapper is implemented by the transports wrapping
+// a base transport, so that the options configuring the
+// transport of the client, such as ConfigureTransport, can
+// replace their base transport.
+type TransportWrapper interface {
+	http.RoundTripper
+
+	// Unwrap returns the base transport, or nil for the
+	// default transport.
+	Unwrap() http.RoundTripper
+
+	// WithBase returns a copy of the transport wrapping the
+	// base transport.
+	WithBase(base http.RoundTripper) http.RoundTripper
+}
+
+// knownTransports are the types of the transports whose Base
+// field can be replaced in a copy holding their exported
+// fields. Their unexported fields, such as locks and caches,
+// are safe to leave zero.
+var knownTransports = map[reflect.Type]bool{
+	reflect.TypeOf(transport.Authorization{}):         true,
+	reflect.TypeOf(transport.BasicAuth{}):             true,
+	reflect.TypeOf(transport.BearerToken{}):           true,
+	reflect.TypeOf(transport.Cache{}):                 true,
+	reflect.TypeOf(transport.Custom{}):                true,
+	reflect.TypeOf(transport.GitHubApp{}):             true,
+	reflect.TypeOf(transport.GitHubAppInstallation{}): true,
+	reflect.TypeOf(transport.PrivateToken{}):          true,
+	reflect.TypeOf(scmoauth2.Transport{}):             true,
+	reflect.TypeOf(oauth2.Transport{}):                true,
+}
+
+// replaceBaseTransport replaces the innermost transport of
+// the client, found by unwrapping the known transports and
+// the TransportWrapper implementations wrapping it, with the
+// transport returned by the function. A nil transport stands
+// for the default one. Other transports are not unwrapped,
+// since copying them could lose or share their state.
+//
+// The wrapping transports may be shared with other clients,
+// so they are copied rather than modified.
+func replaceBaseTransport(client *scm.Client, replace func(http.RoundTripper) http.RoundTripper) {
+	httpClient := new(http.Client)
+	if client.Client != nil {
+		// copy the client since it may be shared.
+		*httpClient = *client.Client
+	}
+	client.Client = httpClient
+
+	var wrappers []func(http.RoundTripper) http.RoundTripper
+	rt := httpClient.Transport
+	for {
+		base, withBase, ok := unwrapTransport(rt)
+		if !ok {
+			break
+		}
+		wrappers = append(wrappers, withBase)
+		rt = base
+	}
+	replaced := replace(rt)
+	if replaced == nil || sameTransport(replaced, rt) {
+		return
+	}
+	for i := len(wrappers) - 1; i >= 0; i-- {
+		replaced = wrappers[i](replaced)
+	}
+	httpClient.Transport = replaced
+}
+
+// unwrapTransport returns the base transport of the wrapping
+// transport, and a function returning a copy of the wrapping
+// transport with another base. It returns false if the
+// transport is not a known wrapping transport.
+func unwrapTransport(rt http.RoundTripper) (http.RoundTripper, func(http.RoundTripper) http.RoundTripper, bool) {
+	if wrapper, ok := rt.(TransportWrapper); ok {
+		return wrapper.Unwrap(), wrapper.WithBase, true
+	}
+	v := reflect.ValueOf(rt)
+	if v.Kind() != reflect.Pointer || v.IsNil() || !knownTransports[v.Elem().Type()] {
+		return nil, nil, false
+	}
+	base, _ := v.Elem().FieldByName("Base").Interface().(http.RoundTripper)
+	return base, func(base http.RoundTripper) http.RoundTripper {
+		clone := cloneTransport(v)
+		field := clone.Elem().FieldByName("Base")
+		if base == nil {
+			field.Set(reflect.Zero(field.Type()))
+		} else {
+			field.Set(reflect.ValueOf(base))
+		}
+		return clone.Interface().(http.RoundTripper)
+	}, true
+}
+
+// cloneTransport returns a new transport of the type of the
+// known transport pointer, holding a copy of its exported
+// fields.
+func cloneTransport(v reflect.Value) reflect.Value {
+	clone := reflect.New(v.Elem().Type())
+	for i := 0; i < v.Elem().NumField(); i++ {
+		if v.Elem().Type().Field(i).IsExported() {
+			clone.Elem().Field(i).Set(v.Elem().Field(i))
+		}
+	}
+	return clone
+}
+
+// sameTransport reports whether both transports are the
+// same, without panicking on non-comparable transports.
+func sameTransport(a, b http.RoundTripper) bool {
+	if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return a == nil && b == nil
+	}
+	return reflect.TypeOf(a).Comparable() && a == b
+}
+
+// newTransport returns a copy of the default transport.
+func newTransport() *http.Transport {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	// the default transport was replaced, such as by a
+	// test, so start from the default settings.
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// certPool returns the system certificate pool extended
+// with the certificates of the PEM encoded bundle.
+func certPool(pem []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificates found in the CA bundle")
+	}
+	return pool, nil
+}
+
+// errorTransport is an http.RoundTripper failing every
+// request with an error.
+type errorTransport struct {
+	err error
+}
+
+// RoundTrip returns the error.
+func (t errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
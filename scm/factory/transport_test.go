@@ -0,0 +1,215 @@
+package factory
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userHandler serves the GitLab user of the Private-Token.
+func userHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/user", r.URL.Path)
+		if r.Header.Get("Private-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1, "username": "bob"}`))
+	}
+}
+
+func findUser(t *testing.T, serverURL string, opts ...ClientOptionFunc) error {
+	client, err := NewClient("gitlab", serverURL, "token", opts...)
+	require.NoError(t, err)
+	user, _, err := client.Users.Find(context.Background())
+	if err != nil {
+		return err
+	}
+	assert.Equal(t, "bob", user.Login)
+	return nil
+}
+
+func TestSetRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(userHandler(t))
+	defer server.Close()
+
+	err := findUser(t, server.URL)
+	assert.Error(t, err, "the certificate of the server should not be trusted")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	assert.NoError(t, findUser(t, server.URL, SetRootCAs(pool)))
+}
+
+func TestSetCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(userHandler(t))
+	defer server.Close()
+
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, findUser(t, server.URL, SetCABundle(bundle)))
+
+	err := findUser(t, server.URL, SetCABundle([]byte("invalid")))
+	assert.ErrorContains(t, err, "no certificates found in the CA bundle")
+}
+
+func TestSetInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(userHandler(t))
+	defer server.Close()
+
+	assert.NoError(t, findUser(t, server.URL, SetInsecureSkipVerify()))
+}
+
+func TestSetClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(userHandler(t))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	err := findUser(t, server.URL, SetRootCAs(pool))
+	assert.Error(t, err, "the server should require a client certificate")
+
+	cert := newCertificate(t)
+	assert.NoError(t, findUser(t, server.URL, SetRootCAs(pool), SetClientCertificate(cert)))
+}
+
+func TestSetProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		userHandler(t)(w, r)
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	assert.NoError(t, findUser(t, "http://gitlab.example.com", SetProxy(proxyURL)))
+	assert.Equal(t, []string{"http://gitlab.example.com/api/v4/user"}, proxied)
+}
+
+func TestSetTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	err := findUser(t, server.URL, SetTimeout(50*time.Millisecond))
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func TestConfigureTransportDoesNotModifySharedClient(t *testing.T) {
+	shared := &http.Client{}
+	client, err := NewClient("github", "", "", Client(shared), SetInsecureSkipVerify(), SetTimeout(time.Second))
+	require.NoError(t, err)
+
+	assert.Nil(t, shared.Transport)
+	assert.Zero(t, shared.Timeout)
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil {
+		assert.False(t, config.InsecureSkipVerify)
+	}
+	transport, ok := client.Client.Transport.(*http.Transport)
+	require.True(t, ok, "want an *http.Transport, got %T", client.Client.Transport)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, time.Second, client.Client.Timeout)
+}
+
+func TestConfigureTransportDoesNotModifySharedTransport(t *testing.T) {
+	shared := &http.Client{Transport: &transport.PrivateToken{Token: "abc123"}}
+	client, err := NewClient("gitlab", "", "", Client(shared), SetInsecureSkipVerify())
+	require.NoError(t, err)
+
+	assert.Nil(t, shared.Transport.(*transport.PrivateToken).Base)
+	auth, ok := client.Client.Transport.(*transport.PrivateToken)
+	require.True(t, ok, "want a *transport.PrivateToken, got %T", client.Client.Transport)
+	assert.Equal(t, "abc123", auth.Token)
+	base, ok := auth.Base.(*http.Transport)
+	require.True(t, ok, "want an *http.Transport, got %T", auth.Base)
+	assert.True(t, base.TLSClientConfig.InsecureSkipVerify)
+}
+
+// countingTransport counts the requests it sends through its
+// base transport. It is unknown to the factory.
+type countingTransport struct {
+	Base  http.RoundTripper
+	count int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.count++
+	return t.Base.RoundTrip(r)
+}
+
+// wrappingTransport is a countingTransport implementing
+// TransportWrapper.
+type wrappingTransport struct {
+	countingTransport
+}
+
+func (t *wrappingTransport) Unwrap() http.RoundTripper {
+	return t.Base
+}
+
+func (t *wrappingTransport) WithBase(base http.RoundTripper) http.RoundTripper {
+	return &wrappingTransport{countingTransport{Base: base}}
+}
+
+func TestConfigureTransportUnknownTransport(t *testing.T) {
+	unknown := &countingTransport{Base: http.DefaultTransport, count: 1}
+	client, err := NewClient("github", "", "", Client(&http.Client{Transport: unknown}), SetInsecureSkipVerify())
+	require.NoError(t, err)
+
+	assert.Same(t, unknown, client.Client.Transport, "want the unknown transport unchanged")
+	assert.Equal(t, 1, unknown.count)
+}
+
+func TestConfigureTransportWrapper(t *testing.T) {
+	wrapper := &wrappingTransport{countingTransport{Base: &transport.PrivateToken{Token: "abc123"}}}
+	client, err := NewClient("github", "", "", Client(&http.Client{Transport: wrapper}), SetInsecureSkipVerify())
+	require.NoError(t, err)
+
+	assert.IsType(t, &transport.PrivateToken{}, wrapper.Base)
+	assert.Nil(t, wrapper.Base.(*transport.PrivateToken).Base)
+	got, ok := client.Client.Transport.(*wrappingTransport)
+	require.True(t, ok, "want a *wrappingTransport, got %T", client.Client.Transport)
+	auth, ok := got.Base.(*transport.PrivateToken)
+	require.True(t, ok, "want a *transport.PrivateToken, got %T", got.Base)
+	assert.Equal(t, "abc123", auth.Token)
+	base, ok := auth.Base.(*http.Transport)
+	require.True(t, ok, "want an *http.Transport, got %T", auth.Base)
+	assert.True(t, base.TLSClientConfig.InsecureSkipVerify)
+}
+
+// newCertificate returns a self-signed client certificate.
+func newCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
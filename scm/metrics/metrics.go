@@ -0,0 +1,107 @@
+// Package metrics instruments scm.Client API calls.
+//
+// Metrics are reported through the small Recorder
+// interface so that any metrics library can be used. The
+// requests are labeled by driver, service and templated
+// path rather than the raw URL, which keeps the number of
+// distinct label values bounded.
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// StatusClassError is the status class of requests that
+// failed without receiving a response.
+const StatusClassError = "error"
+
+// Labels describes an API request.
+type Labels struct {
+	// Driver is the name of the provider driver.
+	Driver string
+
+	// Service is the name of the scm.Client service that
+	// sent the request, see Service.
+	Service string
+
+	// Method is the HTTP method.
+	Method string
+
+	// Path is the templated request path, see TemplatePath.
+	Path string
+
+	// StatusClass is the class of the HTTP status, such
+	// as 2xx or 5xx, or StatusClassError if no response
+	// was received.
+	StatusClass string
+}
+
+// Recorder records the metrics of API requests.
+// Implementations typically forward them to counters,
+// histograms and gauges of a metrics library.
+type Recorder interface {
+	// RecordRequest records a request and its latency.
+	RecordRequest(labels Labels, duration time.Duration)
+
+	// RecordError records a failed request, either a
+	// transport error or a 4xx or 5xx response.
+	RecordError(labels Labels)
+
+	// RecordRateLimit records the rate limit reported by
+	// the provider.
+	RecordRateLimit(driver string, rate scm.Rate)
+}
+
+// Instrument registers middleware with the client that
+// reports the metrics of every request to the recorder.
+func Instrument(client *scm.Client, recorder Recorder) {
+	client.Use(Middleware(recorder))
+}
+
+// Middleware returns middleware that reports the metrics
+// of every request to the recorder.
+func Middleware(recorder Recorder) scm.Middleware {
+	record := func(ctx context.Context, event *scm.RequestEvent) {
+		labels := NewLabels(event)
+		recorder.RecordRequest(labels, event.Duration)
+		if event.Err != nil {
+			recorder.RecordError(labels)
+		}
+		if event.Response != nil && event.Response.Rate != (scm.Rate{}) {
+			recorder.RecordRateLimit(labels.Driver, event.Response.Rate)
+		}
+	}
+	return scm.Middleware{
+		AfterResponse: record,
+		OnError: func(ctx context.Context, event *scm.RequestEvent) {
+			// error responses are recorded by AfterResponse.
+			if event.Response == nil {
+				record(ctx, event)
+			}
+		},
+	}
+}
+
+// NewLabels returns the labels describing the request.
+func NewLabels(event *scm.RequestEvent) Labels {
+	path := TemplatePath(event.Driver, event.Request.Path)
+	return Labels{
+		Driver:      event.Driver.String(),
+		Service:     Service(path),
+		Method:      event.Request.Method,
+		Path:        path,
+		StatusClass: statusClass(event.Response),
+	}
+}
+
+// statusClass returns the class of the response status.
+func statusClass(res *scm.Response) string {
+	if res == nil {
+		return StatusClassError
+	}
+	return strconv.Itoa(res.Status/100) + "xx"
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type recorder struct {
+	requests []Labels
+	errors   []Labels
+	rates    []scm.Rate
+}
+
+func (r *recorder) RecordRequest(labels Labels, duration time.Duration) {
+	r.requests = append(r.requests, labels)
+}
+
+func (r *recorder) RecordError(labels Labels) {
+	r.errors = append(r.errors, labels)
+}
+
+func (r *recorder) RecordRateLimit(driver string, rate scm.Rate) {
+	r.rates = append(r.rates, rate)
+}
+
+func TestInstrument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Reset", "1512076018")
+		if r.URL.Path == "/repos/octocat/hello-world/pulls/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	base, _ := url.Parse(server.URL + "/")
+	client := &scm.Client{BaseURL: base, Driver: scm.DriverGithub}
+	r := new(recorder)
+	Instrument(client, r)
+
+	for _, path := range []string{"repos/octocat/hello-world/pulls/1", "repos/octocat/hello-world/pulls/2"} {
+		res, err := client.Do(context.Background(), &scm.Request{Method: "GET", Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	want := []Labels{
+		{Driver: "github", Service: ServicePullRequests, Method: "GET", Path: "repos/{owner}/{repo}/pulls/{number}", StatusClass: "2xx"},
+		{Driver: "github", Service: ServicePullRequests, Method: "GET", Path: "repos/{owner}/{repo}/pulls/{number}", StatusClass: "4xx"},
+	}
+	if diff := cmp.Diff(want, r.requests); diff != "" {
+		t.Errorf("Unexpected requests")
+		t.Log(diff)
+	}
+	if diff := cmp.Diff(want[1:], r.errors); diff != "" {
+		t.Errorf("Unexpected errors")
+		t.Log(diff)
+	}
+	if got, want := len(r.rates), 2; got != want {
+		t.Fatalf("Want %d rate limits recorded, got %d", want, got)
+	}
+	if got, want := r.rates[1].Remaining, 4999; got != want {
+		t.Errorf("Want remaining rate limit %d, got %d", want, got)
+	}
+}
+
+func TestInstrumentTransportError(t *testing.T) {
+	base, _ := url.Parse("http://127.0.0.1:0/")
+	client := &scm.Client{BaseURL: base, Driver: scm.DriverGitlab}
+	r := new(recorder)
+	Instrument(client, r)
+
+	if _, err := client.Do(context.Background(), &scm.Request{Method: "GET", Path: "api/v4/user"}); err == nil {
+		t.Fatalf("Want transport error")
+	}
+	want := []Labels{
+		{Driver: "gitlab", Service: ServiceUsers, Method: "GET", Path: "api/v4/user", StatusClass: StatusClassError},
+	}
+	if diff := cmp.Diff(want, r.requests); diff != "" {
+		t.Errorf("Unexpected requests")
+		t.Log(diff)
+	}
+	if diff := cmp.Diff(want, r.errors); diff != "" {
+		t.Errorf("Unexpected errors")
+		t.Log(diff)
+	}
+}
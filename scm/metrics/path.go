@@ -0,0 +1,252 @@
+package metrics
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// Service names used to label requests, matching the
+// service fields of scm.Client.
+const (
+	ServiceApps          = "Apps"
+	ServiceContents      = "Contents"
+	ServiceDeployments   = "Deployments"
+	ServiceGit           = "Git"
+	ServiceGraphQL       = "GraphQL"
+	ServiceIssues        = "Issues"
+	ServiceMilestones    = "Milestones"
+	ServiceOrganizations = "Organizations"
+	ServicePullRequests  = "PullRequests"
+	ServiceReleases      = "Releases"
+	ServiceRepositories  = "Repositories"
+	ServiceReviews       = "Reviews"
+	ServiceUsers         = "Users"
+	ServiceOther         = "Other"
+)
+
+// staticSegments are the path segments used by the
+// provider APIs that are kept verbatim in templated
+// paths. Any other segment is replaced by a placeholder,
+// which keeps the number of distinct paths bounded.
+var staticSegments = map[string]bool{
+	"_apis": true, "access_tokens": true, "api": true, "app": true,
+	"approvals": true, "approve": true, "assignees": true, "blame": true,
+	"branches": true, "browse": true, "changes": true, "collaborators": true,
+	"combined-statuses": true, "comments": true, "commit": true, "commits": true,
+	"compare": true, "contents": true, "decline": true, "default-reviewers": true,
+	"deployments": true, "diff": true, "diffs": true, "discussions": true,
+	"dismissals": true, "events": true, "files": true, "forks": true,
+	"git": true, "graphql": true, "groups": true, "heads": true,
+	"hooks": true, "installation": true, "installations": true, "invitations": true,
+	"issues": true, "items": true, "labels": true, "latest": true,
+	"lock": true, "members": true, "memberships": true, "merge": true,
+	"merge_requests": true, "milestones": true, "notes": true, "orgs": true,
+	"participants": true, "permission": true, "permissions": true, "projects": true,
+	"pull": true, "pull-requests": true, "pullRequests": true, "pullrequests": true,
+	"pulls": true, "pushes": true, "raw": true, "ref": true,
+	"refs": true, "releases": true, "repos": true, "repositories": true,
+	"repository": true, "repository_invitations": true, "requested_reviewers": true, "rest": true,
+	"reviewers": true, "reviews": true, "search": true, "src": true,
+	"status": true, "statuses": true, "tags": true, "teams": true,
+	"threads": true, "tree": true, "trees": true, "user": true,
+	"users": true, "workspaces": true, "1.0": true, "2.0": true,
+	"v1": true, "v3": true, "v4": true, "build-status": true,
+}
+
+// paramNames names the placeholder of the segment that
+// follows a static segment, for the paths of every driver.
+var paramNames = map[string]string{
+	"branches":       "{branch}",
+	"commit":         "{sha}",
+	"commits":        "{sha}",
+	"compare":        "{range}",
+	"heads":          "{ref}",
+	"installations":  "{installation}",
+	"orgs":           "{org}",
+	"statuses":       "{sha}",
+	"tags":           "{tag}",
+	"teams":          "{team}",
+	"users":          "{user}",
+	"pulls":          "{number}",
+	"issues":         "{number}",
+	"merge_requests": "{number}",
+	"pull-requests":  "{number}",
+	"pullrequests":   "{number}",
+	"pullRequests":   "{number}",
+	"milestones":     "{number}",
+	"comments":       "{id}",
+	"hooks":          "{id}",
+	"releases":       "{id}",
+	"deployments":    "{id}",
+	"reviews":        "{id}",
+	"labels":         "{label}",
+	"notes":          "{id}",
+}
+
+// githubParamNames names the placeholders of the paths of
+// GitHub and the drivers sharing its API layout, such as
+// repos/{owner}/{repo}.
+var githubParamNames = map[string]string{
+	"repos":   "{owner}",
+	"{owner}": "{repo}",
+}
+
+// driverParamNames names the placeholders of the segments
+// whose meaning depends on the driver, on top of the
+// paramNames. The drivers missing from the map use the
+// GitHub names.
+var driverParamNames = map[scm.Driver]map[string]string{
+	scm.DriverGithub: githubParamNames,
+	scm.DriverGitea:  githubParamNames,
+	scm.DriverGogs:   githubParamNames,
+	scm.DriverGitlab: {
+		// api/v4/projects/{project}/merge_requests/{number}
+		"projects": "{project}",
+		"groups":   "{group}",
+	},
+	scm.DriverStash: {
+		// rest/api/1.0/projects/{project}/repos/{repo}
+		"projects": "{project}",
+		"repos":    "{repo}",
+	},
+	scm.DriverBitbucket: {
+		// 2.0/repositories/{workspace}/{repo}
+		"repositories": "{workspace}",
+		"{workspace}":  "{repo}",
+		"workspaces":   "{workspace}",
+	},
+	scm.DriverAzure: {
+		// {org}/{project}/_apis/git/repositories/{repo}
+		"repositories": "{repo}",
+		"projects":     "{project}",
+	},
+}
+
+// paramName returns the name of the placeholder of the
+// segment following the prev segment in the paths of the
+// driver, or an empty string if none.
+func paramName(driver scm.Driver, prev string) string {
+	names, ok := driverParamNames[driver]
+	if !ok {
+		names = githubParamNames
+	}
+	if name := names[prev]; name != "" {
+		return name
+	}
+	return paramNames[prev]
+}
+
+// pathSegments are the static segments after which the
+// remainder of the path is a file path.
+var pathSegments = map[string]bool{
+	"browse":   true,
+	"contents": true,
+	"files":    true,
+	"raw":      true,
+	"src":      true,
+}
+
+var numeric = regexp.MustCompile(`^[0-9]+$`)
+
+// TemplatePath returns the request path of the driver with
+// parameters such as owner, repository, numbers, references
+// and file paths replaced by placeholders, for example
+// repos/{owner}/{repo}/pulls/{number}/comments for GitHub.
+func TemplatePath(driver scm.Driver, path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "/"
+	}
+	var out []string
+	for _, segment := range strings.Split(path, "/") {
+		prev := ""
+		if len(out) > 0 {
+			prev = out[len(out)-1]
+		}
+		name := paramName(driver, prev)
+		switch {
+		case pathSegments[prev]:
+			out = append(out, "{path}")
+			return strings.Join(out, "/")
+		case staticSegments[segment]:
+			out = append(out, segment)
+		case name != "":
+			out = append(out, name)
+		case numeric.MatchString(segment):
+			out = append(out, "{id}")
+		default:
+			out = append(out, "{param}")
+		}
+	}
+	return strings.Join(out, "/")
+}
+
+// serviceSegments maps static path segments to the service
+// handling the request.
+var serviceSegments = map[string]string{
+	"access_tokens":          ServiceApps,
+	"app":                    ServiceApps,
+	"installation":           ServiceApps,
+	"installations":          ServiceApps,
+	"browse":                 ServiceContents,
+	"contents":               ServiceContents,
+	"files":                  ServiceContents,
+	"raw":                    ServiceContents,
+	"src":                    ServiceContents,
+	"deployments":            ServiceDeployments,
+	"branches":               ServiceGit,
+	"commit":                 ServiceGit,
+	"commits":                ServiceGit,
+	"compare":                ServiceGit,
+	"git":                    ServiceGit,
+	"refs":                   ServiceGit,
+	"tags":                   ServiceGit,
+	"trees":                  ServiceGit,
+	"graphql":                ServiceGraphQL,
+	"issues":                 ServiceIssues,
+	"milestones":             ServiceMilestones,
+	"groups":                 ServiceOrganizations,
+	"memberships":            ServiceOrganizations,
+	"members":                ServiceOrganizations,
+	"orgs":                   ServiceOrganizations,
+	"teams":                  ServiceOrganizations,
+	"workspaces":             ServiceOrganizations,
+	"merge_requests":         ServicePullRequests,
+	"pull-requests":          ServicePullRequests,
+	"pullRequests":           ServicePullRequests,
+	"pullrequests":           ServicePullRequests,
+	"pulls":                  ServicePullRequests,
+	"releases":               ServiceReleases,
+	"collaborators":          ServiceRepositories,
+	"hooks":                  ServiceRepositories,
+	"projects":               ServiceRepositories,
+	"repos":                  ServiceRepositories,
+	"repositories":           ServiceRepositories,
+	"repository_invitations": ServiceRepositories,
+	"status":                 ServiceRepositories,
+	"statuses":               ServiceRepositories,
+	"approvals":              ServiceReviews,
+	"reviews":                ServiceReviews,
+	"user":                   ServiceUsers,
+	"users":                  ServiceUsers,
+}
+
+// Service returns the name of the scm.Client service
+// handling the templated path. The most specific static
+// segment of the path determines the service, so that
+// repos/{owner}/{repo}/pulls/{number}/reviews is handled
+// by the Reviews service.
+func Service(templated string) string {
+	segments := strings.Split(templated, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if service, ok := serviceSegments[segments[i]]; ok {
+			return service
+		}
+	}
+	return ServiceOther
+}
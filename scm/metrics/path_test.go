@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestTemplatePath(t *testing.T) {
+	tests := []struct {
+		driver   scm.Driver
+		path     string
+		template string
+		service  string
+	}{
+		{scm.DriverGithub, "repos/octocat/hello-world/pulls/1347/comments?page=2", "repos/{owner}/{repo}/pulls/{number}/comments", ServicePullRequests},
+		{scm.DriverGithub, "repos/octocat/hello-world/pulls/1347/reviews", "repos/{owner}/{repo}/pulls/{number}/reviews", ServiceReviews},
+		{scm.DriverGithub, "repos/octocat/hello-world/issues/1347/labels", "repos/{owner}/{repo}/issues/{number}/labels", ServiceIssues},
+		{scm.DriverGithub, "repos/octocat/hello-world/labels", "repos/{owner}/{repo}/labels", ServiceRepositories},
+		{scm.DriverGithub, "repos/octocat/hello-world/contents/docs/README.md?ref=main", "repos/{owner}/{repo}/contents/{path}", ServiceContents},
+		{scm.DriverGithub, "repos/octocat/hello-world/git/refs/heads/feature/x", "repos/{owner}/{repo}/git/refs/heads/{ref}/{param}", ServiceGit},
+		{scm.DriverGithub, "repos/octocat/hello-world/commits/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d/status", "repos/{owner}/{repo}/commits/{sha}/status", ServiceRepositories},
+		{scm.DriverGithub, "app/installations/42/access_tokens", "app/installations/{installation}/access_tokens", ServiceApps},
+		{scm.DriverGithub, "user", "user", ServiceUsers},
+		{scm.DriverGitea, "api/v1/repos/go-gitea/gitea/pulls/1", "api/v1/repos/{owner}/{repo}/pulls/{number}", ServicePullRequests},
+		{scm.DriverFake, "repos/octocat/hello-world", "repos/{owner}/{repo}", ServiceRepositories},
+		{scm.DriverGitlab, "api/v4/projects/diaspora%2Fdiaspora/merge_requests/1/notes", "api/v4/projects/{project}/merge_requests/{number}/notes", ServicePullRequests},
+		{scm.DriverGitlab, "api/v4/projects/diaspora%2Fdiaspora/repository/files/app%2Fmodels%2Fkey.rb", "api/v4/projects/{project}/repository/files/{path}", ServiceContents},
+		{scm.DriverStash, "rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities", "rest/api/1.0/projects/{project}/repos/{repo}/pull-requests/{number}/{param}", ServicePullRequests},
+		{scm.DriverStash, "rest/api/1.0/users/jcitizen/repos/my-repo", "rest/api/1.0/users/{user}/repos/{repo}", ServiceRepositories},
+		{scm.DriverBitbucket, "2.0/repositories/atlassian/stash-example-plugin/pullrequests/1", "2.0/repositories/{workspace}/{repo}/pullrequests/{number}", ServicePullRequests},
+		{scm.DriverAzure, "ORG/PROJ/_apis/git/repositories/REPO/pullrequests/1", "{param}/{param}/_apis/git/repositories/{repo}/pullrequests/{number}", ServicePullRequests},
+		{scm.DriverGithub, "", "/", ServiceOther},
+	}
+	for _, test := range tests {
+		got := TemplatePath(test.driver, test.path)
+		if got != test.template {
+			t.Errorf("Want template of %s %q to be %q, got %q", test.driver, test.path, test.template, got)
+		}
+		if got, want := Service(got), test.service; got != want {
+			t.Errorf("Want service of %s %q to be %q, got %q", test.driver, test.path, want, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package scm
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type (
+	// RequestEvent describes a single attempt of an API
+	// request sent by the Client.
+	RequestEvent struct {
+		// Driver identifies the provider of the Client.
+		Driver Driver
+
+		// Request is the API request. BeforeRequest
+		// callbacks may modify it before it is sent.
+		Request *Request
+
+		// Response is the API response, or nil if no
+		// response was received. Its body must not be
+		// consumed by the callbacks.
+		Response *Response
+
+		// Attempt is the number of the attempt, starting
+		// at one. It is greater than one for retries.
+		Attempt int
+
+		// Start is the time the attempt started.
+		Start time.Time
+
+		// Duration is the time it took to receive the
+		// response headers or the error.
+		Duration time.Duration
+
+		// Err is the transport error, or an *Error for
+		// responses with a 4xx or 5xx status.
+		Err error
+	}
+
+	// Middleware holds optional callbacks invoked around
+	// every API request sent by the Client. It can be used
+	// to plug in logging, metrics or auditing regardless
+	// of the driver.
+	Middleware struct {
+		// BeforeRequest is called before the request is
+		// sent.
+		BeforeRequest func(ctx context.Context, event *RequestEvent)
+
+		// AfterResponse is called when a response is
+		// received, including error responses.
+		AfterResponse func(ctx context.Context, event *RequestEvent)
+
+		// OnError is called when the request fails or the
+		// response has a 4xx or 5xx status.
+		OnError func(ctx context.Context, event *RequestEvent)
+	}
+)
+
+// Use appends the middleware to the chain of middleware
+// invoked around every request. Middleware are invoked in
+// the order they were added.
+func (c *Client) Use(middleware ...Middleware) {
+	c.mu.Lock()
+	c.middleware = append(c.middleware, middleware...)
+	c.mu.Unlock()
+}
+
+// beforeRequest invokes the BeforeRequest callbacks.
+func (c *Client) beforeRequest(ctx context.Context, middleware []Middleware, event *RequestEvent) {
+	for _, m := range middleware {
+		if m.BeforeRequest != nil {
+			m.BeforeRequest(ctx, event)
+		}
+	}
+}
+
+// afterRequest records the outcome of the request in the
+// event and invokes the AfterResponse and OnError
+// callbacks.
+func (c *Client) afterRequest(ctx context.Context, middleware []Middleware, event *RequestEvent, res *Response, err error) {
+	if len(middleware) == 0 {
+		return
+	}
+	event.Duration = time.Since(event.Start)
+	event.Response = res
+	event.Err = err
+	if res != nil && res.Status >= http.StatusBadRequest {
+		event.Err = NewError(c.Driver, res)
+	}
+	for _, m := range middleware {
+		if res != nil && m.AfterResponse != nil {
+			m.AfterResponse(ctx, event)
+		}
+		if event.Err != nil && m.OnError != nil {
+			m.OnError(ctx, event)
+		}
+	}
+}
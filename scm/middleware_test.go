@@ -0,0 +1,157 @@
+package scm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Audit"), "1"; got != want {
+			t.Errorf("Want header added by middleware %q, got %q", want, got)
+		}
+		w.Header().Set("X-GitHub-Request-Id", "DD0E:6011")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	base, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: base, Driver: DriverGithub}
+
+	var before, after, failed []*RequestEvent
+	client.Use(Middleware{
+		BeforeRequest: func(ctx context.Context, event *RequestEvent) {
+			if event.Request.Header == nil {
+				event.Request.Header = http.Header{}
+			}
+			event.Request.Header.Set("X-Audit", "1")
+			before = append(before, event)
+		},
+		AfterResponse: func(ctx context.Context, event *RequestEvent) {
+			after = append(after, event)
+		},
+		OnError: func(ctx context.Context, event *RequestEvent) {
+			failed = append(failed, event)
+		},
+	})
+
+	for _, path := range []string{"user", "missing"} {
+		res, err := client.Do(context.Background(), &Request{Method: "GET", Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	if got, want := len(before), 2; got != want {
+		t.Fatalf("Want %d BeforeRequest calls, got %d", want, got)
+	}
+	if got, want := len(after), 2; got != want {
+		t.Fatalf("Want %d AfterResponse calls, got %d", want, got)
+	}
+	if got, want := len(failed), 1; got != want {
+		t.Fatalf("Want %d OnError calls, got %d", want, got)
+	}
+
+	event := after[0]
+	if got, want := event.Driver, DriverGithub; got != want {
+		t.Errorf("Want driver %s, got %s", want, got)
+	}
+	if got, want := event.Response.ID, "DD0E:6011"; got != want {
+		t.Errorf("Want request ID %q, got %q", want, got)
+	}
+	if event.Duration <= 0 || event.Duration > time.Minute {
+		t.Errorf("Want request duration recorded, got %s", event.Duration)
+	}
+	if got, want := event.Attempt, 1; got != want {
+		t.Errorf("Want attempt %d, got %d", want, got)
+	}
+	if !IsScmNotFound(failed[0].Err) {
+		t.Errorf("Want not found error, got %v", failed[0].Err)
+	}
+}
+
+func TestClientMiddlewareTransportError(t *testing.T) {
+	base, _ := url.Parse("http://127.0.0.1:0/")
+	client := &Client{BaseURL: base}
+
+	var failed []*RequestEvent
+	client.Use(Middleware{
+		AfterResponse: func(ctx context.Context, event *RequestEvent) {
+			t.Errorf("Want AfterResponse not called without a response")
+		},
+		OnError: func(ctx context.Context, event *RequestEvent) {
+			failed = append(failed, event)
+		},
+	})
+	if _, err := client.Do(context.Background(), &Request{Method: "GET", Path: "user"}); err == nil {
+		t.Fatalf("Want transport error")
+	}
+	if len(failed) != 1 || failed[0].Err == nil || failed[0].Response != nil {
+		t.Errorf("Want OnError called with the transport error")
+	}
+}
+
+func TestClientMiddlewareRewrite(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if got, want := r.URL.Path, "/v2/user"; got != want {
+			t.Errorf("Want path rewritten by middleware %q, got %q", want, got)
+		}
+		if b, _ := io.ReadAll(r.Body); string(b) != "rewritten" {
+			t.Errorf("Want body rewritten by middleware, got %q", b)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	base, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: base, Retry: &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}}
+	client.Use(Middleware{
+		BeforeRequest: func(ctx context.Context, event *RequestEvent) {
+			event.Request.Path = "v2/user"
+			if event.Attempt == 1 {
+				event.Request.Body = strings.NewReader("rewritten")
+			}
+		},
+	})
+	res, err := client.Do(context.Background(), &Request{Method: "PUT", Path: "user", Body: strings.NewReader("original")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got, want := attempts, 2; got != want {
+		t.Errorf("Want %d attempts, got %d", want, got)
+	}
+}
+
+func TestClientMiddlewareInvalidPath(t *testing.T) {
+	base, _ := url.Parse("https://api.github.com/")
+	client := &Client{BaseURL: base}
+
+	var failed []*RequestEvent
+	client.Use(Middleware{
+		OnError: func(ctx context.Context, event *RequestEvent) {
+			failed = append(failed, event)
+		},
+	})
+	if _, err := client.Do(context.Background(), &Request{Method: "GET", Path: ":invalid"}); err == nil {
+		t.Fatalf("Want invalid path error")
+	}
+	if len(failed) != 1 || failed[0].Duration < 0 || failed[0].Duration > time.Minute {
+		t.Errorf("Want OnError called with the duration of the attempt")
+	}
+}
@@ -0,0 +1,171 @@
+package scm
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+type (
+	// ListFunc lists a single page of items using the
+	// pagination parameters in opts. It is typically a
+	// closure around one of the service List methods.
+	ListFunc[T any] func(ctx context.Context, opts *ListOptions) ([]T, *Response, error)
+
+	// PageOptions specifies optional parameters for
+	// iterating over all pages of a list.
+	PageOptions struct {
+		// ListOptions holds the options of the first page.
+		// The Size is used as the page size for every page.
+		ListOptions
+
+		// MaxItems stops the iteration once the given number
+		// of items has been returned. Zero means no limit.
+		MaxItems int
+
+		// Concurrency is the number of pages fetched in
+		// parallel when the provider reports the last page.
+		// Values less than two fetch pages sequentially.
+		Concurrency int
+	}
+)
+
+// Iterate returns an iterator lazily yielding every item of
+// every page returned by list. Pages are followed using the
+// Response.Page values returned by the driver: the NextURL
+// (Bitbucket), the Next page number (GitHub, GitLab,
+// Bitbucket Server, Gitea) or, when the driver returns no
+// pagination details, by requesting the following page as
+// long as full pages are returned.
+//
+// Iteration stops on the first error, which is yielded
+// with the zero value of T.
+func Iterate[T any](ctx context.Context, list ListFunc[T], opts *PageOptions) iter.Seq2[T, error] {
+	if opts == nil {
+		opts = &PageOptions{}
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		count := 0
+		// emit yields the items and reports whether the
+		// iteration should continue.
+		emit := func(items []T) bool {
+			for _, item := range items {
+				if opts.MaxItems > 0 && count >= opts.MaxItems {
+					return false
+				}
+				if !yield(item, nil) {
+					return false
+				}
+				count++
+			}
+			return opts.MaxItems == 0 || count < opts.MaxItems
+		}
+
+		page := opts.ListOptions
+		if page.Page == 0 && page.URL == "" {
+			page.Page = 1
+		}
+		for {
+			items, res, err := list(ctx, &page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !emit(items) {
+				return
+			}
+			if opts.Concurrency > 1 && res != nil && res.Page.NextURL == "" &&
+				res.Page.Next > page.Page && res.Page.Last >= res.Page.Next {
+				iterateConcurrent(ctx, list, page, res.Page.Next, res.Page.Last, opts.Concurrency, yield, emit)
+				return
+			}
+			next, ok := nextPage(page, res, len(items))
+			if !ok {
+				return
+			}
+			page = next
+		}
+	}
+}
+
+// ListAll returns all items of every page returned by
+// list. See Iterate for details.
+func ListAll[T any](ctx context.Context, list ListFunc[T], opts *PageOptions) ([]T, error) {
+	var all []T
+	for item, err := range Iterate(ctx, list, opts) {
+		if err != nil {
+			return all, err
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
+
+// iterateConcurrent fetches the pages first to last in
+// batches of the given size and emits their items in order.
+func iterateConcurrent[T any](ctx context.Context, list ListFunc[T], opts ListOptions, first, last, batch int, yield func(T, error) bool, emit func([]T) bool) {
+	type result struct {
+		items []T
+		err   error
+	}
+	for start := first; start <= last; start += batch {
+		end := min(start+batch-1, last)
+		results := make([]result, end-start+1)
+
+		var wg sync.WaitGroup
+		for i := range results {
+			page := opts
+			page.Page = start + i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				items, _, err := list(ctx, &page)
+				results[i] = result{items, err}
+			}()
+		}
+		wg.Wait()
+
+		for _, r := range results {
+			if r.err != nil {
+				var zero T
+				yield(zero, r.err)
+				return
+			}
+			if !emit(r.items) {
+				return
+			}
+		}
+	}
+}
+
+// nextPage returns the options for the page following the
+// current page, or false if the current page is the last.
+func nextPage(current ListOptions, res *Response, count int) (ListOptions, bool) {
+	if count == 0 {
+		return current, false
+	}
+	next := current
+	switch {
+	case res != nil && res.Page.NextURL != "":
+		if res.Page.NextURL == current.URL {
+			return current, false
+		}
+		next.URL = res.Page.NextURL
+		next.Page = res.Page.Next
+	case res != nil && res.Page.Next != 0:
+		if res.Page.Next <= current.Page {
+			return current, false
+		}
+		next.URL = ""
+		next.Page = res.Page.Next
+	case (res == nil || res.Page == Page{}) && current.Size > 0 && count == current.Size:
+		// the driver paginates locally without reporting
+		// pagination details so keep going while pages
+		// are full.
+		next.Page++
+	default:
+		return current, false
+	}
+	return next, true
+}
@@ -0,0 +1,146 @@
+package scm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// numbers returns a ListFunc serving the numbers 1 to n in
+// pages, populating the Response using the paginate func.
+func numbers(n int, paginate func(opts *ListOptions, res *Response, last int)) ListFunc[int] {
+	return func(ctx context.Context, opts *ListOptions) ([]int, *Response, error) {
+		size := opts.Size
+		if size == 0 {
+			size = 2
+		}
+		page := opts.Page
+		if opts.URL != "" {
+			fmt.Sscanf(opts.URL, "https://example.com/items?page=%d", &page)
+		}
+		var items []int
+		for i := (page-1)*size + 1; i <= n && i <= page*size; i++ {
+			items = append(items, i)
+		}
+		last := (n + size - 1) / size
+		res := &Response{}
+		if paginate == nil {
+			return items, nil, nil
+		}
+		paginate(&ListOptions{Page: page}, res, last)
+		return items, res, nil
+	}
+}
+
+func linkPages(opts *ListOptions, res *Response, last int) {
+	if opts.Page < last {
+		res.Page.Next = opts.Page + 1
+		res.Page.Last = last
+	}
+}
+
+func urlPages(opts *ListOptions, res *Response, last int) {
+	if opts.Page < last {
+		res.Page.NextURL = fmt.Sprintf("https://example.com/items?page=%d", opts.Page+1)
+		res.Page.Next = opts.Page + 1
+	}
+}
+
+func TestListAll(t *testing.T) {
+	want := []int{1, 2, 3, 4, 5, 6, 7}
+	tests := []struct {
+		name string
+		list ListFunc[int]
+		opts *PageOptions
+	}{
+		{name: "page numbers", list: numbers(7, linkPages)},
+		{name: "next url", list: numbers(7, urlPages)},
+		{name: "local", list: numbers(7, nil), opts: &PageOptions{ListOptions: ListOptions{Size: 2}}},
+		{name: "concurrent", list: numbers(7, linkPages), opts: &PageOptions{Concurrency: 3}},
+	}
+	for _, test := range tests {
+		got, err := ListAll(context.Background(), test.list, test.opts)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%s: Unexpected Results", test.name)
+			t.Log(diff)
+		}
+	}
+}
+
+func TestListAllMaxItems(t *testing.T) {
+	var calls int32
+	list := numbers(100, linkPages)
+	counted := func(ctx context.Context, opts *ListOptions) ([]int, *Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return list(ctx, opts)
+	}
+	got, err := ListAll(context.Background(), counted, &PageOptions{MaxItems: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int{1, 2, 3, 4, 5}, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got, want := atomic.LoadInt32(&calls), int32(3); got != want {
+		t.Errorf("Want %d pages requested, got %d", want, got)
+	}
+}
+
+func TestIterateError(t *testing.T) {
+	boom := errors.New("boom")
+	list := func(ctx context.Context, opts *ListOptions) ([]int, *Response, error) {
+		if opts.Page == 2 {
+			return nil, nil, boom
+		}
+		return []int{1, 2}, &Response{Page: Page{Next: 2}}, nil
+	}
+	got, err := ListAll(context.Background(), list, nil)
+	if err != boom {
+		t.Errorf("Want error %v, got %v", boom, err)
+	}
+	if diff := cmp.Diff([]int{1, 2}, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIterateBreak(t *testing.T) {
+	var calls int32
+	list := numbers(10, linkPages)
+	counted := func(ctx context.Context, opts *ListOptions) ([]int, *Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return list(ctx, opts)
+	}
+	for item := range Iterate(context.Background(), counted, nil) {
+		if item == 3 {
+			break
+		}
+	}
+	if got, want := atomic.LoadInt32(&calls), int32(2); got != want {
+		t.Errorf("Want %d pages requested, got %d", want, got)
+	}
+}
+
+func TestIterateRepeatedPage(t *testing.T) {
+	var calls int32
+	list := func(ctx context.Context, opts *ListOptions) ([]int, *Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return []int{1}, &Response{Page: Page{Next: 1}}, nil
+	}
+	got, err := ListAll(context.Background(), list, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Want iteration to stop when the next page does not advance")
+	}
+}
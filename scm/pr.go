@@ -209,6 +209,18 @@ const (
 	MergeableStateUnknown MergeableState = ""
 )
 
+// Merge methods of the PullRequestMergeOptions.
+const (
+	// MergeMethodMerge creates a merge commit.
+	MergeMethodMerge = "merge"
+	// MergeMethodSquash squashes the commits into one.
+	MergeMethodSquash = "squash"
+	// MergeMethodRebase rebases the commits onto the base branch.
+	MergeMethodRebase = "rebase"
+	// MergeMethodRebaseMerge rebases the commits and creates a merge commit.
+	MergeMethodRebaseMerge = "rebase-merge"
+)
+
 // Repository returns the base repository where the PR will merge to
 func (pr *PullRequest) Repository() Repository {
 	return pr.Base.Repo
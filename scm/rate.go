@@ -0,0 +1,99 @@
+package scm
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// rateLimitHeaderPrefixes are the header prefixes used by
+// the supported providers to report rate limits. GitHub,
+// Bitbucket Server and Gitea use the X-RateLimit- prefix
+// while GitLab uses the RateLimit- prefix.
+var rateLimitHeaderPrefixes = []string{"X-RateLimit-", "RateLimit-"}
+
+// bitbucketRateWindow is the rolling window of the rate
+// limits of Bitbucket Cloud.
+const bitbucketRateWindow = time.Hour
+
+// PopulateRate parses the rate limit response headers and
+// populates the Rate values in the Response. The Rate is
+// left untouched if the response does not report the rate
+// limit.
+func (r *Response) PopulateRate() {
+	if near := r.Header.Get("X-RateLimit-NearLimit"); near != "" {
+		r.populateBitbucketRate(near == "true")
+		return
+	}
+	for _, prefix := range rateLimitHeaderPrefixes {
+		remaining := r.Header.Get(prefix + "Remaining")
+		if remaining == "" {
+			continue
+		}
+		r.Rate.Remaining, _ = strconv.Atoi(remaining)
+		r.Rate.Limit, _ = strconv.Atoi(
+			r.Header.Get(prefix + "Limit"),
+		)
+		r.Rate.Reset, _ = strconv.ParseInt(
+			r.Header.Get(prefix+"Reset"), 10, 64,
+		)
+		return
+	}
+}
+
+// populateBitbucketRate populates the Rate from the headers
+// of Bitbucket Cloud, which reports the limit of the rolling
+// window but only whether less than 20% of the requests
+// remain. The Remaining value is then the upper bound of
+// this 20%, and the Reset the end of the window, so that a
+// Throttler pauses the requests near the limit.
+func (r *Response) populateBitbucketRate(near bool) {
+	limit, err := strconv.Atoi(r.Header.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return
+	}
+	r.Rate.Limit = limit
+	r.Rate.Remaining = limit
+	if near {
+		r.Rate.Remaining = limit / 5
+		r.Rate.Reset = time.Now().Add(bitbucketRateWindow).Unix()
+	}
+}
+
+// Throttler pauses requests when the last recorded rate
+// limit for the client drops below a threshold, until the
+// rate limit is reset.
+type Throttler struct {
+	// Threshold is the number of remaining requests below
+	// which requests are paused.
+	Threshold int
+
+	// MaxWait caps the time a request is paused. Zero
+	// means the request waits until the rate limit reset.
+	MaxWait time.Duration
+}
+
+// delay returns how long a request should be paused given
+// the last recorded rate limit.
+func (t *Throttler) delay(rate Rate) time.Duration {
+	if t == nil || rate.Limit == 0 || rate.Reset == 0 {
+		return 0
+	}
+	if rate.Remaining >= t.Threshold {
+		return 0
+	}
+	wait := time.Until(time.Unix(rate.Reset, 0))
+	if wait <= 0 {
+		return 0
+	}
+	if t.MaxWait > 0 && wait > t.MaxWait {
+		wait = t.MaxWait
+	}
+	return wait
+}
+
+// Wait blocks until the rate limit allows a new request
+// to be sent or the context is done.
+func (t *Throttler) Wait(ctx context.Context, rate Rate) error {
+	return sleep(ctx, t.delay(rate))
+}
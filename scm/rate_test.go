@@ -0,0 +1,106 @@
+package scm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResponsePopulateRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   Rate
+	}{
+		{
+			name: "github",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"60"},
+				"X-Ratelimit-Remaining": {"59"},
+				"X-Ratelimit-Reset":     {"1512076018"},
+			},
+			want: Rate{Limit: 60, Remaining: 59, Reset: 1512076018},
+		},
+		{
+			name: "gitlab",
+			header: http.Header{
+				"Ratelimit-Limit":     {"600"},
+				"Ratelimit-Remaining": {"599"},
+				"Ratelimit-Reset":     {"1512076018"},
+			},
+			want: Rate{Limit: 600, Remaining: 599, Reset: 1512076018},
+		},
+		{
+			name: "bitbucket",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"1000"},
+				"X-Ratelimit-Resource":  {"api-repo"},
+				"X-Ratelimit-Nearlimit": {"false"},
+			},
+			want: Rate{Limit: 1000, Remaining: 1000},
+		},
+		{
+			name: "limit only",
+			header: http.Header{
+				"X-Ratelimit-Limit": {"1000"},
+			},
+			want: Rate{},
+		},
+	}
+	for _, test := range tests {
+		res := &Response{Header: test.header}
+		res.PopulateRate()
+		if got, want := res.Rate, test.want; got != want {
+			t.Errorf("%s: Want rate %+v, got %+v", test.name, want, got)
+		}
+	}
+}
+
+func TestResponsePopulateRate_BitbucketNearLimit(t *testing.T) {
+	res := &Response{Header: http.Header{
+		"X-Ratelimit-Limit":     {"1000"},
+		"X-Ratelimit-Resource":  {"api-repo"},
+		"X-Ratelimit-Nearlimit": {"true"},
+	}}
+	res.PopulateRate()
+	if got, want := res.Rate.Remaining, 200; got != want {
+		t.Errorf("Want remaining %d near the limit, got %d", want, got)
+	}
+	reset := time.Unix(res.Rate.Reset, 0)
+	if until := time.Until(reset); until <= 0 || until > time.Hour {
+		t.Errorf("Want reset within the hour window, got %s", reset)
+	}
+}
+
+func TestThrottlerDelay(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	throttler := &Throttler{Threshold: 10, MaxWait: time.Minute}
+
+	if got := throttler.delay(Rate{Limit: 60, Remaining: 30, Reset: reset}); got != 0 {
+		t.Errorf("Want no delay above threshold, got %s", got)
+	}
+	if got := throttler.delay(Rate{Limit: 60, Remaining: 5, Reset: reset}); got != time.Minute {
+		t.Errorf("Want delay capped at %s, got %s", time.Minute, got)
+	}
+	if got := throttler.delay(Rate{Limit: 60, Remaining: 5, Reset: time.Now().Add(-time.Second).Unix()}); got != 0 {
+		t.Errorf("Want no delay once reset has passed, got %s", got)
+	}
+	if got := throttler.delay(Rate{}); got != 0 {
+		t.Errorf("Want no delay without a recorded rate, got %s", got)
+	}
+	var nilThrottler *Throttler
+	if got := nilThrottler.delay(Rate{Limit: 60, Reset: reset}); got != 0 {
+		t.Errorf("Want no delay for nil throttler, got %s", got)
+	}
+}
+
+func TestThrottlerWaitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	throttler := &Throttler{Threshold: 10}
+	rate := Rate{Limit: 60, Remaining: 0, Reset: time.Now().Add(time.Hour).Unix()}
+	if err := throttler.Wait(ctx, rate); err != context.Canceled {
+		t.Errorf("Want context canceled error, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package scm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRetryMinBackoff is the initial delay used
+	// between attempts when none is configured.
+	DefaultRetryMinBackoff = time.Second
+
+	// DefaultRetryMaxBackoff is the largest computed delay
+	// used between attempts when none is configured.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures how the Client retries failed
+// requests. Requests are retried on connection errors,
+// 5xx responses and rate limit responses (429, or a 403
+// carrying GitHub's primary or secondary rate limit
+// markers). A Retry-After or rate limit reset header sent
+// by the server takes precedence over the computed
+// exponential backoff.
+//
+// Non-idempotent requests (POST and PATCH) are only
+// retried when the server explicitly rejected them due to
+// rate limiting, unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts,
+	// including the first one. Values less than two
+	// disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry.
+	// It doubles on every subsequent attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the computed exponential backoff.
+	MaxBackoff time.Duration
+
+	// MaxRetryAfter is the longest server requested delay
+	// the client is willing to wait. If the server asks
+	// for a longer delay the response is returned to the
+	// caller as is. Zero means no limit.
+	MaxRetryAfter time.Duration
+
+	// RetryNonIdempotent allows POST and PATCH requests
+	// to be retried on connection errors and 5xx responses.
+	RetryNonIdempotent bool
+}
+
+// enabled returns true if the policy allows retries.
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+// backoff returns the jittered exponential delay before
+// the given attempt, where attempt 1 is the first retry.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	min := p.MinBackoff
+	if min <= 0 {
+		min = DefaultRetryMinBackoff
+	}
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = DefaultRetryMaxBackoff
+	}
+	d := min
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	// equal jitter: keep half of the delay and randomize
+	// the other half to avoid thundering herds.
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)) // #nosec
+}
+
+// retry reports whether the request should be attempted
+// again and how long to wait before doing so. The
+// attempt argument is the number of attempts made so far.
+func (p *RetryPolicy) retry(method string, res *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	idempotent := p.RetryNonIdempotent || isIdempotent(method)
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		return p.backoff(attempt), idempotent
+	}
+
+	if wait, ok := rateLimitDelay(res); ok {
+		if p.MaxRetryAfter > 0 && wait > p.MaxRetryAfter {
+			return 0, false
+		}
+		return wait, true
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return p.backoff(attempt), true
+	}
+	switch res.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if wait, ok := retryAfter(res.Header); ok {
+			if p.MaxRetryAfter > 0 && wait > p.MaxRetryAfter {
+				return 0, false
+			}
+			return wait, idempotent
+		}
+		return p.backoff(attempt), idempotent
+	}
+	return 0, false
+}
+
+// rateLimitDelay returns the delay requested by the server
+// if the response indicates the request was rejected due
+// to rate limiting.
+func rateLimitDelay(res *http.Response) (time.Duration, bool) {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusForbidden:
+	default:
+		return 0, false
+	}
+	if wait, ok := retryAfter(res.Header); ok {
+		return wait, true
+	}
+	if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			wait := time.Until(time.Unix(reset, 0))
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+	if res.StatusCode == http.StatusForbidden && isSecondaryRateLimit(res) {
+		// GitHub recommends waiting at least one minute
+		// when no explicit delay is provided.
+		return time.Minute, true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit peeks at the response body to
+// detect GitHub's secondary rate limit message. The body
+// is restored so that it can still be read by the caller.
+func isSecondaryRateLimit(res *http.Response) bool {
+	if res.Body == nil {
+		return false
+	}
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return false
+	}
+	msg := strings.ToLower(string(b))
+	return strings.Contains(msg, "secondary rate limit") ||
+		strings.Contains(msg, "abuse detection")
+}
+
+// retryAfter parses the Retry-After header, which is
+// either a number of seconds or an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// isIdempotent returns true if the HTTP method can be
+// safely replayed.
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodPost, http.MethodPatch:
+		return false
+	}
+	return true
+}
+
+// sleep waits for the duration d or until the context is
+// done, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,163 @@
+package scm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryClient(t *testing.T, handler http.HandlerFunc, policy *RetryPolicy) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	base, _ := url.Parse(server.URL + "/")
+	return &Client{BaseURL: base, Retry: policy}
+}
+
+func TestClientRetryServerError(t *testing.T) {
+	var calls int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	res, err := client.Do(context.Background(), &Request{Method: "GET", Path: "repos"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if got, want := res.Status, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got, want := atomic.LoadInt32(&calls), int32(3); got != want {
+		t.Errorf("Want %d attempts, got %d", want, got)
+	}
+}
+
+func TestClientRetryExhausted(t *testing.T) {
+	var calls int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+
+	res, err := client.Do(context.Background(), &Request{Method: "GET", Path: "repos"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if got, want := res.Status, 503; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got, want := atomic.LoadInt32(&calls), int32(2); got != want {
+		t.Errorf("Want %d attempts, got %d", want, got)
+	}
+}
+
+func TestClientRetryNonIdempotent(t *testing.T) {
+	var calls int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	res, err := client.Do(context.Background(), &Request{Method: "POST", Path: "repos", Body: strings.NewReader("{}")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if got, want := atomic.LoadInt32(&calls), int32(1); got != want {
+		t.Errorf("Want %d attempts, got %d", want, got)
+	}
+}
+
+func TestClientRetryReplaysBody(t *testing.T) {
+	var calls int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != `{"title":"hello"}` {
+			t.Errorf("Unexpected request body %q", b)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	res, err := client.Do(context.Background(), &Request{Method: "POST", Path: "repos", Body: strings.NewReader(`{"title":"hello"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if got, want := res.Status, 201; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got, want := atomic.LoadInt32(&calls), int32(2); got != want {
+		t.Errorf("Want %d attempts, got %d", want, got)
+	}
+}
+
+func TestClientRetrySecondaryRateLimit(t *testing.T) {
+	var calls int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"message":"You have exceeded a secondary rate limit."}`)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, &RetryPolicy{MaxAttempts: 2, MaxRetryAfter: time.Second})
+
+	// the default secondary rate limit delay exceeds the
+	// configured maximum, so the response is returned.
+	res, err := client.Do(context.Background(), &Request{Method: "GET", Path: "repos"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if got, want := res.Status, 403; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	b, _ := io.ReadAll(res.Body)
+	if !strings.Contains(string(b), "secondary rate limit") {
+		t.Errorf("Want response body preserved, got %q", b)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "5")
+	if got, ok := retryAfter(h); !ok || got != 5*time.Second {
+		t.Errorf("Want 5s delay, got %s", got)
+	}
+	h.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if got, ok := retryAfter(h); !ok || got != 0 {
+		t.Errorf("Want zero delay for past date, got %s", got)
+	}
+	h.Set("Retry-After", "soon")
+	if _, ok := retryAfter(h); ok {
+		t.Errorf("Want invalid Retry-After header ignored")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		got := p.backoff(attempt + 1)
+		if got < max/2 || got > max {
+			t.Errorf("Want backoff for attempt %d between %s and %s, got %s", attempt+1, max/2, max, got)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Command tracegen generates the decorators of the services
+// of scm.Client that trace every call of a service method,
+// see tracing.Instrument.
+//
+// It is run by go generate from the directory of the
+// tracing package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	dir := flag.String("scm", "..", "the directory of the scm package")
+	out := flag.String("o", "service_gen.go", "the generated file")
+	flag.Parse()
+
+	pkg, err := load(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load type checks the scm package.
+func load(dir string) (*types.Package, error) {
+	fset := token.NewFileSet()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, match, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check("github.com/jenkins-x/go-scm/scm", fset, files, nil)
+}
+
+// service is a service field of scm.Client.
+type service struct {
+	field string
+	named *types.Named
+	iface *types.Interface
+}
+
+// services returns the service fields of scm.Client whose
+// methods all take a context, in the order of the fields.
+func services(pkg *types.Package) ([]service, error) {
+	client, ok := pkg.Scope().Lookup("Client").Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("scm.Client is not a struct")
+	}
+	var out []service
+	for i := 0; i < client.NumFields(); i++ {
+		field := client.Field(i)
+		named, ok := field.Type().(*types.Named)
+		if !ok || !field.Exported() {
+			continue
+		}
+		iface, ok := named.Underlying().(*types.Interface)
+		if !ok || iface.NumMethods() == 0 || !takesContext(iface) {
+			continue
+		}
+		out = append(out, service{field: field.Name(), named: named, iface: iface})
+	}
+	return out, nil
+}
+
+// takesContext reports whether every method of the interface
+// takes a context.Context first parameter.
+func takesContext(iface *types.Interface) bool {
+	for i := 0; i < iface.NumMethods(); i++ {
+		params := iface.Method(i).Type().(*types.Signature).Params()
+		if params.Len() == 0 || types.TypeString(params.At(0).Type(), nil) != "context.Context" {
+			return false
+		}
+	}
+	return true
+}
+
+// generate returns the source of the decorators of the
+// services.
+func generate(pkg *types.Package) ([]byte, error) {
+	services, err := services(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]string{"context": "context"}
+	qualifier := func(p *types.Package) string {
+		imports[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// traceServices decorates the services of the client with\n")
+	fmt.Fprintf(&body, "// the spans of the tracer.\n")
+	fmt.Fprintf(&body, "func traceServices(client *scm.Client, tracer *serviceTracer) {\n")
+	for _, service := range services {
+		fmt.Fprintf(&body, "\tif client.%s != nil {\n", service.field)
+		fmt.Fprintf(&body, "\t\tclient.%s = &trace%s{next: client.%s, tracer: tracer}\n", service.field, service.named.Obj().Name(), service.field)
+		fmt.Fprintf(&body, "\t}\n")
+	}
+	fmt.Fprintf(&body, "}\n")
+
+	for _, service := range services {
+		typ := "trace" + service.named.Obj().Name()
+		fmt.Fprintf(&body, "\ntype %s struct {\n", typ)
+		fmt.Fprintf(&body, "\tnext %s\n", types.TypeString(service.named, qualifier))
+		fmt.Fprintf(&body, "\ttracer *serviceTracer\n")
+		fmt.Fprintf(&body, "}\n")
+
+		for i := 0; i < service.iface.NumMethods(); i++ {
+			method := service.iface.Method(i)
+			op := service.field + "." + method.Name()
+			if err := writeMethod(&body, typ, method, op, qualifier); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by tracegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package tracing\n\n")
+	fmt.Fprintf(&src, "import (\n")
+	var paths []string
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for i, path := range paths {
+		if i > 0 && !strings.Contains(paths[i-1], ".") && strings.Contains(path, ".") {
+			fmt.Fprintf(&src, "\n")
+		}
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	fmt.Fprintf(&src, ")\n\n")
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// writeMethod writes the method of the decorator, which
+// passes the call to the next service within the span of
+// the service method.
+func writeMethod(w *bytes.Buffer, typ string, method *types.Func, op string, qualifier types.Qualifier) error {
+	sig := method.Type().(*types.Signature)
+	params, results := sig.Params(), sig.Results()
+	n := results.Len()
+	if n == 0 || types.TypeString(results.At(n-1).Type(), nil) != "error" {
+		return fmt.Errorf("want an error last result")
+	}
+
+	var decls, args []string
+	for i := 1; i < params.Len(); i++ {
+		name := fmt.Sprintf("p%d", i)
+		t := params.At(i).Type()
+		if sig.Variadic() && i == params.Len()-1 {
+			decls = append(decls, name+" ..."+types.TypeString(t.(*types.Slice).Elem(), qualifier))
+			args = append(args, name+"...")
+		} else {
+			decls = append(decls, name+" "+types.TypeString(t, qualifier))
+			args = append(args, name)
+		}
+	}
+	var outs, values []string
+	res := "nil"
+	for i := 0; i < n; i++ {
+		t := results.At(i).Type()
+		name := fmt.Sprintf("r%d", i)
+		switch {
+		case i == n-1:
+			name = "err"
+		case types.TypeString(t, nil) == "*github.com/jenkins-x/go-scm/scm.Response":
+			name = "res"
+			res = name
+		}
+		outs = append(outs, name+" "+types.TypeString(t, qualifier))
+		values = append(values, name)
+	}
+
+	call := strings.Join(append([]string{"ctx"}, args...), ", ")
+	fmt.Fprintf(w, "\nfunc (s *%s) %s(%s) (%s) {\n", typ, method.Name(), strings.Join(append([]string{"ctx context.Context"}, decls...), ", "), strings.Join(outs, ", "))
+	fmt.Fprintf(w, "\tctx, span := s.tracer.start(ctx, %q)\n", op)
+	fmt.Fprintf(w, "\t%s = s.next.%s(%s)\n", strings.Join(values, ", "), method.Name(), call)
+	fmt.Fprintf(w, "\tspan.end(%s, err)\n", res)
+	fmt.Fprintf(w, "\treturn\n")
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
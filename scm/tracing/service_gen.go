@@ -0,0 +1,1085 @@
+// Code generated by tracegen. DO NOT EDIT.
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// traceServices decorates the services of the client with
+// the spans of the tracer.
+func traceServices(client *scm.Client, tracer *serviceTracer) {
+	if client.Apps != nil {
+		client.Apps = &traceAppService{next: client.Apps, tracer: tracer}
+	}
+	if client.Contents != nil {
+		client.Contents = &traceContentService{next: client.Contents, tracer: tracer}
+	}
+	if client.Deployments != nil {
+		client.Deployments = &traceDeploymentService{next: client.Deployments, tracer: tracer}
+	}
+	if client.Git != nil {
+		client.Git = &traceGitService{next: client.Git, tracer: tracer}
+	}
+	if client.GraphQL != nil {
+		client.GraphQL = &traceGraphQLService{next: client.GraphQL, tracer: tracer}
+	}
+	if client.Organizations != nil {
+		client.Organizations = &traceOrganizationService{next: client.Organizations, tracer: tracer}
+	}
+	if client.Issues != nil {
+		client.Issues = &traceIssueService{next: client.Issues, tracer: tracer}
+	}
+	if client.Milestones != nil {
+		client.Milestones = &traceMilestoneService{next: client.Milestones, tracer: tracer}
+	}
+	if client.Releases != nil {
+		client.Releases = &traceReleaseService{next: client.Releases, tracer: tracer}
+	}
+	if client.PullRequests != nil {
+		client.PullRequests = &tracePullRequestService{next: client.PullRequests, tracer: tracer}
+	}
+	if client.Repositories != nil {
+		client.Repositories = &traceRepositoryService{next: client.Repositories, tracer: tracer}
+	}
+	if client.Reviews != nil {
+		client.Reviews = &traceReviewService{next: client.Reviews, tracer: tracer}
+	}
+	if client.Users != nil {
+		client.Users = &traceUserService{next: client.Users, tracer: tracer}
+	}
+	if client.Commits != nil {
+		client.Commits = &traceCommitService{next: client.Commits, tracer: tracer}
+	}
+}
+
+type traceAppService struct {
+	next   scm.AppService
+	tracer *serviceTracer
+}
+
+func (s *traceAppService) CreateInstallationToken(ctx context.Context, p1 int64) (r0 *scm.InstallationToken, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Apps.CreateInstallationToken")
+	r0, res, err = s.next.CreateInstallationToken(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceAppService) GetOrganisationInstallation(ctx context.Context, p1 string) (r0 *scm.Installation, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Apps.GetOrganisationInstallation")
+	r0, res, err = s.next.GetOrganisationInstallation(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceAppService) GetRepositoryInstallation(ctx context.Context, p1 string) (r0 *scm.Installation, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Apps.GetRepositoryInstallation")
+	r0, res, err = s.next.GetRepositoryInstallation(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceAppService) GetUserInstallation(ctx context.Context, p1 string) (r0 *scm.Installation, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Apps.GetUserInstallation")
+	r0, res, err = s.next.GetUserInstallation(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+type traceContentService struct {
+	next   scm.ContentService
+	tracer *serviceTracer
+}
+
+func (s *traceContentService) Create(ctx context.Context, p1 string, p2 string, p3 *scm.ContentParams) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Contents.Create")
+	res, err = s.next.Create(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceContentService) Delete(ctx context.Context, p1 string, p2 string, p3 *scm.ContentParams) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Contents.Delete")
+	res, err = s.next.Delete(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceContentService) Find(ctx context.Context, p1 string, p2 string, p3 string) (r0 *scm.Content, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Contents.Find")
+	r0, res, err = s.next.Find(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceContentService) List(ctx context.Context, p1 string, p2 string, p3 string, p4 *scm.ListOptions) (r0 []*scm.FileEntry, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Contents.List")
+	r0, res, err = s.next.List(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+func (s *traceContentService) Update(ctx context.Context, p1 string, p2 string, p3 *scm.ContentParams) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Contents.Update")
+	res, err = s.next.Update(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+type traceDeploymentService struct {
+	next   scm.DeploymentService
+	tracer *serviceTracer
+}
+
+func (s *traceDeploymentService) Create(ctx context.Context, p1 string, p2 *scm.DeploymentInput) (r0 *scm.Deployment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Deployments.Create")
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceDeploymentService) CreateStatus(ctx context.Context, p1 string, p2 string, p3 *scm.DeploymentStatusInput) (r0 *scm.DeploymentStatus, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Deployments.CreateStatus")
+	r0, res, err = s.next.CreateStatus(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceDeploymentService) Delete(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Deployments.Delete")
+	res, err = s.next.Delete(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceDeploymentService) Find(ctx context.Context, p1 string, p2 string) (r0 *scm.Deployment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Deployments.Find")
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceDeploymentService) FindStatus(ctx context.Context, p1 string, p2 string, p3 string) (r0 *scm.DeploymentStatus, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Deployments.FindStatus")
+	r0, res, err = s.next.FindStatus(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceDeploymentService) List(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Deployment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Deployments.List")
+	r0, res, err = s.next.List(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceDeploymentService) ListStatus(ctx context.Context, p1 string, p2 string, p3 *scm.ListOptions) (r0 []*scm.DeploymentStatus, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Deployments.ListStatus")
+	r0, res, err = s.next.ListStatus(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+type traceGitService struct {
+	next   scm.GitService
+	tracer *serviceTracer
+}
+
+func (s *traceGitService) CompareCommits(ctx context.Context, p1 string, p2 string, p3 string, p4 *scm.ListOptions) (r0 []*scm.Change, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.CompareCommits")
+	r0, res, err = s.next.CompareCommits(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) CreateRef(ctx context.Context, p1 string, p2 string, p3 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.CreateRef")
+	r0, res, err = s.next.CreateRef(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) DeleteRef(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.DeleteRef")
+	res, err = s.next.DeleteRef(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) FindBranch(ctx context.Context, p1 string, p2 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.FindBranch")
+	r0, res, err = s.next.FindBranch(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) FindCommit(ctx context.Context, p1 string, p2 string) (r0 *scm.Commit, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.FindCommit")
+	r0, res, err = s.next.FindCommit(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) FindRef(ctx context.Context, p1 string, p2 string) (r0 string, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.FindRef")
+	r0, res, err = s.next.FindRef(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) FindTag(ctx context.Context, p1 string, p2 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.FindTag")
+	r0, res, err = s.next.FindTag(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) GetDefaultBranch(ctx context.Context, p1 string) (r0 *scm.Reference, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.GetDefaultBranch")
+	r0, res, err = s.next.GetDefaultBranch(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) ListBranches(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Reference, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.ListBranches")
+	r0, res, err = s.next.ListBranches(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) ListChanges(ctx context.Context, p1 string, p2 string, p3 *scm.ListOptions) (r0 []*scm.Change, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.ListChanges")
+	r0, res, err = s.next.ListChanges(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) ListCommits(ctx context.Context, p1 string, p2 scm.CommitListOptions) (r0 []*scm.Commit, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.ListCommits")
+	r0, res, err = s.next.ListCommits(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceGitService) ListTags(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Reference, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Git.ListTags")
+	r0, res, err = s.next.ListTags(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+type traceGraphQLService struct {
+	next   scm.GraphQLService
+	tracer *serviceTracer
+}
+
+func (s *traceGraphQLService) Query(ctx context.Context, p1 interface{}, p2 map[string]interface{}) (err error) {
+	ctx, span := s.tracer.start(ctx, "GraphQL.Query")
+	err = s.next.Query(ctx, p1, p2)
+	span.end(nil, err)
+	return
+}
+
+type traceOrganizationService struct {
+	next   scm.OrganizationService
+	tracer *serviceTracer
+}
+
+func (s *traceOrganizationService) AcceptOrganizationInvitation(ctx context.Context, p1 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.AcceptOrganizationInvitation")
+	res, err = s.next.AcceptOrganizationInvitation(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) Create(ctx context.Context, p1 *scm.OrganizationInput) (r0 *scm.Organization, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.Create")
+	r0, res, err = s.next.Create(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) Delete(ctx context.Context, p1 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.Delete")
+	res, err = s.next.Delete(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) Find(ctx context.Context, p1 string) (r0 *scm.Organization, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.Find")
+	r0, res, err = s.next.Find(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) IsAdmin(ctx context.Context, p1 string, p2 string) (r0 bool, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.IsAdmin")
+	r0, res, err = s.next.IsAdmin(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) IsMember(ctx context.Context, p1 string, p2 string) (r0 bool, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.IsMember")
+	r0, res, err = s.next.IsMember(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) List(ctx context.Context, p1 *scm.ListOptions) (r0 []*scm.Organization, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.List")
+	r0, res, err = s.next.List(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) ListMemberships(ctx context.Context, p1 *scm.ListOptions) (r0 []*scm.Membership, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.ListMemberships")
+	r0, res, err = s.next.ListMemberships(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) ListOrgMembers(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.TeamMember, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.ListOrgMembers")
+	r0, res, err = s.next.ListOrgMembers(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) ListPendingInvitations(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.OrganizationPendingInvite, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.ListPendingInvitations")
+	r0, res, err = s.next.ListPendingInvitations(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) ListTeamMembers(ctx context.Context, p1 int, p2 string, p3 *scm.ListOptions) (r0 []*scm.TeamMember, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.ListTeamMembers")
+	r0, res, err = s.next.ListTeamMembers(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceOrganizationService) ListTeams(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Team, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Organizations.ListTeams")
+	r0, res, err = s.next.ListTeams(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+type traceIssueService struct {
+	next   scm.IssueService
+	tracer *serviceTracer
+}
+
+func (s *traceIssueService) AddLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.AddLabel")
+	res, err = s.next.AddLabel(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) AssignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.AssignIssue")
+	res, err = s.next.AssignIssue(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) ClearMilestone(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.ClearMilestone")
+	res, err = s.next.ClearMilestone(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) Close(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.Close")
+	res, err = s.next.Close(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) Create(ctx context.Context, p1 string, p2 *scm.IssueInput) (r0 *scm.Issue, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.Create")
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) CreateComment(ctx context.Context, p1 string, p2 int, p3 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.CreateComment")
+	r0, res, err = s.next.CreateComment(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) DeleteComment(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.DeleteComment")
+	res, err = s.next.DeleteComment(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) DeleteLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.DeleteLabel")
+	res, err = s.next.DeleteLabel(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) EditComment(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.EditComment")
+	r0, res, err = s.next.EditComment(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) Find(ctx context.Context, p1 string, p2 int) (r0 *scm.Issue, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.Find")
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) FindComment(ctx context.Context, p1 string, p2 int, p3 int) (r0 *scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.FindComment")
+	r0, res, err = s.next.FindComment(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) List(ctx context.Context, p1 string, p2 scm.IssueListOptions) (r0 []*scm.Issue, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.List")
+	r0, res, err = s.next.List(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) ListComments(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.ListComments")
+	r0, res, err = s.next.ListComments(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) ListEvents(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.ListedIssueEvent, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.ListEvents")
+	r0, res, err = s.next.ListEvents(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) ListLabels(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Label, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.ListLabels")
+	r0, res, err = s.next.ListLabels(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) Lock(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.Lock")
+	res, err = s.next.Lock(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) Reopen(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.Reopen")
+	res, err = s.next.Reopen(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) Search(ctx context.Context, p1 scm.SearchOptions) (r0 []*scm.SearchIssue, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.Search")
+	r0, res, err = s.next.Search(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) SetMilestone(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.SetMilestone")
+	res, err = s.next.SetMilestone(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) UnassignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.UnassignIssue")
+	res, err = s.next.UnassignIssue(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceIssueService) Unlock(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Issues.Unlock")
+	res, err = s.next.Unlock(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+type traceMilestoneService struct {
+	next   scm.MilestoneService
+	tracer *serviceTracer
+}
+
+func (s *traceMilestoneService) Create(ctx context.Context, p1 string, p2 *scm.MilestoneInput) (r0 *scm.Milestone, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Milestones.Create")
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceMilestoneService) Delete(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Milestones.Delete")
+	res, err = s.next.Delete(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceMilestoneService) Find(ctx context.Context, p1 string, p2 int) (r0 *scm.Milestone, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Milestones.Find")
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceMilestoneService) List(ctx context.Context, p1 string, p2 scm.MilestoneListOptions) (r0 []*scm.Milestone, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Milestones.List")
+	r0, res, err = s.next.List(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceMilestoneService) Update(ctx context.Context, p1 string, p2 int, p3 *scm.MilestoneInput) (r0 *scm.Milestone, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Milestones.Update")
+	r0, res, err = s.next.Update(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+type traceReleaseService struct {
+	next   scm.ReleaseService
+	tracer *serviceTracer
+}
+
+func (s *traceReleaseService) Create(ctx context.Context, p1 string, p2 *scm.ReleaseInput) (r0 *scm.Release, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.Create")
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReleaseService) Delete(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.Delete")
+	res, err = s.next.Delete(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReleaseService) DeleteByTag(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.DeleteByTag")
+	res, err = s.next.DeleteByTag(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReleaseService) Find(ctx context.Context, p1 string, p2 int) (r0 *scm.Release, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.Find")
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReleaseService) FindByTag(ctx context.Context, p1 string, p2 string) (r0 *scm.Release, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.FindByTag")
+	r0, res, err = s.next.FindByTag(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReleaseService) List(ctx context.Context, p1 string, p2 scm.ReleaseListOptions) (r0 []*scm.Release, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.List")
+	r0, res, err = s.next.List(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReleaseService) Update(ctx context.Context, p1 string, p2 int, p3 *scm.ReleaseInput) (r0 *scm.Release, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.Update")
+	r0, res, err = s.next.Update(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReleaseService) UpdateByTag(ctx context.Context, p1 string, p2 string, p3 *scm.ReleaseInput) (r0 *scm.Release, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Releases.UpdateByTag")
+	r0, res, err = s.next.UpdateByTag(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+type tracePullRequestService struct {
+	next   scm.PullRequestService
+	tracer *serviceTracer
+}
+
+func (s *tracePullRequestService) AddLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.AddLabel")
+	res, err = s.next.AddLabel(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) AssignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.AssignIssue")
+	res, err = s.next.AssignIssue(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) ClearMilestone(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.ClearMilestone")
+	res, err = s.next.ClearMilestone(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) Close(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.Close")
+	res, err = s.next.Close(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) Create(ctx context.Context, p1 string, p2 *scm.PullRequestInput) (r0 *scm.PullRequest, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.Create")
+	r0, res, err = s.next.Create(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) CreateComment(ctx context.Context, p1 string, p2 int, p3 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.CreateComment")
+	r0, res, err = s.next.CreateComment(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) DeleteComment(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.DeleteComment")
+	res, err = s.next.DeleteComment(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) DeleteLabel(ctx context.Context, p1 string, p2 int, p3 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.DeleteLabel")
+	res, err = s.next.DeleteLabel(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) DeletePullRequest(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.DeletePullRequest")
+	res, err = s.next.DeletePullRequest(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) EditComment(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.CommentInput) (r0 *scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.EditComment")
+	r0, res, err = s.next.EditComment(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) Find(ctx context.Context, p1 string, p2 int) (r0 *scm.PullRequest, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.Find")
+	r0, res, err = s.next.Find(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) FindComment(ctx context.Context, p1 string, p2 int, p3 int) (r0 *scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.FindComment")
+	r0, res, err = s.next.FindComment(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) List(ctx context.Context, p1 string, p2 *scm.PullRequestListOptions) (r0 []*scm.PullRequest, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.List")
+	r0, res, err = s.next.List(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) ListChanges(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Change, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.ListChanges")
+	r0, res, err = s.next.ListChanges(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) ListComments(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Comment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.ListComments")
+	r0, res, err = s.next.ListComments(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) ListCommits(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Commit, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.ListCommits")
+	r0, res, err = s.next.ListCommits(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) ListEvents(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.ListedIssueEvent, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.ListEvents")
+	r0, res, err = s.next.ListEvents(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) ListLabels(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Label, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.ListLabels")
+	r0, res, err = s.next.ListLabels(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) Merge(ctx context.Context, p1 string, p2 int, p3 *scm.PullRequestMergeOptions) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.Merge")
+	res, err = s.next.Merge(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) Reopen(ctx context.Context, p1 string, p2 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.Reopen")
+	res, err = s.next.Reopen(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) RequestReview(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.RequestReview")
+	res, err = s.next.RequestReview(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) SetMilestone(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.SetMilestone")
+	res, err = s.next.SetMilestone(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) UnassignIssue(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.UnassignIssue")
+	res, err = s.next.UnassignIssue(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) UnrequestReview(ctx context.Context, p1 string, p2 int, p3 []string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.UnrequestReview")
+	res, err = s.next.UnrequestReview(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *tracePullRequestService) Update(ctx context.Context, p1 string, p2 int, p3 *scm.PullRequestInput) (r0 *scm.PullRequest, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "PullRequests.Update")
+	r0, res, err = s.next.Update(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+type traceRepositoryService struct {
+	next   scm.RepositoryService
+	tracer *serviceTracer
+}
+
+func (s *traceRepositoryService) AddCollaborator(ctx context.Context, p1 string, p2 string, p3 string) (r0 bool, r1 bool, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.AddCollaborator")
+	r0, r1, res, err = s.next.AddCollaborator(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) Create(ctx context.Context, p1 *scm.RepositoryInput) (r0 *scm.Repository, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.Create")
+	r0, res, err = s.next.Create(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) CreateHook(ctx context.Context, p1 string, p2 *scm.HookInput) (r0 *scm.Hook, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.CreateHook")
+	r0, res, err = s.next.CreateHook(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) CreateStatus(ctx context.Context, p1 string, p2 string, p3 *scm.StatusInput) (r0 *scm.Status, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.CreateStatus")
+	r0, res, err = s.next.CreateStatus(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) Delete(ctx context.Context, p1 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.Delete")
+	res, err = s.next.Delete(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) DeleteHook(ctx context.Context, p1 string, p2 string) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.DeleteHook")
+	res, err = s.next.DeleteHook(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) Find(ctx context.Context, p1 string) (r0 *scm.Repository, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.Find")
+	r0, res, err = s.next.Find(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) FindCombinedStatus(ctx context.Context, p1 string, p2 string) (r0 *scm.CombinedStatus, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.FindCombinedStatus")
+	r0, res, err = s.next.FindCombinedStatus(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) FindHook(ctx context.Context, p1 string, p2 string) (r0 *scm.Hook, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.FindHook")
+	r0, res, err = s.next.FindHook(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) FindPerms(ctx context.Context, p1 string) (r0 *scm.Perm, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.FindPerms")
+	r0, res, err = s.next.FindPerms(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) FindUserPermission(ctx context.Context, p1 string, p2 string) (r0 string, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.FindUserPermission")
+	r0, res, err = s.next.FindUserPermission(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) Fork(ctx context.Context, p1 *scm.RepositoryInput, p2 string) (r0 *scm.Repository, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.Fork")
+	r0, res, err = s.next.Fork(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) IsCollaborator(ctx context.Context, p1 string, p2 string) (r0 bool, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.IsCollaborator")
+	r0, res, err = s.next.IsCollaborator(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) List(ctx context.Context, p1 *scm.ListOptions) (r0 []*scm.Repository, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.List")
+	r0, res, err = s.next.List(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) ListCollaborators(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []scm.User, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.ListCollaborators")
+	r0, res, err = s.next.ListCollaborators(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) ListHooks(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Hook, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.ListHooks")
+	r0, res, err = s.next.ListHooks(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) ListLabels(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Label, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.ListLabels")
+	r0, res, err = s.next.ListLabels(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) ListOrganisation(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Repository, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.ListOrganisation")
+	r0, res, err = s.next.ListOrganisation(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) ListStatus(ctx context.Context, p1 string, p2 string, p3 *scm.ListOptions) (r0 []*scm.Status, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.ListStatus")
+	r0, res, err = s.next.ListStatus(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) ListUser(ctx context.Context, p1 string, p2 *scm.ListOptions) (r0 []*scm.Repository, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.ListUser")
+	r0, res, err = s.next.ListUser(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceRepositoryService) UpdateHook(ctx context.Context, p1 string, p2 *scm.HookInput) (r0 *scm.Hook, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Repositories.UpdateHook")
+	r0, res, err = s.next.UpdateHook(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+type traceReviewService struct {
+	next   scm.ReviewService
+	tracer *serviceTracer
+}
+
+func (s *traceReviewService) Create(ctx context.Context, p1 string, p2 int, p3 *scm.ReviewInput) (r0 *scm.Review, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.Create")
+	r0, res, err = s.next.Create(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReviewService) Delete(ctx context.Context, p1 string, p2 int, p3 int) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.Delete")
+	res, err = s.next.Delete(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReviewService) Dismiss(ctx context.Context, p1 string, p2 int, p3 int, p4 string) (r0 *scm.Review, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.Dismiss")
+	r0, res, err = s.next.Dismiss(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReviewService) Find(ctx context.Context, p1 string, p2 int, p3 int) (r0 *scm.Review, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.Find")
+	r0, res, err = s.next.Find(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReviewService) List(ctx context.Context, p1 string, p2 int, p3 *scm.ListOptions) (r0 []*scm.Review, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.List")
+	r0, res, err = s.next.List(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReviewService) ListComments(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.ListOptions) (r0 []*scm.ReviewComment, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.ListComments")
+	r0, res, err = s.next.ListComments(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReviewService) Submit(ctx context.Context, p1 string, p2 int, p3 int, p4 *scm.ReviewSubmitInput) (r0 *scm.Review, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.Submit")
+	r0, res, err = s.next.Submit(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+func (s *traceReviewService) Update(ctx context.Context, p1 string, p2 int, p3 int, p4 string) (r0 *scm.Review, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Reviews.Update")
+	r0, res, err = s.next.Update(ctx, p1, p2, p3, p4)
+	span.end(res, err)
+	return
+}
+
+type traceUserService struct {
+	next   scm.UserService
+	tracer *serviceTracer
+}
+
+func (s *traceUserService) AcceptInvitation(ctx context.Context, p1 int64) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Users.AcceptInvitation")
+	res, err = s.next.AcceptInvitation(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceUserService) CreateToken(ctx context.Context, p1 string, p2 string) (r0 *scm.UserToken, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Users.CreateToken")
+	r0, res, err = s.next.CreateToken(ctx, p1, p2)
+	span.end(res, err)
+	return
+}
+
+func (s *traceUserService) DeleteToken(ctx context.Context, p1 int64) (res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Users.DeleteToken")
+	res, err = s.next.DeleteToken(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceUserService) Find(ctx context.Context) (r0 *scm.User, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Users.Find")
+	r0, res, err = s.next.Find(ctx)
+	span.end(res, err)
+	return
+}
+
+func (s *traceUserService) FindEmail(ctx context.Context) (r0 string, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Users.FindEmail")
+	r0, res, err = s.next.FindEmail(ctx)
+	span.end(res, err)
+	return
+}
+
+func (s *traceUserService) FindLogin(ctx context.Context, p1 string) (r0 *scm.User, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Users.FindLogin")
+	r0, res, err = s.next.FindLogin(ctx, p1)
+	span.end(res, err)
+	return
+}
+
+func (s *traceUserService) ListInvitations(ctx context.Context) (r0 []*scm.Invitation, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Users.ListInvitations")
+	r0, res, err = s.next.ListInvitations(ctx)
+	span.end(res, err)
+	return
+}
+
+type traceCommitService struct {
+	next   scm.CommitService
+	tracer *serviceTracer
+}
+
+func (s *traceCommitService) UpdateCommitStatus(ctx context.Context, p1 string, p2 string, p3 *scm.CommitStatusUpdateOptions) (r0 *scm.CommitStatus, res *scm.Response, err error) {
+	ctx, span := s.tracer.start(ctx, "Commits.UpdateCommitStatus")
+	r0, res, err = s.next.UpdateCommitStatus(ctx, p1, p2, p3)
+	span.end(res, err)
+	return
+}
@@ -0,0 +1,219 @@
+// Package tracing creates trace spans for scm.Client API
+// calls.
+//
+// Spans are created through the small Tracer interface so
+// that the scm module does not depend on a tracing SDK. An
+// OpenTelemetry implementation starts the span using an
+// otel trace.Tracer and injects the W3C trace context
+// using the propagation.TraceContext propagator.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/metrics"
+)
+
+// Attribute keys set on the spans. Where possible they
+// follow the OpenTelemetry semantic conventions.
+const (
+	AttributeDriver     = "scm.driver"
+	AttributeRepository = "scm.repository"
+	AttributeRequestID  = "scm.request_id"
+	AttributeAttempt    = "scm.attempt"
+	AttributeMethod     = "http.request.method"
+	AttributeTemplate   = "url.template"
+	AttributeStatusCode = "http.response.status_code"
+)
+
+type (
+	// Attribute is a key value pair describing a span.
+	Attribute struct {
+		Key   string
+		Value interface{}
+	}
+
+	// Span is a single traced operation.
+	Span interface {
+		// SetAttributes sets attributes on the span.
+		SetAttributes(attributes ...Attribute)
+
+		// RecordError records the error and marks the span
+		// as failed.
+		RecordError(err error)
+
+		// End completes the span.
+		End()
+	}
+
+	// Tracer creates spans and propagates the trace
+	// context.
+	Tracer interface {
+		// Start creates a span as a child of the span in
+		// the context, if any.
+		Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+
+		// Inject writes the trace context of the span in
+		// the context, such as the W3C traceparent header,
+		// to the request header.
+		Inject(ctx context.Context, header http.Header)
+	}
+)
+
+// Instrument traces the calls of the services of the
+// client using the tracer. Each call of a service method
+// is traced by a span named after the method, for example
+// PullRequests.Merge, which is a child of the span in the
+// context passed to the method. Its request attempts are
+// traced by child spans, see Middleware.
+//
+// Instrument decorates the services of the client, so it
+// must be called once, after the services are set.
+func Instrument(client *scm.Client, tracer Tracer) {
+	traceServices(client, &serviceTracer{tracer: tracer, driver: client.Driver})
+	client.Use(Middleware(tracer))
+}
+
+//go:generate go run ./internal/tracegen
+
+// serviceTracer starts the spans of the service methods.
+type serviceTracer struct {
+	tracer Tracer
+	driver scm.Driver
+}
+
+// methodSpan is the span of a service method call.
+type methodSpan struct {
+	span Span
+}
+
+// start starts the span of the service method.
+func (t *serviceTracer) start(ctx context.Context, name string) (context.Context, *methodSpan) {
+	ctx, span := t.tracer.Start(ctx, name, Attribute{AttributeDriver, t.driver.String()})
+	return ctx, &methodSpan{span: span}
+}
+
+// end ends the span with the result of the method.
+func (s *methodSpan) end(res *scm.Response, err error) {
+	if res != nil {
+		s.span.SetAttributes(Attribute{AttributeStatusCode, res.Status})
+	}
+	if err != nil {
+		s.span.RecordError(err)
+	}
+	s.span.End()
+}
+
+// Middleware returns middleware that traces every request
+// attempt using the tracer. Each attempt is traced by a
+// span named after the method and templated path of the
+// request, see SpanName, which is a child of the span in
+// the context passed to the service method, such as the
+// span of the method started by Instrument.
+func Middleware(tracer Tracer) scm.Middleware {
+	var spans sync.Map // *scm.RequestEvent -> Span
+	end := func(event *scm.RequestEvent) {
+		v, ok := spans.LoadAndDelete(event)
+		if !ok {
+			return
+		}
+		span := v.(Span)
+		if res := event.Response; res != nil {
+			span.SetAttributes(Attribute{AttributeStatusCode, res.Status})
+			if res.ID != "" {
+				span.SetAttributes(Attribute{AttributeRequestID, res.ID})
+			}
+		}
+		if event.Err != nil {
+			span.RecordError(event.Err)
+		}
+		span.End()
+	}
+	return scm.Middleware{
+		BeforeRequest: func(ctx context.Context, event *scm.RequestEvent) {
+			path := metrics.TemplatePath(event.Driver, event.Request.Path)
+			attributes := []Attribute{
+				{AttributeDriver, event.Driver.String()},
+				{AttributeMethod, event.Request.Method},
+				{AttributeTemplate, path},
+				{AttributeAttempt, event.Attempt},
+			}
+			if repo := Repository(event.Request.Path); repo != "" {
+				attributes = append(attributes, Attribute{AttributeRepository, repo})
+			}
+			ctx, span := tracer.Start(ctx, SpanName(event.Request.Method, path), attributes...)
+			if event.Request.Header == nil {
+				event.Request.Header = http.Header{}
+			}
+			tracer.Inject(ctx, event.Request.Header)
+			spans.Store(event, span)
+		},
+		AfterResponse: func(ctx context.Context, event *scm.RequestEvent) {
+			end(event)
+		},
+		OnError: func(ctx context.Context, event *scm.RequestEvent) {
+			// error responses are handled by AfterResponse.
+			end(event)
+		},
+	}
+}
+
+// SpanName returns the name of the span of a request
+// attempt, made of the method and templated path of the
+// request such as GET repos/{owner}/{repo}, following the
+// OpenTelemetry conventions of HTTP client spans.
+func SpanName(method, templated string) string {
+	return strings.ToUpper(method) + " " + templated
+}
+
+// Repository returns the full name of the repository the
+// request path refers to, or an empty string.
+func Repository(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	at := func(i int) string {
+		if i < len(segments) {
+			s, err := url.PathUnescape(segments[i])
+			if err == nil {
+				return s
+			}
+			return segments[i]
+		}
+		return ""
+	}
+	for i, segment := range segments {
+		switch segment {
+		case "repos":
+			// Bitbucket Server: projects/{project}/repos/{repo}
+			if i >= 2 && segments[i-2] == "projects" {
+				return at(i-1) + "/" + at(i+1)
+			}
+			// GitHub, Gitea and Gogs: repos/{owner}/{repo}
+			if owner, name := at(i+1), at(i+2); owner != "" && name != "" {
+				return owner + "/" + name
+			}
+		case "projects":
+			// GitLab: projects/{namespace%2Fproject}
+			if i+2 >= len(segments) || segments[i+2] != "repos" {
+				return at(i + 1)
+			}
+		case "repositories":
+			// Azure DevOps: {org}/{project}/_apis/git/repositories/{repo}
+			if i >= 4 && segments[i-2] == "_apis" {
+				return at(0) + "/" + at(1) + "/" + at(i+1)
+			}
+			// Bitbucket: repositories/{workspace}/{repo}
+			if owner, name := at(i+1), at(i+2); owner != "" && name != "" {
+				return owner + "/" + name
+			}
+		}
+	}
+	return ""
+}
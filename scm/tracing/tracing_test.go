@@ -0,0 +1,153 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+)
+
+type span struct {
+	name       string
+	parent     string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *span) SetAttributes(attributes ...Attribute) {
+	for _, a := range attributes {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+func (s *span) RecordError(err error) { s.err = err }
+
+func (s *span) End() { s.ended = true }
+
+type spanKey struct{}
+
+type tracer struct {
+	spans []*span
+}
+
+func (t *tracer) Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	s := &span{name: name, attributes: map[string]interface{}{}}
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.parent = parent.name
+	}
+	s.SetAttributes(attributes...)
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func (t *tracer) Inject(ctx context.Context, header http.Header) {
+	if s, ok := ctx.Value(spanKey{}).(*span); ok {
+		header.Set("traceparent", s.parent+"/"+s.name)
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if got, want := r.Header.Get("traceparent"), "PullRequests.Merge/PUT repos/{owner}/{repo}/pulls/{number}/merge"; got != want {
+			t.Errorf("Want traceparent %q, got %q", want, got)
+		}
+		w.Header().Set("X-GitHub-Request-Id", "DD0E:6011")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer server.Close()
+
+	client, _ := github.New(server.URL)
+	client.Retry = &scm.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, RetryNonIdempotent: true}
+	tr := new(tracer)
+	Instrument(client, tr)
+
+	ctx, parent := tr.Start(context.Background(), "webhook")
+	defer parent.End()
+	_, err := client.PullRequests.Merge(ctx, "octocat/hello-world", 1347, &scm.PullRequestMergeOptions{})
+	if err == nil {
+		t.Fatal("Want merge error")
+	}
+
+	if got, want := len(tr.spans), 4; got != want {
+		t.Fatalf("Want %d spans, got %d", want, got)
+	}
+	method := tr.spans[1]
+	if got, want := method.name, "PullRequests.Merge"; got != want {
+		t.Errorf("Want span name %q, got %q", want, got)
+	}
+	if got, want := method.parent, "webhook"; got != want {
+		t.Errorf("Want parent span %q, got %q", want, got)
+	}
+	if method.err == nil || !method.ended {
+		t.Errorf("Want method span ended with the error")
+	}
+
+	for i, s := range tr.spans[2:] {
+		if got, want := s.name, "PUT repos/{owner}/{repo}/pulls/{number}/merge"; got != want {
+			t.Errorf("Want span name %q, got %q", want, got)
+		}
+		if got, want := s.parent, "PullRequests.Merge"; got != want {
+			t.Errorf("Want parent span %q, got %q", want, got)
+		}
+		status := 502
+		if i == 1 {
+			status = 405
+		}
+		want := map[string]interface{}{
+			AttributeDriver:     "github",
+			AttributeMethod:     "PUT",
+			AttributeTemplate:   "repos/{owner}/{repo}/pulls/{number}/merge",
+			AttributeRepository: "octocat/hello-world",
+			AttributeAttempt:    i + 1,
+			AttributeStatusCode: status,
+			AttributeRequestID:  "DD0E:6011",
+		}
+		if diff := cmp.Diff(want, s.attributes); diff != "" {
+			t.Errorf("Unexpected attributes")
+			t.Log(diff)
+		}
+		if s.err == nil {
+			t.Errorf("Want error recorded on span")
+		}
+		if !s.ended {
+			t.Errorf("Want span ended")
+		}
+	}
+}
+
+func TestSpanName(t *testing.T) {
+	if got, want := SpanName("get", "repos/{owner}/{repo}/pulls"), "GET repos/{owner}/{repo}/pulls"; got != want {
+		t.Errorf("Want span name %q, got %q", want, got)
+	}
+}
+
+func TestRepository(t *testing.T) {
+	tests := []struct {
+		path, repo string
+	}{
+		{"repos/octocat/hello-world/pulls/1", "octocat/hello-world"},
+		{"api/v4/projects/diaspora%2Fdiaspora/merge_requests", "diaspora/diaspora"},
+		{"rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests", "PRJ/my-repo"},
+		{"2.0/repositories/atlassian/stash-example-plugin/pullrequests", "atlassian/stash-example-plugin"},
+		{"myorg/myproject/_apis/git/repositories/myrepo/pullrequests?api-version=6.0", "myorg/myproject/myrepo"},
+		{"user/repos", ""},
+		{"user", ""},
+	}
+	for _, test := range tests {
+		if got := Repository(test.path); got != test.repo {
+			t.Errorf("Want repository of %q to be %q, got %q", test.path, test.repo, got)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// XFromCache is the header added to responses served from
+// the cache.
+const XFromCache = "X-From-Cache"
+
+// CacheStorage is the interface used by Cache to store
+// responses.
+type CacheStorage interface {
+	// Get returns the cached response for the key.
+	Get(key string) ([]byte, bool)
+
+	// Set stores the response for the key.
+	Set(key string, response []byte)
+
+	// Delete removes the response for the key.
+	Delete(key string)
+}
+
+// Cache is an http.RoundTripper that caches GET responses
+// carrying an ETag or Last-Modified header and revalidates
+// them using conditional requests. A 304 Not Modified
+// response is replaced by the cached response, which
+// GitHub does not count against the rate limit.
+//
+// Responses are cached per URL and Authorization header,
+// so the Cache should be used as the Base of the transport
+// that authorizes the requests.
+type Cache struct {
+	Base http.RoundTripper
+
+	// Storage stores the cached responses. If nil, an
+	// in-memory cache with DefaultCacheSize entries is used.
+	Storage CacheStorage
+
+	once sync.Once
+}
+
+// RoundTrip serves the request from the cache if the
+// cached response is still valid.
+func (t *Cache) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet ||
+		r.Header.Get("If-None-Match") != "" ||
+		r.Header.Get("If-Modified-Since") != "" {
+		return t.base().RoundTrip(r)
+	}
+	storage := t.storage()
+	key := cacheKey(r)
+
+	var cached *http.Response
+	if b, ok := storage.Get(key); ok {
+		res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), r)
+		if err == nil {
+			cached = res
+		} else {
+			storage.Delete(key)
+		}
+	}
+
+	req := r
+	if cached != nil {
+		req = cloneRequest(r)
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	res, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && res.StatusCode == http.StatusNotModified {
+		res.Body.Close()
+		// refresh the headers describing the current state
+		// of the client, such as the rate limit.
+		for k, v := range res.Header {
+			cached.Header[k] = v
+		}
+		cached.Header.Set(XFromCache, "1")
+		return cached, nil
+	}
+	if cached != nil {
+		cached.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusOK ||
+		(res.Header.Get("ETag") == "" && res.Header.Get("Last-Modified") == "") {
+		return res, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if dump, err := httputil.DumpResponse(res, true); err == nil {
+		storage.Set(key, dump)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Cache) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// storage returns the cache storage. If no storage is
+// configured, an in-memory storage is created.
+func (t *Cache) storage() CacheStorage {
+	t.once.Do(func() {
+		if t.Storage == nil {
+			t.Storage = NewMemoryCache(DefaultCacheSize)
+		}
+	})
+	return t.Storage
+}
+
+// varyHeaders are the request headers the responses are
+// cached per: the headers bearing the credentials of the
+// drivers, so that clients with different credentials never
+// share a response, and the Accept header.
+var varyHeaders = []string{
+	"Authorization",
+	"Private-Token",
+	"Cookie",
+	"Proxy-Authorization",
+	"Accept",
+}
+
+// cacheKey returns the cache key for the request. The
+// credentials are hashed so they are never stored.
+func cacheKey(r *http.Request) string {
+	h := sha256.New()
+	for _, name := range varyHeaders {
+		for _, value := range r.Header.Values(name) {
+			io.WriteString(h, name+": "+value+"\n")
+		}
+	}
+	u := *r.URL
+	if u.User != nil {
+		io.WriteString(h, "User: "+u.User.String()+"\n")
+		u.User = nil
+	}
+	return hex.EncodeToString(h.Sum(nil)) + " " + u.String()
+}
+
+// DefaultCacheSize is the number of responses stored by
+// the default in-memory cache.
+const DefaultCacheSize = 1000
+
+// MemoryCache is an in-memory CacheStorage that evicts the
+// least recently used responses.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key      string
+	response []byte
+}
+
+// NewMemoryCache returns a new MemoryCache holding up to
+// size responses.
+func NewMemoryCache(size int) *MemoryCache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &MemoryCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get returns the cached response for the key.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).response, true
+}
+
+// Set stores the response for the key, evicting the least
+// recently used response if the cache is full.
+func (c *MemoryCache) Set(key string, response []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryCacheEntry).response = response
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&memoryCacheEntry{key, response})
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Delete removes the response for the key.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of cached responses.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// DiskCache is a CacheStorage storing responses as files
+// in a directory.
+type DiskCache struct {
+	// Dir is the directory the responses are stored in.
+	// It is created if it does not exist.
+	Dir string
+}
+
+// Get returns the cached response for the key.
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// Set stores the response for the key. Errors are ignored
+// since a missing entry only results in a cache miss.
+func (c *DiskCache) Set(key string, response []byte) {
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return
+	}
+	// write to a temporary file first so concurrent readers
+	// never observe a partially written response.
+	f, err := os.CreateTemp(c.Dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(response)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return
+	}
+	if err := os.Rename(f.Name(), c.path(key)); err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// Delete removes the response for the key.
+func (c *DiskCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+// path returns the file path of the key.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
+
+// CacheStorage implementations
+var (
+	_ CacheStorage = (*MemoryCache)(nil)
+	_ CacheStorage = (*DiskCache)(nil)
+)
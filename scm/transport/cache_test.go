@@ -0,0 +1,131 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newCacheServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("X-RateLimit-Remaining", r.Header.Get("Authorization"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = io.WriteString(w, `{"login":"octocat"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, client *http.Client, url, token string) (*http.Response, string) {
+	t.Helper()
+	req, _ := http.NewRequest("GET", url, http.NoBody)
+	req.Header.Set("Authorization", token)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	return res, string(b)
+}
+
+func testCache(t *testing.T, storage CacheStorage) {
+	var hits int32
+	server := newCacheServer(t, &hits)
+	client := &http.Client{Transport: &Cache{Storage: storage}}
+
+	res, body := get(t, client, server.URL+"/user", "a")
+	if got := res.Header.Get(XFromCache); got != "" {
+		t.Errorf("Want first response not from cache")
+	}
+	if got, want := body, `{"login":"octocat"}`; got != want {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+
+	res, body = get(t, client, server.URL+"/user", "a")
+	if got, want := res.StatusCode, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got := res.Header.Get(XFromCache); got != "1" {
+		t.Errorf("Want second response from cache")
+	}
+	if got, want := body, `{"login":"octocat"}`; got != want {
+		t.Errorf("Want cached body %q, got %q", want, got)
+	}
+
+	// responses are cached per token.
+	res, _ = get(t, client, server.URL+"/user", "b")
+	if got := res.Header.Get(XFromCache); got != "" {
+		t.Errorf("Want response for a different token not from cache")
+	}
+	if got, want := res.Header.Get("X-RateLimit-Remaining"), "b"; got != want {
+		t.Errorf("Want header %q, got %q", want, got)
+	}
+	if got, want := atomic.LoadInt32(&hits), int32(3); got != want {
+		t.Errorf("Want %d requests sent, got %d", want, got)
+	}
+}
+
+func TestCacheMemory(t *testing.T) {
+	testCache(t, nil)
+}
+
+func TestCacheDisk(t *testing.T) {
+	testCache(t, &DiskCache{Dir: t.TempDir()})
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("a"))
+	c.Set("b", []byte("b"))
+	c.Get("a")
+	c.Set("c", []byte("c"))
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Want least recently used entry evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Want recently used entry kept")
+	}
+	if got, want := c.Len(), 2; got != want {
+		t.Errorf("Want %d entries, got %d", want, got)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Want deleted entry removed")
+	}
+}
+
+func TestCachePrivateToken(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("ETag", `"`+r.Header.Get("Private-Token")+`"`)
+		_, _ = io.WriteString(w, r.Header.Get("Private-Token"))
+	}))
+	defer server.Close()
+
+	storage := NewMemoryCache(0)
+	newClient := func(token string) *http.Client {
+		return &http.Client{Transport: &PrivateToken{
+			Base:  &Cache{Storage: storage},
+			Token: token,
+		}}
+	}
+	for _, token := range []string{"alice", "bob", "alice"} {
+		_, body := get(t, newClient(token), server.URL+"/projects", "")
+		if got, want := body, token; got != want {
+			t.Errorf("Want the response of token %q, got %q", want, got)
+		}
+	}
+	if got, want := storage.Len(), 2; got != want {
+		t.Errorf("Want %d responses cached, got %d", want, got)
+	}
+}
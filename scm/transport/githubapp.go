@@ -0,0 +1,263 @@
+package transport
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+const (
+	// appJWTLifetime is the lifetime of the JSON Web Tokens
+	// signed for a GitHub App. GitHub rejects tokens valid
+	// for more than ten minutes.
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockSkew is how far the issue time of the
+	// JSON Web Tokens is backdated to allow for clock drift.
+	appJWTClockSkew = time.Minute
+
+	// installationTokenExpiryDelta determines how early an
+	// installation token is renewed before it expires.
+	installationTokenExpiryDelta = 5 * time.Minute
+)
+
+// ErrInvalidPrivateKey is returned when the GitHub App
+// private key cannot be parsed.
+var ErrInvalidPrivateKey = errors.New("invalid GitHub App private key: expected a PEM encoded RSA key")
+
+// ParsePrivateKey parses a PEM encoded PKCS1 or PKCS8 RSA
+// private key, as downloaded from the GitHub App settings.
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+	return rsaKey, nil
+}
+
+// GitHubApp is an http.RoundTripper that authenticates
+// requests as a GitHub App, wrapping a base RoundTripper
+// and adding an Authorization header with an RS256 signed
+// JSON Web Token. The token is cached and re-signed
+// shortly before it expires.
+type GitHubApp struct {
+	Base http.RoundTripper
+
+	AppID int64           // GitHub App ID
+	Key   *rsa.PrivateKey // GitHub App private key
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// RoundTrip adds the Authorization header to the request.
+func (t *GitHubApp) RoundTrip(r *http.Request) (*http.Response, error) {
+	token, err := t.Token()
+	if err != nil {
+		return nil, err
+	}
+	r2 := cloneRequest(r)
+	r2.Header.Set("Authorization", "Bearer "+token)
+	return t.base().RoundTrip(r2)
+}
+
+// Token returns a signed JSON Web Token for the GitHub App.
+func (t *GitHubApp) Token() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if t.token != "" && now.Add(appJWTClockSkew).Before(t.expires) {
+		return t.token, nil
+	}
+	expires := now.Add(appJWTLifetime)
+	token, err := signJWT(t.Key, map[string]interface{}{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": expires.Unix(),
+		"iss": t.AppID,
+	})
+	if err != nil {
+		return "", err
+	}
+	t.token = token
+	t.expires = expires
+	return token, nil
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *GitHubApp) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// signJWT returns the RS256 signed JSON Web Token for the
+// given claims.
+func signJWT(key *rsa.PrivateKey, claims map[string]interface{}) (string, error) {
+	if key == nil {
+		return "", ErrInvalidPrivateKey
+	}
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// InstallationTokens creates and caches GitHub App
+// installation access tokens per installation ID. Tokens
+// are renewed shortly before they expire.
+//
+// InstallationTokens is safe for concurrent use by
+// multiple goroutines.
+type InstallationTokens struct {
+	// Apps is the app service of a client authenticated
+	// as the GitHub App, see GitHubApp.
+	Apps scm.AppService
+
+	mu     sync.Mutex
+	tokens map[int64]*scm.InstallationToken
+	// calls holds the in-flight token creations, keyed by
+	// installation ID.
+	calls map[int64]*installationTokenCall
+}
+
+// installationTokenCall is an in-flight or completed
+// installation token creation.
+type installationTokenCall struct {
+	done  chan struct{}
+	token *scm.InstallationToken
+	err   error
+}
+
+// Token returns a valid access token for the installation.
+// The token of an installation is created only once when
+// several callers need it at once, without blocking the
+// callers of other installations.
+func (s *InstallationTokens) Token(ctx context.Context, installationID int64) (string, error) {
+	s.mu.Lock()
+	if token, ok := s.tokens[installationID]; ok && !installationTokenExpired(token) {
+		s.mu.Unlock()
+		return token.Token, nil
+	}
+	if call, ok := s.calls[installationID]; ok {
+		s.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if call.err != nil {
+			return "", call.err
+		}
+		return call.token.Token, nil
+	}
+	call := &installationTokenCall{done: make(chan struct{})}
+	if s.calls == nil {
+		s.calls = map[int64]*installationTokenCall{}
+	}
+	s.calls[installationID] = call
+	s.mu.Unlock()
+
+	token, _, err := s.Apps.CreateInstallationToken(ctx, installationID)
+	if err != nil {
+		call.err = fmt.Errorf("failed to create token for GitHub App installation %d: %w", installationID, err)
+	}
+
+	s.mu.Lock()
+	delete(s.calls, installationID)
+	if call.err == nil {
+		call.token = token
+		if s.tokens == nil {
+			s.tokens = map[int64]*scm.InstallationToken{}
+		}
+		s.tokens[installationID] = token
+	}
+	s.mu.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return "", call.err
+	}
+	return token.Token, nil
+}
+
+// installationTokenExpired reports whether the token is
+// expired or about to expire.
+func installationTokenExpired(token *scm.InstallationToken) bool {
+	if token.Token == "" {
+		return true
+	}
+	if token.ExpiresAt == nil {
+		return false
+	}
+	return token.ExpiresAt.Add(-installationTokenExpiryDelta).Before(time.Now())
+}
+
+// GitHubAppInstallation is an http.RoundTripper that
+// authenticates requests as a GitHub App installation,
+// wrapping a base RoundTripper and adding an Authorization
+// header with an installation access token.
+type GitHubAppInstallation struct {
+	Base http.RoundTripper
+
+	InstallationID int64
+	Tokens         *InstallationTokens
+}
+
+// RoundTrip adds the Authorization header to the request.
+func (t *GitHubAppInstallation) RoundTrip(r *http.Request) (*http.Response, error) {
+	token, err := t.Tokens.Token(r.Context(), t.InstallationID)
+	if err != nil {
+		return nil, err
+	}
+	r2 := cloneRequest(r)
+	r2.Header.Set("Authorization", "token "+token)
+	return t.base().RoundTrip(r2)
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *GitHubAppInstallation) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
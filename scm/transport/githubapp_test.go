@@ -0,0 +1,189 @@
+package transport
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func testKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := testKey(t)
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	for _, data := range [][]byte{pkcs1, pkcs8} {
+		got, err := ParsePrivateKey(data)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !got.Equal(key) {
+			t.Errorf("Want parsed key to match")
+		}
+	}
+	if _, err := ParsePrivateKey([]byte("not a key")); err != ErrInvalidPrivateKey {
+		t.Errorf("Want invalid key error, got %v", err)
+	}
+}
+
+func TestGitHubAppToken(t *testing.T) {
+	key := testKey(t)
+	app := &GitHubApp{AppID: 42, Key: key}
+	token, err := app.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Want JWT with 3 parts, got %q", token)
+	}
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, sum[:], sig); err != nil {
+		t.Errorf("Want valid JWT signature, got %s", err)
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	claims := struct {
+		IssuedAt  int64 `json:"iat"`
+		ExpiresAt int64 `json:"exp"`
+		Issuer    int64 `json:"iss"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := claims.Issuer, int64(42); got != want {
+		t.Errorf("Want issuer %d, got %d", want, got)
+	}
+	if claims.ExpiresAt-claims.IssuedAt > int64((10 * time.Minute).Seconds()) {
+		t.Errorf("Want JWT valid for at most 10 minutes")
+	}
+
+	again, _ := app.Token()
+	if again != token {
+		t.Errorf("Want JWT to be cached")
+	}
+}
+
+type fakeApps struct {
+	scm.AppService
+	calls   int
+	expires time.Time
+}
+
+func (f *fakeApps) CreateInstallationToken(ctx context.Context, id int64) (*scm.InstallationToken, *scm.Response, error) {
+	f.calls++
+	return &scm.InstallationToken{Token: "ghs_" + strconv.Itoa(f.calls), ExpiresAt: &f.expires}, nil, nil
+}
+
+func TestGitHubAppInstallation(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world").
+		MatchHeader("Authorization", "token ghs_1").
+		Times(2).
+		Reply(200)
+
+	apps := &fakeApps{expires: time.Now().Add(time.Hour)}
+	client := &http.Client{
+		Transport: &GitHubAppInstallation{
+			InstallationID: 1,
+			Tokens:         &InstallationTokens{Apps: apps},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		res, err := client.Get("https://api.github.com/repos/octocat/hello-world")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	if got, want := apps.calls, 1; got != want {
+		t.Errorf("Want %d installation token created, got %d", want, got)
+	}
+}
+
+func TestInstallationTokensRenew(t *testing.T) {
+	apps := &fakeApps{expires: time.Now().Add(time.Minute)}
+	tokens := &InstallationTokens{Apps: apps}
+	first, _ := tokens.Token(context.Background(), 1)
+	second, _ := tokens.Token(context.Background(), 1)
+	if first == second {
+		t.Errorf("Want token about to expire renewed")
+	}
+}
+
+type blockingApps struct {
+	scm.AppService
+	release chan struct{}
+	calls   map[int64]*int32
+}
+
+func (b *blockingApps) CreateInstallationToken(ctx context.Context, id int64) (*scm.InstallationToken, *scm.Response, error) {
+	atomic.AddInt32(b.calls[id], 1)
+	if id == 1 {
+		<-b.release
+	}
+	return &scm.InstallationToken{Token: "ghs_" + strconv.FormatInt(id, 10)}, nil, nil
+}
+
+func TestInstallationTokensConcurrent(t *testing.T) {
+	apps := &blockingApps{
+		release: make(chan struct{}),
+		calls:   map[int64]*int32{1: new(int32), 2: new(int32)},
+	}
+	tokens := &InstallationTokens{Apps: apps}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if token, err := tokens.Token(context.Background(), 1); err != nil || token != "ghs_1" {
+				t.Errorf("Want token ghs_1, got %q, %v", token, err)
+			}
+		}()
+	}
+
+	// the token of another installation is not blocked by
+	// the in-flight creation.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if token, err := tokens.Token(ctx, 2); err != nil || token != "ghs_2" {
+		t.Errorf("Want token ghs_2, got %q, %v", token, err)
+	}
+	close(apps.release)
+	wg.Wait()
+
+	if got, want := atomic.LoadInt32(apps.calls[1]), int32(1); got != want {
+		t.Errorf("Want %d installation token created, got %d", want, got)
+	}
+}
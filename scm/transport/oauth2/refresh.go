@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -20,12 +21,15 @@ import (
 // expirations due to client-server time mismatches.
 const expiryDelta = time.Minute
 
-// Refresher is an http.RoundTripper that refreshes oauth
-// tokens, wrapping a base RoundTripper and refreshing the
+// Refresher is a scm.TokenSource that refreshes oauth
+// tokens, wrapping a base TokenSource and refreshing the
 // token if expired.
 //
-// IMPORTANT the Refresher is NOT safe for concurrent use
-// by multiple goroutines.
+// The Refresher is safe for concurrent use by multiple
+// goroutines. When several callers observe the same
+// expired token at once, the token is refreshed only once
+// and the refreshed token is cached for subsequent calls,
+// until the source returns another token.
 type Refresher struct {
 	ClientID     string
 	ClientSecret string
@@ -33,6 +37,30 @@ type Refresher struct {
 
 	Source scm.TokenSource
 	Client *http.Client
+
+	// OnRefresh is optionally called with the refreshed
+	// token, and can be used to persist a rotated refresh
+	// token. If it returns an error, the error is returned
+	// to the caller but the refreshed token is still used
+	// for subsequent calls.
+	OnRefresh func(ctx context.Context, token *scm.Token) error
+
+	mu sync.Mutex
+	// key is the refresh token of the expired source token
+	// the cached token was refreshed from.
+	key string
+	// token is the last refreshed token of the source.
+	token *scm.Token
+	// calls holds the in-flight refreshes, keyed by the
+	// refresh token of the expired source token.
+	calls map[string]*refreshCall
+}
+
+// refreshCall is an in-flight or completed token refresh.
+type refreshCall struct {
+	done  chan struct{}
+	token *scm.Token
+	err   error
 }
 
 // Token returns a token. If the token is missing or
@@ -42,18 +70,77 @@ func (t *Refresher) Token(ctx context.Context) (*scm.Token, error) {
 	if err != nil {
 		return nil, err
 	}
-	if !expired(token) {
+	if token == nil || !expired(token) {
 		return token, nil
 	}
-	err = t.Refresh(token)
-	if err != nil {
-		return nil, err
+
+	key := token.Refresh
+	t.mu.Lock()
+	var cached *scm.Token
+	if t.token != nil && t.key == key {
+		cached = t.token
+	}
+	if cached != nil && !expired(cached) {
+		t.mu.Unlock()
+		return copyToken(cached), nil
+	}
+	if call, ok := t.calls[key]; ok {
+		t.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if call.err != nil {
+			return nil, call.err
+		}
+		return copyToken(call.token), nil
+	}
+	call := &refreshCall{done: make(chan struct{})}
+	if t.calls == nil {
+		t.calls = map[string]*refreshCall{}
+	}
+	t.calls[key] = call
+	refreshed := copyToken(token)
+	if cached != nil {
+		// the refresh token of the source token may have
+		// been spent by the last refresh, so the cached
+		// token holding the rotated one is refreshed.
+		refreshed = copyToken(cached)
 	}
-	return token, nil
+	t.mu.Unlock()
+
+	call.err = t.refresh(ctx, refreshed)
+
+	t.mu.Lock()
+	delete(t.calls, key)
+	if call.err == nil {
+		call.token = refreshed
+		t.key = key
+		t.token = refreshed
+	}
+	t.mu.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	if t.OnRefresh != nil {
+		if err := t.OnRefresh(ctx, copyToken(refreshed)); err != nil {
+			return nil, err
+		}
+	}
+	return copyToken(refreshed), nil
 }
 
 // Refresh refreshes the expired token.
 func (t *Refresher) Refresh(token *scm.Token) error {
+	return t.refresh(context.Background(), token)
+}
+
+// refresh refreshes the expired token, updating it in
+// place.
+func (t *Refresher) refresh(ctx context.Context, token *scm.Token) error {
 	values := url.Values{}
 	values.Set("grant_type", "refresh_token")
 	values.Set("refresh_token", token.Refresh)
@@ -61,7 +148,7 @@ func (t *Refresher) Refresh(token *scm.Token) error {
 	reader := strings.NewReader(
 		values.Encode(),
 	)
-	req, err := http.NewRequest("POST", t.Endpoint, reader)
+	req, err := http.NewRequestWithContext(ctx, "POST", t.Endpoint, reader)
 	if err != nil {
 		return err
 	}
@@ -90,13 +177,22 @@ func (t *Refresher) Refresh(token *scm.Token) error {
 	}
 
 	token.Token = out.Access
-	token.Refresh = out.Refresh
+	if out.Refresh != "" {
+		token.Refresh = out.Refresh
+	}
 	token.Expires = time.Now().Add(
 		time.Duration(out.Expires) * time.Second,
 	)
 	return nil
 }
 
+// copyToken returns a copy of the token so that callers
+// never share a token that may be updated concurrently.
+func copyToken(token *scm.Token) *scm.Token {
+	t := *token
+	return &t
+}
+
 // client returns the http transport. If no base client
 // is configured, the default client is returned.
 func (t *Refresher) client() *http.Client {
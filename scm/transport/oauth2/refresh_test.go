@@ -6,6 +6,12 @@ package oauth2
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -127,6 +133,101 @@ func TestRefresh_NotExpired(t *testing.T) {
 	}
 }
 
+func TestRefresh_Concurrent(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		// slow down the refresh so that callers overlap.
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintf(w, `{"access_token": "access-%d", "refresh_token": "refresh-%d", "expires_in": 7200}`, n, n)
+	}))
+	defer server.Close()
+
+	var persisted []*scm.Token
+	r := &Refresher{
+		ClientID:     "dafe3804960dab",
+		ClientSecret: "20e651849b1f12",
+		Endpoint:     server.URL,
+		Source: StaticTokenSource(&scm.Token{
+			Refresh: "3a2bfce4cb9b0f",
+		}),
+		OnRefresh: func(ctx context.Context, token *scm.Token) error {
+			persisted = append(persisted, token)
+			return nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	tokens := make([]*scm.Token, 10)
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := r.Token(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			tokens[i] = token
+		}()
+	}
+	wg.Wait()
+
+	// subsequent calls are served from the cache.
+	token, err := r.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens = append(tokens, token)
+
+	if got, want := atomic.LoadInt32(&calls), int32(1); got != want {
+		t.Errorf("Want %d token refresh, got %d", want, got)
+	}
+	for _, token := range tokens {
+		if token == nil || token.Token != "access-1" {
+			t.Errorf("Want refreshed access token, got %v", token)
+		}
+	}
+	if len(persisted) != 1 {
+		t.Fatalf("Want refreshed token persisted once, got %d", len(persisted))
+	}
+	if got, want := persisted[0].Refresh, "refresh-1"; got != want {
+		t.Errorf("Want rotated refresh token %q persisted, got %q", want, got)
+	}
+}
+
+func TestRefresh_PersistError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://bitbucket.org").
+		Post("/site/oauth2/access_token").
+		Reply(200).
+		BodyString(`{"access_token": "9698fa6a8113b3", "expires_in": 7200}`)
+
+	want := errors.New("secret store unavailable")
+	r := &Refresher{
+		Endpoint: "https://bitbucket.org/site/oauth2/access_token",
+		Source: StaticTokenSource(&scm.Token{
+			Refresh: "3a2bfce4cb9b0f",
+		}),
+		OnRefresh: func(ctx context.Context, token *scm.Token) error {
+			return want
+		},
+	}
+	if _, err := r.Token(context.Background()); err != want {
+		t.Errorf("Want error %v, got %v", want, err)
+	}
+
+	// the refreshed token is still used.
+	token, err := r.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := token.Token, "9698fa6a8113b3"; got != want {
+		t.Errorf("Want access token %q, got %q", want, got)
+	}
+}
+
 func TestExpired(t *testing.T) {
 	tests := []struct {
 		token   *scm.Token
@@ -197,3 +298,39 @@ func TestExpired(t *testing.T) {
 		}
 	}
 }
+
+func TestRefresh_Rotated(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		n := atomic.AddInt32(&calls, 1)
+		// every refresh token can be used once.
+		if got, want := r.PostForm.Get("refresh_token"), fmt.Sprintf("refresh-%d", n-1); got != want {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"error": "invalid_grant", "error_description": "Want refresh token %s, got %s"}`, want, got)
+			return
+		}
+		// expire the tokens right away so that every call
+		// refreshes the token.
+		fmt.Fprintf(w, `{"access_token": "access-%d", "refresh_token": "refresh-%d", "expires_in": 1}`, n, n)
+	}))
+	defer server.Close()
+
+	r := &Refresher{
+		Endpoint: server.URL,
+		Source: StaticTokenSource(&scm.Token{
+			Refresh: "refresh-0",
+		}),
+	}
+	for i := 1; i <= 3; i++ {
+		token, err := r.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := token.Token, fmt.Sprintf("access-%d", i); got != want {
+			t.Errorf("Want access token %q, got %q", want, got)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces scrubbed secrets in recorded
+// interactions.
+const Redacted = "REDACTED"
+
+// RecorderMode defines whether a Recorder records or
+// replays interactions.
+type RecorderMode int
+
+// RecorderMode values.
+const (
+	// ModeReplay serves responses from the cassette and
+	// never sends requests.
+	ModeReplay RecorderMode = iota
+
+	// ModeRecord sends requests using the base transport
+	// and records the interactions to the cassette.
+	ModeRecord
+)
+
+// RecorderModeFromEnv returns ModeRecord if the
+// SCM_RECORD environment variable is set to true, and
+// ModeReplay otherwise.
+func RecorderModeFromEnv() RecorderMode {
+	if os.Getenv("SCM_RECORD") == "true" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// MatchField is a bit set of the request fields compared
+// when looking up a recorded interaction.
+type MatchField int
+
+// MatchField values.
+const (
+	MatchMethod MatchField = 1 << iota
+	MatchHost
+	MatchPath
+	MatchQuery
+	MatchBody
+
+	// DefaultMatch matches the method, path and query.
+	DefaultMatch = MatchMethod | MatchPath | MatchQuery
+)
+
+// ErrInteractionNotFound is returned in replay mode when
+// no recorded interaction matches the request.
+var ErrInteractionNotFound = errors.New("no recorded interaction matches the request")
+
+// defaultScrubHeaders are the headers always removed from
+// recorded interactions.
+var defaultScrubHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Private-Token",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// defaultScrubParams are the query parameters always
+// redacted in recorded interactions.
+var defaultScrubParams = []string{
+	"access_token",
+	"client_secret",
+	"private_token",
+	"token",
+}
+
+type (
+	// Cassette holds the recorded interactions.
+	Cassette struct {
+		Interactions []*Interaction `yaml:"interactions"`
+	}
+
+	// Interaction is a recorded request and response.
+	Interaction struct {
+		Request  RecordedRequest  `yaml:"request"`
+		Response RecordedResponse `yaml:"response"`
+	}
+
+	// RecordedRequest is a recorded HTTP request.
+	RecordedRequest struct {
+		Method string      `yaml:"method"`
+		URL    string      `yaml:"url"`
+		Header http.Header `yaml:"header,omitempty"`
+		Body   string      `yaml:"body,omitempty"`
+	}
+
+	// RecordedResponse is a recorded HTTP response.
+	RecordedResponse struct {
+		Status int         `yaml:"status"`
+		Header http.Header `yaml:"header,omitempty"`
+		Body   string      `yaml:"body,omitempty"`
+	}
+)
+
+// Recorder is an http.RoundTripper that records HTTP
+// interactions to a cassette file and replays them, so
+// that tests can run deterministically without network
+// access. Credentials are scrubbed from the recorded
+// interactions.
+//
+// In record mode every interaction is written to the
+// cassette as soon as it completes. In replay mode the
+// first unused interaction matching the request is
+// returned; once all matching interactions are used, the
+// last one is returned again.
+type Recorder struct {
+	Base http.RoundTripper
+
+	// Path is the path of the cassette file.
+	Path string
+
+	// Mode defines whether interactions are recorded or
+	// replayed.
+	Mode RecorderMode
+
+	// Match defines the request fields compared when
+	// replaying. Defaults to DefaultMatch.
+	Match MatchField
+
+	// ScrubHeaders are additional headers removed from
+	// recorded interactions.
+	ScrubHeaders []string
+
+	// Secrets are values, such as tokens, replaced with
+	// Redacted anywhere in recorded interactions.
+	Secrets []string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     map[*Interaction]bool
+}
+
+// NewRecorder returns a new Recorder for the cassette
+// file and mode.
+func NewRecorder(path string, mode RecorderMode) *Recorder {
+	return &Recorder{Path: path, Mode: mode}
+}
+
+// RoundTrip records or replays the request.
+func (t *Recorder) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	if t.Mode == ModeRecord {
+		return t.record(r, body)
+	}
+	return t.replay(r, body)
+}
+
+// record sends the request and records the interaction.
+func (t *Recorder) record(r *http.Request, body []byte) (*http.Response, error) {
+	r2 := cloneRequest(r)
+	if body != nil {
+		r2.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	res, err := t.base().RoundTrip(r2)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: r.Method,
+			URL:    t.scrubURL(r.URL),
+			Header: t.scrubHeader(r.Header),
+			Body:   t.scrub(string(body)),
+		},
+		Response: RecordedResponse{
+			Status: res.StatusCode,
+			Header: t.scrubHeader(res.Header),
+			Body:   t.scrub(string(resBody)),
+		},
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cassette == nil {
+		t.cassette = new(Cassette)
+	}
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// replay returns the recorded response matching the
+// request.
+func (t *Recorder) replay(r *http.Request, body []byte) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cassette == nil {
+		cassette, err := LoadCassette(t.Path)
+		if err != nil {
+			return nil, err
+		}
+		t.cassette = cassette
+		t.used = map[*Interaction]bool{}
+	}
+
+	var found *Interaction
+	for _, interaction := range t.cassette.Interactions {
+		if !t.matches(r, body, &interaction.Request) {
+			continue
+		}
+		found = interaction
+		if !t.used[interaction] {
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, r.Method, t.scrubURL(r.URL))
+	}
+	t.used[found] = true
+
+	header := found.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", found.Response.Status, http.StatusText(found.Response.Status)),
+		StatusCode:    found.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(found.Response.Body)),
+		ContentLength: int64(len(found.Response.Body)),
+		Request:       r,
+	}, nil
+}
+
+// matches reports whether the request matches the
+// recorded request.
+func (t *Recorder) matches(r *http.Request, body []byte, recorded *RecordedRequest) bool {
+	match := t.Match
+	if match == 0 {
+		match = DefaultMatch
+	}
+	u, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	actual, err := url.Parse(t.scrubURL(r.URL))
+	if err != nil {
+		return false
+	}
+	if match&MatchMethod != 0 && !strings.EqualFold(r.Method, recorded.Method) {
+		return false
+	}
+	if match&MatchHost != 0 && actual.Host != u.Host {
+		return false
+	}
+	if match&MatchPath != 0 && actual.EscapedPath() != u.EscapedPath() {
+		return false
+	}
+	if match&MatchQuery != 0 && actual.Query().Encode() != u.Query().Encode() {
+		return false
+	}
+	if match&MatchBody != 0 && t.scrub(string(body)) != recorded.Body {
+		return false
+	}
+	return true
+}
+
+// save writes the cassette to the file. The caller must
+// hold the lock.
+func (t *Recorder) save() error {
+	if err := os.MkdirAll(filepath.Dir(t.Path), 0o755); err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(t.cassette)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.Path, b, 0o600)
+}
+
+// scrubHeader returns a copy of the header without the
+// scrubbed headers and with secrets redacted.
+func (t *Recorder) scrubHeader(h http.Header) http.Header {
+	out := http.Header{}
+	for k, values := range h {
+		for _, v := range values {
+			out.Add(k, t.scrub(v))
+		}
+	}
+	for _, k := range defaultScrubHeaders {
+		out.Del(k)
+	}
+	for _, k := range t.ScrubHeaders {
+		out.Del(k)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// scrubURL returns the URL with credentials removed and
+// secrets redacted.
+func (t *Recorder) scrubURL(u *url.URL) string {
+	u2 := *u
+	u2.User = nil
+	if u2.Opaque != "" {
+		// the client sends the escaped paths, such as the
+		// encoded slashes of GitLab projects, as opaque.
+		u2.RawPath = u2.Opaque
+		u2.Path, _ = url.PathUnescape(u2.Opaque)
+		u2.Opaque = ""
+	}
+	query := u2.Query()
+	for _, k := range defaultScrubParams {
+		if query.Has(k) {
+			query.Set(k, Redacted)
+		}
+	}
+	u2.RawQuery = query.Encode()
+	return t.scrub(u2.String())
+}
+
+// scrub replaces the secrets in s.
+func (t *Recorder) scrub(s string) string {
+	for _, secret := range t.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return s
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Recorder) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// LoadCassette reads the cassette from the file.
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := new(Cassette)
+	if err := yaml.Unmarshal(b, cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// readBody reads and restores the request body.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
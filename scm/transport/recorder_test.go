@@ -0,0 +1,147 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Request-Id", "abc")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = io.WriteString(w, `[{"login":"page2"}]`)
+			return
+		}
+		if n > 1 {
+			_, _ = io.WriteString(w, `[{"login":"changed"}]`)
+			return
+		}
+		_, _ = io.WriteString(w, `[{"login":"octocat","token":"s3cr3t"}]`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.yaml")
+	recorder := &Recorder{
+		Base:    &BearerToken{Token: "s3cr3t"},
+		Path:    path,
+		Mode:    ModeRecord,
+		Secrets: []string{"s3cr3t"},
+	}
+	client := &http.Client{Transport: recorder}
+
+	_, body := get(t, client, server.URL+"/users?page=1&access_token=s3cr3t", "")
+	if got, want := body, `[{"login":"octocat","token":"s3cr3t"}]`; got != want {
+		t.Errorf("Want recorded body %q, got %q", want, got)
+	}
+	get(t, client, server.URL+"/users?page=1&access_token=s3cr3t", "")
+	get(t, client, server.URL+"/users?page=2", "")
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"s3cr3t", "Bearer", "session"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("Want %q scrubbed from the cassette", secret)
+		}
+	}
+
+	// replay the interactions without the server.
+	server.Close()
+	replay := &http.Client{Transport: NewRecorder(path, ModeReplay)}
+
+	res, body := get(t, replay, server.URL+"/users?page=1&access_token=other", "")
+	if got, want := body, `[{"login":"octocat","token":"REDACTED"}]`; got != want {
+		t.Errorf("Want replayed body %q, got %q", want, got)
+	}
+	if got, want := res.Header.Get("X-Request-Id"), "abc"; got != want {
+		t.Errorf("Want header %q, got %q", want, got)
+	}
+	_, body = get(t, replay, server.URL+"/users?page=1&access_token=other", "")
+	if got, want := body, `[{"login":"changed"}]`; got != want {
+		t.Errorf("Want second interaction %q, got %q", want, got)
+	}
+	// the last matching interaction is reused.
+	_, body = get(t, replay, server.URL+"/users?page=1&access_token=other", "")
+	if got, want := body, `[{"login":"changed"}]`; got != want {
+		t.Errorf("Want last interaction %q, got %q", want, got)
+	}
+	_, body = get(t, replay, server.URL+"/users?page=2", "")
+	if got, want := body, `[{"login":"page2"}]`; got != want {
+		t.Errorf("Want page interaction %q, got %q", want, got)
+	}
+
+	req, _ := http.NewRequest("DELETE", server.URL+"/users", http.NoBody)
+	_, err = replay.Do(req)
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("Want ErrInteractionNotFound, got %v", err)
+	}
+}
+
+func TestRecorder_MatchBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.yaml")
+	client := &http.Client{Transport: NewRecorder(path, ModeRecord)}
+	for _, body := range []string{`{"title":"a"}`, `{"title":"b"}`} {
+		res, err := client.Post(server.URL+"/issues", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	recorder := NewRecorder(path, ModeReplay)
+	recorder.Match = DefaultMatch | MatchBody
+	replay := &http.Client{Transport: recorder}
+	res, err := replay.Post(server.URL+"/issues", "application/json", strings.NewReader(`{"title":"b"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	if got, want := string(b), `{"title":"b"}`; got != want {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+
+	_, err = replay.Post(server.URL+"/issues", "application/json", strings.NewReader(`{"title":"c"}`))
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("Want ErrInteractionNotFound, got %v", err)
+	}
+}
+
+func TestRecorder_MissingCassette(t *testing.T) {
+	client := &http.Client{Transport: NewRecorder(filepath.Join(t.TempDir(), "missing.yaml"), ModeReplay)}
+	_, err := client.Get("https://api.github.com/user")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Want os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestRecorder_OpaqueURL(t *testing.T) {
+	recorder := NewRecorder("", ModeReplay)
+	u, err := url.Parse("https://gitlab.com/api/v4/projects/diaspora%2Fdiaspora")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the client sends the escaped paths as opaque URLs.
+	u.Opaque = u.RawPath
+	if got, want := recorder.scrubURL(u), "https://gitlab.com/api/v4/projects/diaspora%2Fdiaspora"; got != want {
+		t.Errorf("Want URL %q, got %q", want, got)
+	}
+}
@@ -5,6 +5,7 @@
 package scm
 
 import (
+	"errors"
 	"strings"
 )
 
@@ -92,6 +93,9 @@ func ConvertStatusInputToStatus(input *StatusInput) *Status {
 
 // IsScmNotFound returns true if the resource is not found
 func IsScmNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
 	if err != nil {
 		// I think that we should instead rely on the http status (404)
 		// until jenkins-x go-scm is updated t return that in the error this works for github and gitlab
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"sync"
+)
+
+// Deduplicator records the deliveries of the webhooks being
+// handled or handled, so that the redeliveries of a webhook
+// are ignored.
+//
+// A Deduplicator must be safe for concurrent use by multiple
+// goroutines.
+type Deduplicator interface {
+	// Add records the delivery and reports whether it was
+	// not already recorded.
+	Add(id string) bool
+
+	// Remove forgets the delivery, so that the redelivery
+	// of a webhook whose handling failed is handled again.
+	Remove(id string)
+}
+
+// memoryDeduplicator records the last deliveries in memory.
+type memoryDeduplicator struct {
+	mu   sync.Mutex
+	size int
+	ids  map[string]bool
+	ring []string
+	next int
+}
+
+// NewMemoryDeduplicator returns a Deduplicator recording the
+// last size deliveries in memory.
+func NewMemoryDeduplicator(size int) Deduplicator {
+	return &memoryDeduplicator{
+		size: size,
+		ids:  map[string]bool{},
+		ring: make([]string, size),
+	}
+}
+
+func (d *memoryDeduplicator) Add(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size <= 0 {
+		return true
+	}
+	if d.ids[id] {
+		return false
+	}
+	// forget the oldest delivery to make room
+	delete(d.ids, d.ring[d.next])
+	d.ring[d.next] = id
+	d.next = (d.next + 1) % d.size
+	d.ids[id] = true
+	return true
+}
+
+func (d *memoryDeduplicator) Remove(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.ids[id] {
+		return
+	}
+	delete(d.ids, id)
+	for i, recorded := range d.ring {
+		if recorded == id {
+			d.ring[i] = ""
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import "net/http"
+
+// header identifies the webhooks of a driver by their event
+// header, and holds the header of their delivery GUID.
+type header struct {
+	driver   string
+	event    string
+	delivery string
+}
+
+// headers the headers of the drivers, in the order they are
+// checked: Gitea also sends the Gogs and GitHub headers and
+// Gogs the GitHub headers.
+var headers = []header{
+	{driver: "gitea", event: "X-Gitea-Event", delivery: "X-Gitea-Delivery"},
+	{driver: "gogs", event: "X-Gogs-Event", delivery: "X-Gogs-Delivery"},
+	{driver: "github", event: "X-GitHub-Event", delivery: "X-GitHub-Delivery"},
+	{driver: "gitlab", event: "X-Gitlab-Event", delivery: "X-Gitlab-Event-UUID"},
+}
+
+// DetectDriver returns the driver of the webhook from the
+// headers of its request, or an empty string if unknown.
+func DetectDriver(h http.Header) string {
+	for _, header := range headers {
+		if h.Get(header.event) != "" {
+			return header.driver
+		}
+	}
+	if h.Get("X-Event-Key") != "" {
+		// Bitbucket Cloud and Server share the event header,
+		// only the former sends the UUID of the hook.
+		if h.Get("X-Hook-UUID") != "" {
+			return "bitbucketcloud"
+		}
+		return "bitbucketserver"
+	}
+	return ""
+}
+
+// DeliveryID returns the GUID of the delivery of the webhook
+// of the driver, which is the same for the redeliveries of
+// the webhook, or an empty string if unknown.
+func DeliveryID(driver string, h http.Header) string {
+	for _, header := range headers {
+		if header.driver == driver {
+			return h.Get(header.delivery)
+		}
+	}
+	switch driver {
+	case "bitbucket", "bitbucketcloud":
+		return h.Get("X-Request-UUID")
+	case "stash", "bitbucketserver":
+		return h.Get("X-Request-Id")
+	}
+	return ""
+}
@@ -0,0 +1,398 @@
+// Package webhook provides an http.Handler receiving the
+// webhooks of any driver, which parses and validates them
+// and dispatches them to typed callbacks.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+)
+
+// DefaultDeliveries is the number of the last deliveries
+// recorded by the Deduplicator of NewHandler.
+const DefaultDeliveries = 10000
+
+var (
+	// ErrUnknownDriver is returned when the driver of a
+	// webhook cannot be detected from its headers.
+	ErrUnknownDriver = errors.New("unknown webhook driver")
+
+	// ErrMethodNotAllowed is returned for the requests
+	// which are not a POST.
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type (
+	// Event describes a webhook request received by the
+	// Handler.
+	Event struct {
+		// Driver is the driver of the webhook.
+		Driver string
+
+		// DeliveryID is the GUID of the delivery of the
+		// webhook, if the driver sends one.
+		DeliveryID string
+
+		// Request is the webhook request. Its body is
+		// consumed by the Handler.
+		Request *http.Request
+
+		// Webhook is the parsed webhook, or nil if the
+		// request was rejected or the event is not
+		// supported by the driver.
+		Webhook scm.Webhook
+
+		// Duplicate reports whether the webhook is a
+		// redelivery of a webhook already handled, in
+		// which case it is not dispatched.
+		Duplicate bool
+
+		// Start is the time the request was received.
+		Start time.Time
+
+		// Duration is the time it took to handle the
+		// request.
+		Duration time.Duration
+
+		// Status is the status of the response. OnError
+		// callbacks may change it, for example so that the
+		// provider does not redeliver a webhook.
+		Status int
+
+		// Err is the error rejecting the request or
+		// returned by a callback.
+		Err error
+	}
+
+	// Middleware holds optional callbacks invoked around
+	// the handling of every webhook request. It can be used
+	// to plug in logging, metrics or error reporting.
+	Middleware struct {
+		// BeforeHandle is called when the webhook is parsed
+		// and validated, before it is dispatched.
+		BeforeHandle func(ctx context.Context, event *Event)
+
+		// AfterHandle is called when the request is
+		// handled, before the response is written,
+		// including rejected requests.
+		AfterHandle func(ctx context.Context, event *Event)
+
+		// OnError is called when the request is rejected
+		// or a callback fails.
+		OnError func(ctx context.Context, event *Event)
+	}
+)
+
+// Handler is an http.Handler receiving the webhooks of any
+// driver. It parses and validates the webhooks, ignores the
+// redeliveries and dispatches them to the callbacks matching
+// their type, in the order they were registered.
+//
+// It responds with:
+//   - 200 when the webhook is handled, is a redelivery or is
+//     an event the driver does not support,
+//   - 400 when the request is not a webhook of the driver,
+//   - 401 when the signature of the webhook is invalid,
+//   - 405 when the request is not a POST,
+//   - 500 when a callback fails, so that the provider
+//     redelivers the webhook.
+//
+// The body of the error responses is the status text, the
+// errors are only passed to the OnError middleware.
+//
+// The callbacks and middleware must be registered before
+// the Handler serves requests.
+type Handler struct {
+	// Driver is the driver of the webhooks, detected from
+	// the headers of the requests if empty. The fake
+	// drivers are never detected, their webhooks are only
+	// accepted if Driver is set to one of them.
+	Driver string
+
+	// Secret returns the secret validating the webhook.
+	// The webhooks are not validated if it is nil or
+	// returns an empty secret.
+	Secret scm.SecretFunc
+
+	// Deduplicator ignores the redeliveries of the
+	// webhooks. They are all dispatched if it is nil.
+	Deduplicator Deduplicator
+
+	middleware []Middleware
+	callbacks  []func(ctx context.Context, hook scm.Webhook) error
+}
+
+// NewHandler returns a new Handler validating the webhooks
+// with the secret function, which ignores the redeliveries
+// of the last DefaultDeliveries webhooks.
+func NewHandler(secret scm.SecretFunc) *Handler {
+	return &Handler{
+		Secret:       secret,
+		Deduplicator: NewMemoryDeduplicator(DefaultDeliveries),
+	}
+}
+
+// Use appends the middleware to the chain of middleware
+// invoked around every request. Middleware are invoked in
+// the order they were added.
+func (h *Handler) Use(middleware ...Middleware) {
+	h.middleware = append(h.middleware, middleware...)
+}
+
+// ServeHTTP handles the webhook request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	event := &Event{
+		Request: r,
+		Start:   time.Now(),
+		Status:  http.StatusOK,
+	}
+	event.Err = h.handle(ctx, event)
+	event.Duration = time.Since(event.Start)
+	for _, m := range h.middleware {
+		if event.Err != nil && m.OnError != nil {
+			m.OnError(ctx, event)
+		}
+	}
+	for _, m := range h.middleware {
+		if m.AfterHandle != nil {
+			m.AfterHandle(ctx, event)
+		}
+	}
+
+	if event.Err != nil && event.Status >= http.StatusBadRequest {
+		http.Error(w, http.StatusText(event.Status), event.Status)
+		return
+	}
+	w.WriteHeader(event.Status)
+}
+
+// handle parses and dispatches the webhook of the event,
+// setting the status of the response.
+func (h *Handler) handle(ctx context.Context, event *Event) error {
+	r := event.Request
+	if r.Method != http.MethodPost {
+		event.Status = http.StatusMethodNotAllowed
+		return ErrMethodNotAllowed
+	}
+	event.Driver = h.Driver
+	if event.Driver == "" {
+		event.Driver = DetectDriver(r.Header)
+	}
+	if event.Driver == "" {
+		event.Status = http.StatusBadRequest
+		return ErrUnknownDriver
+	}
+	service, err := factory.NewWebHookService(event.Driver)
+	if err != nil {
+		event.Status = http.StatusBadRequest
+		return err
+	}
+	event.DeliveryID = DeliveryID(event.Driver, r.Header)
+
+	hook, err := service.Parse(r, h.secret)
+	switch {
+	case errors.Is(err, scm.ErrSignatureInvalid):
+		event.Status = http.StatusUnauthorized
+		return err
+	case scm.IsUnknownWebhook(err) || errors.Is(err, scm.ErrUnknownEvent):
+		return nil
+	case err != nil:
+		event.Status = http.StatusBadRequest
+		return err
+	case hook == nil:
+		return nil
+	}
+	event.Webhook = hook
+
+	id := event.Driver + "/" + event.DeliveryID
+	dedupe := h.Deduplicator != nil && event.DeliveryID != ""
+	if dedupe && !h.Deduplicator.Add(id) {
+		event.Duplicate = true
+		return nil
+	}
+	for _, m := range h.middleware {
+		if m.BeforeHandle != nil {
+			m.BeforeHandle(ctx, event)
+		}
+	}
+	if err := h.dispatch(ctx, hook); err != nil {
+		if dedupe {
+			h.Deduplicator.Remove(id)
+		}
+		event.Status = http.StatusInternalServerError
+		return err
+	}
+	return nil
+}
+
+// secret returns the secret of the webhook, none if the
+// handler has no secret function.
+func (h *Handler) secret(hook scm.Webhook) (string, error) {
+	if h.Secret == nil {
+		return "", nil
+	}
+	return h.Secret(hook)
+}
+
+// dispatch passes the webhook to the matching callbacks,
+// until one fails or panics.
+func (h *Handler) dispatch(ctx context.Context, hook scm.Webhook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic handling %s webhook: %v", hook.Kind(), r)
+		}
+	}()
+	for _, callback := range h.callbacks {
+		if err := callback(ctx, hook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// on registers the callback of the webhooks of type T.
+func on[T scm.Webhook](h *Handler, fn func(context.Context, T) error) {
+	h.callbacks = append(h.callbacks, func(ctx context.Context, hook scm.Webhook) error {
+		if t, ok := hook.(T); ok {
+			return fn(ctx, t)
+		}
+		return nil
+	})
+}
+
+// OnWebhook registers a callback of all the webhooks.
+func (h *Handler) OnWebhook(fn func(context.Context, scm.Webhook) error) {
+	on(h, fn)
+}
+
+// OnBranch registers a callback of the branch webhooks.
+func (h *Handler) OnBranch(fn func(context.Context, *scm.BranchHook) error) {
+	on(h, fn)
+}
+
+// OnCheckRun registers a callback of the check run webhooks.
+func (h *Handler) OnCheckRun(fn func(context.Context, *scm.CheckRunHook) error) {
+	on(h, fn)
+}
+
+// OnCheckSuite registers a callback of the check suite
+// webhooks.
+func (h *Handler) OnCheckSuite(fn func(context.Context, *scm.CheckSuiteHook) error) {
+	on(h, fn)
+}
+
+// OnDeploy registers a callback of the deployment webhooks.
+func (h *Handler) OnDeploy(fn func(context.Context, *scm.DeployHook) error) {
+	on(h, fn)
+}
+
+// OnDeploymentStatus registers a callback of the deployment
+// status webhooks.
+func (h *Handler) OnDeploymentStatus(fn func(context.Context, *scm.DeploymentStatusHook) error) {
+	on(h, fn)
+}
+
+// OnFork registers a callback of the fork webhooks.
+func (h *Handler) OnFork(fn func(context.Context, *scm.ForkHook) error) {
+	on(h, fn)
+}
+
+// OnInstallation registers a callback of the app
+// installation webhooks.
+func (h *Handler) OnInstallation(fn func(context.Context, *scm.InstallationHook) error) {
+	on(h, fn)
+}
+
+// OnInstallationRepository registers a callback of the app
+// installation in a repository webhooks.
+func (h *Handler) OnInstallationRepository(fn func(context.Context, *scm.InstallationRepositoryHook) error) {
+	on(h, fn)
+}
+
+// OnIssue registers a callback of the issue webhooks.
+func (h *Handler) OnIssue(fn func(context.Context, *scm.IssueHook) error) {
+	on(h, fn)
+}
+
+// OnIssueComment registers a callback of the issue comment
+// webhooks.
+func (h *Handler) OnIssueComment(fn func(context.Context, *scm.IssueCommentHook) error) {
+	on(h, fn)
+}
+
+// OnLabel registers a callback of the label webhooks.
+func (h *Handler) OnLabel(fn func(context.Context, *scm.LabelHook) error) {
+	on(h, fn)
+}
+
+// OnPing registers a callback of the ping webhooks.
+func (h *Handler) OnPing(fn func(context.Context, *scm.PingHook) error) {
+	on(h, fn)
+}
+
+// OnPullRequest registers a callback of the pull request
+// webhooks.
+func (h *Handler) OnPullRequest(fn func(context.Context, *scm.PullRequestHook) error) {
+	on(h, fn)
+}
+
+// OnPullRequestComment registers a callback of the pull
+// request comment webhooks.
+func (h *Handler) OnPullRequestComment(fn func(context.Context, *scm.PullRequestCommentHook) error) {
+	on(h, fn)
+}
+
+// OnPush registers a callback of the push webhooks.
+func (h *Handler) OnPush(fn func(context.Context, *scm.PushHook) error) {
+	on(h, fn)
+}
+
+// OnRelease registers a callback of the release webhooks.
+func (h *Handler) OnRelease(fn func(context.Context, *scm.ReleaseHook) error) {
+	on(h, fn)
+}
+
+// OnRepository registers a callback of the repository
+// webhooks.
+func (h *Handler) OnRepository(fn func(context.Context, *scm.RepositoryHook) error) {
+	on(h, fn)
+}
+
+// OnReview registers a callback of the review webhooks.
+func (h *Handler) OnReview(fn func(context.Context, *scm.ReviewHook) error) {
+	on(h, fn)
+}
+
+// OnReviewComment registers a callback of the review comment
+// webhooks.
+func (h *Handler) OnReviewComment(fn func(context.Context, *scm.ReviewCommentHook) error) {
+	on(h, fn)
+}
+
+// OnStar registers a callback of the star webhooks.
+func (h *Handler) OnStar(fn func(context.Context, *scm.StarHook) error) {
+	on(h, fn)
+}
+
+// OnStatus registers a callback of the commit status
+// webhooks.
+func (h *Handler) OnStatus(fn func(context.Context, *scm.StatusHook) error) {
+	on(h, fn)
+}
+
+// OnTag registers a callback of the tag webhooks.
+func (h *Handler) OnTag(fn func(context.Context, *scm.TagHook) error) {
+	on(h, fn)
+}
+
+// OnWatch registers a callback of the watch webhooks.
+func (h *Handler) OnWatch(fn func(context.Context, *scm.WatchHook) error) {
+	on(h, fn)
+}
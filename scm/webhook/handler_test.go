@@ -0,0 +1,191 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "s3cr3t"
+
+func secretFunc(scm.Webhook) (string, error) {
+	return secret, nil
+}
+
+func TestHandler(t *testing.T) {
+	payload, err := os.ReadFile("../driver/github/testdata/webhooks/pr_opened.json")
+	require.NoError(t, err)
+
+	handler := webhook.NewHandler(secretFunc)
+	var prs []*scm.PullRequestHook
+	fail := false
+	handler.OnPullRequest(func(_ context.Context, hook *scm.PullRequestHook) error {
+		if fail {
+			return errors.New("boom")
+		}
+		prs = append(prs, hook)
+		return nil
+	})
+	handler.OnPush(func(context.Context, *scm.PushHook) error {
+		t.Error("Want the push callback not called")
+		return nil
+	})
+	var events []*webhook.Event
+	var errs []error
+	handler.Use(webhook.Middleware{
+		AfterHandle: func(_ context.Context, event *webhook.Event) {
+			events = append(events, event)
+		},
+		OnError: func(_ context.Context, event *webhook.Event) {
+			errs = append(errs, event.Err)
+		},
+	})
+
+	post := func(event, delivery, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(payload))
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		req.Header.Set("X-Hub-Signature", signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	signature := "sha256=" + sign(payload)
+
+	assert.Equal(t, http.StatusOK, post("pull_request", "1", signature))
+	require.Len(t, prs, 1)
+	assert.Equal(t, scm.ActionOpen, prs[0].Action)
+	assert.Equal(t, "github", events[0].Driver)
+	assert.Equal(t, "1", events[0].DeliveryID)
+	assert.Same(t, prs[0], events[0].Webhook)
+
+	assert.Equal(t, http.StatusOK, post("pull_request", "1", signature), "Want a redelivery acknowledged")
+	assert.Len(t, prs, 1, "Want a redelivery not dispatched")
+	assert.True(t, events[1].Duplicate)
+
+	assert.Equal(t, http.StatusUnauthorized, post("pull_request", "2", "sha256=bad"))
+	assert.Equal(t, http.StatusOK, post("unknown", "3", signature))
+	assert.Nil(t, events[3].Webhook)
+
+	fail = true
+	assert.Equal(t, http.StatusInternalServerError, post("pull_request", "4", signature))
+	req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-GitHub-Delivery", "5")
+	req.Header.Set("X-Hub-Signature", signature)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "Internal Server Error\n", rec.Body.String(), "Want the error of the callback not written to the provider")
+	fail = false
+	assert.Equal(t, http.StatusOK, post("pull_request", "4", signature), "Want a failed webhook handled again")
+	assert.Len(t, prs, 2)
+
+	require.Len(t, errs, 3)
+	assert.ErrorIs(t, errs[0], scm.ErrSignatureInvalid)
+	assert.EqualError(t, errs[1], "boom")
+	assert.EqualError(t, errs[2], "boom")
+}
+
+func TestHandlerRejected(t *testing.T) {
+	handler := webhook.NewHandler(nil)
+	handler.Use(webhook.Middleware{
+		OnError: func(_ context.Context, event *webhook.Event) {
+			if errors.Is(event.Err, webhook.ErrMethodNotAllowed) {
+				event.Status = http.StatusNotFound
+			}
+		},
+	})
+
+	tests := []struct {
+		method string
+		header http.Header
+		want   int
+	}{
+		{http.MethodPost, http.Header{}, http.StatusBadRequest},
+		{http.MethodPost, http.Header{"X-Github-Event": {"push"}}, http.StatusBadRequest},
+		{http.MethodPost, http.Header{"X-Fake-Event": {"push"}}, http.StatusBadRequest},
+		{http.MethodGet, http.Header{}, http.StatusNotFound},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/hook", bytes.NewReader([]byte("{}")))
+		req.Header = test.header
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, test.want, rec.Code, "%s %v", test.method, test.header)
+	}
+}
+
+func TestHandlerFake(t *testing.T) {
+	client, _ := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	handler := webhook.NewHandler(secretFunc)
+	handler.Driver = "fake"
+	var comments []*scm.IssueCommentHook
+	handler.OnIssueComment(func(_ context.Context, hook *scm.IssueCommentHook) error {
+		comments = append(comments, hook)
+		return nil
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	_, _, err := client.Repositories.CreateHook(ctx, repo, &scm.HookInput{Target: server.URL, Secret: secret})
+	require.NoError(t, err)
+	issue, _, err := client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Write docs"})
+	require.NoError(t, err)
+	_, _, err = client.Issues.CreateComment(ctx, repo, issue.Number, &scm.CommentInput{Body: "/assign"})
+	require.NoError(t, err)
+
+	require.Len(t, comments, 1)
+	assert.Equal(t, "/assign", comments[0].Comment.Body)
+	assert.Equal(t, repo, comments[0].Repo.FullName)
+}
+
+func TestDetectDriver(t *testing.T) {
+	tests := []struct {
+		header http.Header
+		want   string
+	}{
+		{http.Header{"X-Github-Event": {"push"}}, "github"},
+		{http.Header{"X-Github-Event": {"push"}, "X-Gitea-Event": {"push"}, "X-Gogs-Event": {"push"}}, "gitea"},
+		{http.Header{"X-Github-Event": {"push"}, "X-Gogs-Event": {"push"}}, "gogs"},
+		{http.Header{"X-Gitlab-Event": {"Push Hook"}}, "gitlab"},
+		{http.Header{"X-Event-Key": {"repo:push"}, "X-Hook-Uuid": {"1"}}, "bitbucketcloud"},
+		{http.Header{"X-Event-Key": {"repo:refs_changed"}}, "bitbucketserver"},
+		{http.Header{}, ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, webhook.DetectDriver(test.header), "%v", test.header)
+	}
+}
+
+func TestMemoryDeduplicator(t *testing.T) {
+	d := webhook.NewMemoryDeduplicator(2)
+	assert.True(t, d.Add("a"))
+	assert.False(t, d.Add("a"))
+	assert.True(t, d.Add("b"))
+	assert.True(t, d.Add("c"), "Want the oldest delivery forgotten")
+	assert.True(t, d.Add("a"))
+	d.Remove("c")
+	assert.True(t, d.Add("c"))
+}
+
+func sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}